@@ -2118,6 +2118,8 @@ func (m *ListUserExampleReply) validate(all bool) error {
 
 	}
 
+	// no validation rules for NextCursor
+
 	if len(errors) > 0 {
 		return ListUserExampleReplyMultiError(errors)
 	}
@@ -2197,3 +2199,717 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ListUserExampleReplyValidationError{}
+
+// Validate checks the field values on RestoreUserExampleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreUserExampleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreUserExampleRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreUserExampleRequestMultiError, or nil if none found.
+func (m *RestoreUserExampleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreUserExampleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() < 1 {
+		err := RestoreUserExampleRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than or equal to 1",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RestoreUserExampleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreUserExampleRequestMultiError is an error wrapping multiple validation
+// errors returned by RestoreUserExampleRequest.ValidateAll() if the
+// designated constraints aren't met.
+type RestoreUserExampleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreUserExampleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreUserExampleRequestMultiError) AllErrors() []error { return m }
+
+// RestoreUserExampleRequestValidationError is the validation error returned by
+// RestoreUserExampleRequest.Validate if the designated constraints aren't met.
+type RestoreUserExampleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreUserExampleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreUserExampleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreUserExampleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreUserExampleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreUserExampleRequestValidationError) ErrorName() string {
+	return "RestoreUserExampleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreUserExampleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreUserExampleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreUserExampleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreUserExampleRequestValidationError{}
+
+// Validate checks the field values on RestoreUserExampleReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreUserExampleReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreUserExampleReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreUserExampleReplyMultiError, or nil if none found.
+func (m *RestoreUserExampleReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreUserExampleReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return RestoreUserExampleReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreUserExampleReplyMultiError is an error wrapping multiple validation
+// errors returned by RestoreUserExampleReply.ValidateAll() if the designated
+// constraints aren't met.
+type RestoreUserExampleReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreUserExampleReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreUserExampleReplyMultiError) AllErrors() []error { return m }
+
+// RestoreUserExampleReplyValidationError is the validation error returned by
+// RestoreUserExampleReply.Validate if the designated constraints aren't met.
+type RestoreUserExampleReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreUserExampleReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreUserExampleReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreUserExampleReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreUserExampleReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreUserExampleReplyValidationError) ErrorName() string {
+	return "RestoreUserExampleReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreUserExampleReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreUserExampleReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreUserExampleReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreUserExampleReplyValidationError{}
+
+// Validate checks the field values on ListDeletedUserExampleRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDeletedUserExampleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDeletedUserExampleRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListDeletedUserExampleRequestMultiError, or nil if none found.
+func (m *ListDeletedUserExampleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDeletedUserExampleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetParams() == nil {
+		err := ListDeletedUserExampleRequestValidationError{
+			field:  "Params",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetParams()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListDeletedUserExampleRequestValidationError{
+					field:  "Params",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListDeletedUserExampleRequestValidationError{
+					field:  "Params",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetParams()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListDeletedUserExampleRequestValidationError{
+				field:  "Params",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ListDeletedUserExampleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDeletedUserExampleRequestMultiError is an error wrapping multiple
+// validation errors returned by ListDeletedUserExampleRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ListDeletedUserExampleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDeletedUserExampleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDeletedUserExampleRequestMultiError) AllErrors() []error { return m }
+
+// ListDeletedUserExampleRequestValidationError is the validation error
+// returned by ListDeletedUserExampleRequest.Validate if the designated
+// constraints aren't met.
+type ListDeletedUserExampleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDeletedUserExampleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDeletedUserExampleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDeletedUserExampleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDeletedUserExampleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDeletedUserExampleRequestValidationError) ErrorName() string {
+	return "ListDeletedUserExampleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDeletedUserExampleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDeletedUserExampleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDeletedUserExampleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDeletedUserExampleRequestValidationError{}
+
+// Validate checks the field values on ListDeletedUserExampleReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDeletedUserExampleReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDeletedUserExampleReply with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDeletedUserExampleReplyMultiError, or nil if none found.
+func (m *ListDeletedUserExampleReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDeletedUserExampleReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Total
+
+	for idx, item := range m.GetUserExamples() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListDeletedUserExampleReplyValidationError{
+						field:  fmt.Sprintf("UserExamples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListDeletedUserExampleReplyValidationError{
+						field:  fmt.Sprintf("UserExamples[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListDeletedUserExampleReplyValidationError{
+					field:  fmt.Sprintf("UserExamples[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextCursor
+
+	if len(errors) > 0 {
+		return ListDeletedUserExampleReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDeletedUserExampleReplyMultiError is an error wrapping multiple
+// validation errors returned by ListDeletedUserExampleReply.ValidateAll() if
+// the designated constraints aren't met.
+type ListDeletedUserExampleReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDeletedUserExampleReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDeletedUserExampleReplyMultiError) AllErrors() []error { return m }
+
+// ListDeletedUserExampleReplyValidationError is the validation error returned
+// by ListDeletedUserExampleReply.Validate if the designated constraints
+// aren't met.
+type ListDeletedUserExampleReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDeletedUserExampleReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDeletedUserExampleReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDeletedUserExampleReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDeletedUserExampleReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDeletedUserExampleReplyValidationError) ErrorName() string {
+	return "ListDeletedUserExampleReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDeletedUserExampleReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDeletedUserExampleReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDeletedUserExampleReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDeletedUserExampleReplyValidationError{}
+
+// Validate checks the field values on PurgeUserExampleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PurgeUserExampleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PurgeUserExampleRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PurgeUserExampleRequestMultiError, or nil if none found.
+func (m *PurgeUserExampleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PurgeUserExampleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetId() < 1 {
+		err := PurgeUserExampleRequestValidationError{
+			field:  "Id",
+			reason: "value must be greater than or equal to 1",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return PurgeUserExampleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PurgeUserExampleRequestMultiError is an error wrapping multiple validation
+// errors returned by PurgeUserExampleRequest.ValidateAll() if the designated
+// constraints aren't met.
+type PurgeUserExampleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PurgeUserExampleRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PurgeUserExampleRequestMultiError) AllErrors() []error { return m }
+
+// PurgeUserExampleRequestValidationError is the validation error returned by
+// PurgeUserExampleRequest.Validate if the designated constraints aren't met.
+type PurgeUserExampleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PurgeUserExampleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PurgeUserExampleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PurgeUserExampleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PurgeUserExampleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PurgeUserExampleRequestValidationError) ErrorName() string {
+	return "PurgeUserExampleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PurgeUserExampleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPurgeUserExampleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PurgeUserExampleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PurgeUserExampleRequestValidationError{}
+
+// Validate checks the field values on PurgeUserExampleReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PurgeUserExampleReply) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PurgeUserExampleReply with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PurgeUserExampleReplyMultiError, or nil if none found.
+func (m *PurgeUserExampleReply) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PurgeUserExampleReply) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return PurgeUserExampleReplyMultiError(errors)
+	}
+
+	return nil
+}
+
+// PurgeUserExampleReplyMultiError is an error wrapping multiple validation
+// errors returned by PurgeUserExampleReply.ValidateAll() if the designated
+// constraints aren't met.
+type PurgeUserExampleReplyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PurgeUserExampleReplyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PurgeUserExampleReplyMultiError) AllErrors() []error { return m }
+
+// PurgeUserExampleReplyValidationError is the validation error returned by
+// PurgeUserExampleReply.Validate if the designated constraints aren't met.
+type PurgeUserExampleReplyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PurgeUserExampleReplyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PurgeUserExampleReplyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PurgeUserExampleReplyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PurgeUserExampleReplyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PurgeUserExampleReplyValidationError) ErrorName() string {
+	return "PurgeUserExampleReplyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PurgeUserExampleReplyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPurgeUserExampleReply.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PurgeUserExampleReplyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PurgeUserExampleReplyValidationError{}
@@ -38,6 +38,12 @@ type UserExampleClient interface {
 	ListByIDs(ctx context.Context, in *ListUserExampleByIDsRequest, opts ...grpc.CallOption) (*ListUserExampleByIDsReply, error)
 	// list of userExample by query parameters
 	List(ctx context.Context, in *ListUserExampleRequest, opts ...grpc.CallOption) (*ListUserExampleReply, error)
+	// restore a deleted userExample by id
+	Restore(ctx context.Context, in *RestoreUserExampleRequest, opts ...grpc.CallOption) (*RestoreUserExampleReply, error)
+	// list of deleted userExample by query parameters
+	ListDeleted(ctx context.Context, in *ListDeletedUserExampleRequest, opts ...grpc.CallOption) (*ListDeletedUserExampleReply, error)
+	// permanently delete a deleted userExample by id
+	Purge(ctx context.Context, in *PurgeUserExampleRequest, opts ...grpc.CallOption) (*PurgeUserExampleReply, error)
 }
 
 type userExampleClient struct {
@@ -120,6 +126,33 @@ func (c *userExampleClient) List(ctx context.Context, in *ListUserExampleRequest
 	return out, nil
 }
 
+func (c *userExampleClient) Restore(ctx context.Context, in *RestoreUserExampleRequest, opts ...grpc.CallOption) (*RestoreUserExampleReply, error) {
+	out := new(RestoreUserExampleReply)
+	err := c.cc.Invoke(ctx, "/api.serverNameExample.v1.userExample/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExampleClient) ListDeleted(ctx context.Context, in *ListDeletedUserExampleRequest, opts ...grpc.CallOption) (*ListDeletedUserExampleReply, error) {
+	out := new(ListDeletedUserExampleReply)
+	err := c.cc.Invoke(ctx, "/api.serverNameExample.v1.userExample/ListDeleted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userExampleClient) Purge(ctx context.Context, in *PurgeUserExampleRequest, opts ...grpc.CallOption) (*PurgeUserExampleReply, error) {
+	out := new(PurgeUserExampleReply)
+	err := c.cc.Invoke(ctx, "/api.serverNameExample.v1.userExample/Purge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserExampleServer is the server API for UserExample service.
 // All implementations must embed UnimplementedUserExampleServer
 // for forward compatibility
@@ -140,6 +173,12 @@ type UserExampleServer interface {
 	ListByIDs(context.Context, *ListUserExampleByIDsRequest) (*ListUserExampleByIDsReply, error)
 	// list of userExample by query parameters
 	List(context.Context, *ListUserExampleRequest) (*ListUserExampleReply, error)
+	// restore a deleted userExample by id
+	Restore(context.Context, *RestoreUserExampleRequest) (*RestoreUserExampleReply, error)
+	// list of deleted userExample by query parameters
+	ListDeleted(context.Context, *ListDeletedUserExampleRequest) (*ListDeletedUserExampleReply, error)
+	// permanently delete a deleted userExample by id
+	Purge(context.Context, *PurgeUserExampleRequest) (*PurgeUserExampleReply, error)
 	mustEmbedUnimplementedUserExampleServer()
 }
 
@@ -171,6 +210,15 @@ func (UnimplementedUserExampleServer) ListByIDs(context.Context, *ListUserExampl
 func (UnimplementedUserExampleServer) List(context.Context, *ListUserExampleRequest) (*ListUserExampleReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedUserExampleServer) Restore(context.Context, *RestoreUserExampleRequest) (*RestoreUserExampleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedUserExampleServer) ListDeleted(context.Context, *ListDeletedUserExampleRequest) (*ListDeletedUserExampleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeleted not implemented")
+}
+func (UnimplementedUserExampleServer) Purge(context.Context, *PurgeUserExampleRequest) (*PurgeUserExampleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
 func (UnimplementedUserExampleServer) mustEmbedUnimplementedUserExampleServer() {}
 
 // UnsafeUserExampleServer may be embedded to opt out of forward compatibility for this service.
@@ -328,6 +376,60 @@ func _UserExample_List_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserExample_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExampleServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.serverNameExample.v1.userExample/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExampleServer).Restore(ctx, req.(*RestoreUserExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExample_ListDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedUserExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExampleServer).ListDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.serverNameExample.v1.userExample/ListDeleted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExampleServer).ListDeleted(ctx, req.(*ListDeletedUserExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserExample_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserExampleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserExampleServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.serverNameExample.v1.userExample/Purge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserExampleServer).Purge(ctx, req.(*PurgeUserExampleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserExample_ServiceDesc is the grpc.ServiceDesc for UserExample service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -367,6 +469,18 @@ var UserExample_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _UserExample_List_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _UserExample_Restore_Handler,
+		},
+		{
+			MethodName: "ListDeleted",
+			Handler:    _UserExample_ListDeleted_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _UserExample_Purge_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/serverNameExample/v1/userExample.proto",
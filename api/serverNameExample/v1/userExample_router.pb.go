@@ -21,6 +21,9 @@ type UserExampleLogicer interface {
 	GetByID(ctx context.Context, req *GetUserExampleByIDRequest) (*GetUserExampleByIDReply, error)
 	List(ctx context.Context, req *ListUserExampleRequest) (*ListUserExampleReply, error)
 	ListByIDs(ctx context.Context, req *ListUserExampleByIDsRequest) (*ListUserExampleByIDsReply, error)
+	ListDeleted(ctx context.Context, req *ListDeletedUserExampleRequest) (*ListDeletedUserExampleReply, error)
+	Purge(ctx context.Context, req *PurgeUserExampleRequest) (*PurgeUserExampleReply, error)
+	Restore(ctx context.Context, req *RestoreUserExampleRequest) (*RestoreUserExampleReply, error)
 	UpdateByID(ctx context.Context, req *UpdateUserExampleByIDRequest) (*UpdateUserExampleByIDReply, error)
 }
 
@@ -131,6 +134,9 @@ func (r *userExampleRouter) register() {
 	r.iRouter.Handle("POST", "/api/v1/userExample/condition", r.withMiddleware("POST", "/api/v1/userExample/condition", r.GetByCondition_0)...)
 	r.iRouter.Handle("POST", "/api/v1/userExample/list/ids", r.withMiddleware("POST", "/api/v1/userExample/list/ids", r.ListByIDs_0)...)
 	r.iRouter.Handle("POST", "/api/v1/userExample/list", r.withMiddleware("POST", "/api/v1/userExample/list", r.List_0)...)
+	r.iRouter.Handle("PUT", "/api/v1/userExample/:id/restore", r.withMiddleware("PUT", "/api/v1/userExample/:id/restore", r.Restore_0)...)
+	r.iRouter.Handle("POST", "/api/v1/userExample/list/deleted", r.withMiddleware("POST", "/api/v1/userExample/list/deleted", r.ListDeleted_0)...)
+	r.iRouter.Handle("DELETE", "/api/v1/userExample/:id/purge", r.withMiddleware("DELETE", "/api/v1/userExample/:id/purge", r.Purge_0)...)
 
 }
 
@@ -386,3 +392,93 @@ func (r *userExampleRouter) List_0(c *gin.Context) {
 
 	r.iResponse.Success(c, out)
 }
+
+func (r *userExampleRouter) Restore_0(c *gin.Context) {
+	req := &RestoreUserExampleRequest{}
+	var err error
+
+	if err = c.ShouldBindUri(req); err != nil {
+		r.zapLog.Warn("ShouldBindUri error", zap.Error(err), middleware.GCtxRequestIDField(c))
+		r.iResponse.ParamError(c, err)
+		return
+	}
+
+	if err = c.ShouldBindJSON(req); err != nil {
+		r.zapLog.Warn("ShouldBindJSON error", zap.Error(err), middleware.GCtxRequestIDField(c))
+		r.iResponse.ParamError(c, err)
+		return
+	}
+
+	var ctx context.Context
+	if r.wrapCtxFn != nil {
+		ctx = r.wrapCtxFn(c)
+	} else {
+		ctx = c
+	}
+
+	out, err := r.iLogic.Restore(ctx, req)
+	if err != nil {
+		r.iResponse.Error(c, err)
+		return
+	}
+
+	r.iResponse.Success(c, out)
+}
+
+func (r *userExampleRouter) ListDeleted_0(c *gin.Context) {
+	req := &ListDeletedUserExampleRequest{}
+	var err error
+
+	if err = c.ShouldBindJSON(req); err != nil {
+		r.zapLog.Warn("ShouldBindJSON error", zap.Error(err), middleware.GCtxRequestIDField(c))
+		r.iResponse.ParamError(c, err)
+		return
+	}
+
+	var ctx context.Context
+	if r.wrapCtxFn != nil {
+		ctx = r.wrapCtxFn(c)
+	} else {
+		ctx = c
+	}
+
+	out, err := r.iLogic.ListDeleted(ctx, req)
+	if err != nil {
+		r.iResponse.Error(c, err)
+		return
+	}
+
+	r.iResponse.Success(c, out)
+}
+
+func (r *userExampleRouter) Purge_0(c *gin.Context) {
+	req := &PurgeUserExampleRequest{}
+	var err error
+
+	if err = c.ShouldBindUri(req); err != nil {
+		r.zapLog.Warn("ShouldBindUri error", zap.Error(err), middleware.GCtxRequestIDField(c))
+		r.iResponse.ParamError(c, err)
+		return
+	}
+
+	if err = c.ShouldBindQuery(req); err != nil {
+		r.zapLog.Warn("ShouldBindQuery error", zap.Error(err), middleware.GCtxRequestIDField(c))
+		r.iResponse.ParamError(c, err)
+		return
+	}
+
+	var ctx context.Context
+	if r.wrapCtxFn != nil {
+		ctx = r.wrapCtxFn(c)
+	} else {
+		ctx = c
+	}
+
+	out, err := r.iLogic.Purge(ctx, req)
+	if err != nil {
+		r.iResponse.Error(c, err)
+		return
+	}
+
+	r.iResponse.Success(c, out)
+}
@@ -96,6 +96,8 @@ func (m *Params) validate(all bool) error {
 
 	}
 
+	// no validation rules for Cursor
+
 	if len(errors) > 0 {
 		return ParamsMultiError(errors)
 	}
@@ -202,6 +204,40 @@ func (m *Column) validate(all bool) error {
 
 	// no validation rules for Logic
 
+	for idx, item := range m.GetGroup() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ColumnValidationError{
+						field:  fmt.Sprintf("Group[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ColumnValidationError{
+						field:  fmt.Sprintf("Group[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ColumnValidationError{
+					field:  fmt.Sprintf("Group[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return ColumnMultiError(errors)
 	}
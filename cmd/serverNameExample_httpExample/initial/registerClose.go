@@ -25,8 +25,15 @@ func RegisterClose(servers []app.IServer) []app.Close {
 		return model.CloseMysql()
 	})
 
+	// close the multi level caches before redis
+	if config.Get().App.CacheType == "multi" {
+		closes = append(closes, func() error {
+			return model.CloseCache()
+		})
+	}
+
 	// close redis
-	if config.Get().App.CacheType == "redis" {
+	if config.Get().App.CacheType == "redis" || config.Get().App.CacheType == "multi" || config.Get().App.BloomFilter == "redis" {
 		closes = append(closes, func() error {
 			return model.CloseRedis()
 		})
@@ -121,22 +121,6 @@ func addCacheFields(moduleName string, r replacer.Replacer, cacheName string, pr
 	var fields []replacer.Field
 	fields = append(fields, deleteFieldsMark(r, cacheFile, startMark, endMark)...)
 
-	// match the case where the value type is a pointer
-	if valueType[0] == '*' {
-		fields = append(fields, []replacer.Field{
-			{
-				Old:             "var valueNameExample valueTypeExample",
-				New:             fmt.Sprintf("%s := &%s{}", valueName, valueType[1:]),
-				IsCaseSensitive: false,
-			},
-			{
-				Old:             "&valueNameExample",
-				New:             valueName,
-				IsCaseSensitive: false,
-			},
-		}...)
-	}
-
 	fields = append(fields, []replacer.Field{
 		{
 			Old: "github.com/hankyu66/sponge/internal/model",
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hankyu66/sponge/pkg/gofile"
 	"github.com/hankyu66/sponge/pkg/replacer"
+	"github.com/hankyu66/sponge/pkg/sql2code/parser"
 
 	"github.com/huandu/xstrings"
 )
@@ -27,14 +28,35 @@ var (
 
 	cacheFile = "cache/cacheNameExample.go"
 
-	daoFile     = "dao/userExample.go"
-	daoFileMark = "// todo generate the update fields code to here"
-	daoTestFile = "dao/userExample_test.go"
+	daoFile            = "dao/userExample.go"
+	daoFileMark        = "// todo generate the update fields code to here"
+	daoColumnsFileMark = "// todo generate the column schema code to here"
+
+	daoRelationInterfaceFileMark = "\n\t// todo generate the relation methods declaration to here"
+	daoRelationFileMark          = "\n\n// todo generate the relation methods code to here"
+	daoIndexInterfaceFileMark    = "\n\t// todo generate the index methods declaration to here"
+	daoIndexFileMark             = "\n\n// todo generate the index methods code to here"
+	daoTestFile                  = "dao/userExample_test.go"
 
 	handlerFile     = "types/userExample_types.go"
 	handlerFileMark = "// todo generate the request and response struct to here"
 	handlerTestFile = "handler/userExample_test.go"
 
+	handlerCodeFile      = "handler/userExample.go"
+	handlerLogicFile     = "handler/userExample_logic.go"
+	handlerLogicTestFile = "handler/userExample_logic_test.go"
+	routerFile           = "routers/userExample.go"
+	ecodeHTTPFile        = "ecode/userExample_http.go"
+	ecodeRPCFile         = "ecode/userExample_rpc.go"
+	serviceFile          = "service/userExample.go"
+
+	handlerIndexInterfaceFileMark = "\n\t// todo generate the index handler declaration to here"
+	handlerIndexFileMark          = "\n\n// todo generate the index handler code to here"
+	handlerPbIndexFileMark        = "\n\n// todo generate the index pb handler code to here"
+	routerIndexFileMark           = "\n\t// todo generate the index routes to here"
+	routerIndexMockFileMark       = "\n\n// todo generate the index mock methods to here"
+	serviceIndexFileMark          = "\n\n// todo generate the index service code to here"
+
 	httpFile = "server/http.go"
 
 	protoFile     = "v1/userExample.proto"
@@ -83,6 +105,11 @@ var (
 	wellStartMark = symbolConvert(startMarkStr)
 	wellEndMark   = symbolConvert(endMarkStr)
 
+	softDeleteStartMark = []byte("// soft delete code start")
+	softDeleteEndMark   = []byte("// soft delete code end")
+	shardingStartMark   = []byte("// sharding code start")
+	shardingEndMark     = []byte("// sharding code end")
+
 	// embed FS template file when using
 	selfPackageName = "github.com/hankyu66/sponge"
 )
@@ -175,6 +202,139 @@ func deleteAllFieldsMark(r replacer.Replacer, filename string, startMark []byte,
 	return fields
 }
 
+// the code between the soft delete marks is kept if the table has the deleted_at column, otherwise it is deleted,
+// the marks are always deleted.
+func softDeleteFields(r replacer.Replacer, isSoftDelete bool, filenames ...string) []replacer.Field {
+	return markedCodeFields(r, isSoftDelete, softDeleteStartMark, softDeleteEndMark, filenames...)
+}
+
+// the code between the sharding marks is kept if the table has the sharding key column, otherwise it is deleted,
+// the marks are always deleted.
+func shardingFields(r replacer.Replacer, isSharding bool, filenames ...string) []replacer.Field {
+	return markedCodeFields(r, isSharding, shardingStartMark, shardingEndMark, filenames...)
+}
+
+// replace the marks of the dao file with the methods of the associations, the marks are deleted if the table has
+// no associations.
+func daoRelationFields(interfaceCode string, code string) []replacer.Field {
+	if interfaceCode != "" {
+		interfaceCode = "\n" + interfaceCode
+	}
+	if code != "" {
+		code = "\n" + code
+	}
+	return []replacer.Field{
+		{
+			Old: daoRelationInterfaceFileMark,
+			New: interfaceCode,
+		},
+		{
+			Old: daoRelationFileMark,
+			New: code,
+		},
+	}
+}
+
+// replace the marks of the dao, handler, router and service files with the lookup methods of the unique and
+// secondary indexes, the marks are deleted if the table has no indexes, it must be called after softDeleteFields
+// because some marks follow the soft delete code.
+func indexFields(codes map[string]string) []replacer.Field {
+	var fields []replacer.Field
+	for mark, codeType := range map[string]string{
+		daoIndexInterfaceFileMark:     parser.CodeTypeDAOIndexInterface,
+		daoIndexFileMark:              parser.CodeTypeDAOIndex,
+		handlerIndexInterfaceFileMark: parser.CodeTypeHandlerIndexInterface,
+		handlerIndexFileMark:          parser.CodeTypeHandlerIndex,
+		handlerPbIndexFileMark:        parser.CodeTypeHandlerPbIndex,
+		routerIndexFileMark:           parser.CodeTypeRouterIndex,
+		routerIndexMockFileMark:       parser.CodeTypeRouterIndexMock,
+		serviceIndexFileMark:          parser.CodeTypeServiceIndex,
+	} {
+		code := codes[codeType]
+		if code != "" {
+			code = mark[:strings.LastIndexByte(mark, '\n')+1] + code // keep the line breaks before the mark
+		}
+		fields = append(fields, replacer.Field{
+			Old: mark,
+			New: code,
+		})
+	}
+	return fields
+}
+
+// set the database driver of the configuration files, the whole mysql dsn is replaced because the dsn format of
+// the other drivers is different, the mysql dsn is replaced by the dsn field of the generated service.
+func dbDriverFields(driver string, dbDSN string) []replacer.Field {
+	if driver == "" || driver == "mysql" {
+		return nil
+	}
+	return []replacer.Field{
+		{
+			Old: `driver: "mysql"`,
+			New: `driver: "` + driver + `"`,
+		},
+		{
+			Old: "root:123456@(192.168.3.37:3306)/account?parseTime=true&loc=Local&charset=utf8,utf8mb4",
+			New: dbDSN,
+		},
+	}
+}
+
+// keep or delete the code between the marks, the marks are always deleted
+func markedCodeFields(r replacer.Replacer, isKeep bool, startMark []byte, endMark []byte, filenames ...string) []replacer.Field {
+	var fields []replacer.Field
+
+	for _, filename := range filenames {
+		data, err := r.ReadFile(filename)
+		if err != nil {
+			fmt.Printf("readFile error: %v, please execute the \"sponge update\" command to resolve\n ", err)
+			continue
+		}
+
+		pos := 0
+		for {
+			start := bytes.Index(data[pos:], startMark)
+			end := bytes.Index(data[pos:], endMark)
+			if start < 0 || end < start {
+				break
+			}
+			start, end = start+pos, end+pos
+
+			// expand to whole lines
+			start = bytes.LastIndexByte(data[:start], '\n') + 1
+			end += len(endMark)
+			if end < len(data) && data[end] == '\n' {
+				end++
+			}
+			inner := data[start+bytes.IndexByte(data[start:], '\n')+1 : bytes.LastIndexByte(data[:end-1], '\n')+1]
+			isBlankBefore := start >= 2 && data[start-2] == '\n'
+			isBlankAfter := end == len(data) || data[end] == '\n'
+
+			oldStr, newStr := string(data[start:end]), ""
+			if isKeep {
+				// avoid double blank lines after removing the marks
+				if isBlankBefore && bytes.HasPrefix(inner, []byte("\n")) {
+					inner = inner[1:]
+				}
+				if isBlankAfter && bytes.HasSuffix(inner, []byte("\n\n")) {
+					inner = inner[:len(inner)-1]
+				}
+				newStr = string(inner)
+			} else if isBlankBefore {
+				oldStr = "\n" + oldStr
+			}
+			fields = append(fields, replacer.Field{
+				Old: oldStr,
+				New: newStr,
+			})
+
+			pos = end
+		}
+	}
+
+	return fields
+}
+
 func replaceFileContentMark(r replacer.Replacer, filename string, newContent string) []replacer.Field {
 	var fields []replacer.Field
 
@@ -42,9 +42,15 @@ Examples:
   # generate dao code, structure fields correspond to the column names of the table.
   sponge %s dao --module-name=yourModuleName --db-dsn=root:123456@(192.168.3.37:3306)/test --db-table=user --embed=false
 
+  # generate dao code of the table sharded by user_id, the model includes the sharding rule.
+  sponge %s dao --module-name=yourModuleName --db-dsn=root:123456@(192.168.3.37:3306)/test --db-table=order --sharding-key=user_id --sharding-algorithm=mod:64
+
+  # generate dao code with the custom templates, the <name>.tmpl files in the directory override the built-in templates.
+  sponge %s dao --module-name=yourModuleName --db-dsn=root:123456@(192.168.3.37:3306)/test --db-table=user --template-dir=./templates
+
   # generate dao code and specify the server directory, Note: code generation will be canceled when the latest generated file already exists.
   sponge %s dao --db-dsn=root:123456@(192.168.3.37:3306)/test --db-table=user --out=./yourServerDir
-`, parentName, parentName, parentName, parentName),
+`, parentName, parentName, parentName, parentName, parentName, parentName),
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -56,6 +62,7 @@ Examples:
 			}
 
 			tableNames := strings.Split(dbTables, ",")
+			sqlArgs.RelatedTables = tableNames // used to generate the associations between the tables
 			for count, tableName := range tableNames {
 				if tableName == "" {
 					continue
@@ -94,10 +101,15 @@ using help:
 	//_ = cmd.MarkFlagRequired("module-name")
 	cmd.Flags().StringVarP(&sqlArgs.DBDsn, "db-dsn", "d", "", "db content addr, e.g. user:password@(host:port)/database")
 	_ = cmd.MarkFlagRequired("db-dsn")
+	cmd.Flags().StringVar(&sqlArgs.DBDriver, "db-driver", "mysql", "database driver of the db-dsn, mysql or postgres, "+
+		"e.g. the postgres dsn is host=127.0.0.1 port=5432 user=root password=123456 dbname=test sslmode=disable")
 	cmd.Flags().StringVarP(&dbTables, "db-table", "t", "", "table name, multiple names separated by commas")
 	_ = cmd.MarkFlagRequired("db-table")
 	cmd.Flags().BoolVarP(&sqlArgs.IsEmbed, "embed", "e", true, "whether to embed gorm.model struct")
 	cmd.Flags().IntVarP(&sqlArgs.JSONNamedType, "json-name-type", "j", 1, "json tags name type, 0:snake case, 1:camel case")
+	cmd.Flags().StringVar(&sqlArgs.ShardingKey, "sharding-key", "", "column name of the sharding key, the tables containing the column are sharded, e.g. user_id")
+	cmd.Flags().StringVar(&sqlArgs.ShardingAlgorithm, "sharding-algorithm", "", "sharding algorithm, one of mod:n, hash:n, day:start:end, month:start:end, year:start:end, e.g. mod:64")
+	cmd.Flags().StringVar(&sqlArgs.TemplateDir, "template-dir", "", "directory of the <name>.tmpl files which override the built-in code templates of the same name, e.g. ./templates")
 	cmd.Flags().StringVarP(&outPath, "out", "o", "", "output directory, default is ./dao_<time>, "+
 		"if you specify the directory where the web or microservice generated by sponge, the module-name flag can be ignored")
 	cmd.Flags().BoolVarP(&isIncludeInitDB, "include-init-db", "i", false, "if true, includes mysql and redis initialization code")
@@ -144,8 +156,12 @@ func runGenDaoCommand(moduleName string, isIncludeInitDB bool, codes map[string]
 func addDAOFields(moduleName string, r replacer.Replacer, codes map[string]string) []replacer.Field {
 	var fields []replacer.Field
 
+	fields = append(fields, daoRelationFields(codes[parser.CodeTypeDAORelationInterface], codes[parser.CodeTypeDAORelation])...)
+	fields = append(fields, softDeleteFields(r, codes[parser.SoftDelete] == "true", daoFile, daoTestFile)...)
+	fields = append(fields, shardingFields(r, codes[parser.Sharding] == "true", daoFile)...)
+	fields = append(fields, indexFields(codes)...)
 	fields = append(fields, deleteFieldsMark(r, modelFile, startMark, endMark)...)
-	fields = append(fields, deleteFieldsMark(r, daoFile, startMark, endMark)...)
+	fields = append(fields, deleteAllFieldsMark(r, daoFile, startMark, endMark)...)
 	fields = append(fields, deleteFieldsMark(r, daoTestFile, startMark, endMark)...)
 	fields = append(fields, []replacer.Field{
 		{
@@ -156,6 +172,10 @@ func addDAOFields(moduleName string, r replacer.Replacer, codes map[string]strin
 			Old: daoFileMark,
 			New: codes[parser.CodeTypeDAO],
 		},
+		{
+			Old: daoColumnsFileMark,
+			New: codes[parser.CodeTypeDAOColumns],
+		},
 		{
 			Old: selfPackageName + "/" + r.GetSourcePath(),
 			New: moduleName,
@@ -56,6 +56,7 @@ Examples:
 			}
 
 			tableNames := strings.Split(dbTables, ",")
+			sqlArgs.RelatedTables = tableNames // used to generate the associations between the tables
 			for _, tableName := range tableNames {
 				if tableName == "" {
 					continue
@@ -90,10 +91,15 @@ using help:
 	//_ = cmd.MarkFlagRequired("module-name")
 	cmd.Flags().StringVarP(&sqlArgs.DBDsn, "db-dsn", "d", "", "db content addr, e.g. user:password@(host:port)/database")
 	_ = cmd.MarkFlagRequired("db-dsn")
+	cmd.Flags().StringVar(&sqlArgs.DBDriver, "db-driver", "mysql", "database driver of the db-dsn, mysql or postgres, "+
+		"e.g. the postgres dsn is host=127.0.0.1 port=5432 user=root password=123456 dbname=test sslmode=disable")
 	cmd.Flags().StringVarP(&dbTables, "db-table", "t", "", "table name, multiple names separated by commas")
 	_ = cmd.MarkFlagRequired("db-table")
 	cmd.Flags().BoolVarP(&sqlArgs.IsEmbed, "embed", "e", true, "whether to embed gorm.model struct")
 	cmd.Flags().IntVarP(&sqlArgs.JSONNamedType, "json-name-type", "j", 1, "json tags name type, 0:snake case, 1:camel case")
+	cmd.Flags().StringVar(&sqlArgs.ShardingKey, "sharding-key", "", "column name of the sharding key, the tables containing the column are sharded, e.g. user_id")
+	cmd.Flags().StringVar(&sqlArgs.ShardingAlgorithm, "sharding-algorithm", "", "sharding algorithm, one of mod:n, hash:n, day:start:end, month:start:end, year:start:end, e.g. mod:64")
+	cmd.Flags().StringVar(&sqlArgs.TemplateDir, "template-dir", "", "directory of the <name>.tmpl files which override the built-in code templates of the same name, e.g. ./templates")
 	cmd.Flags().StringVarP(&outPath, "out", "o", "", "output directory, default is ./handler_<time>, "+
 		"if you specify the directory where the web or microservice generated by sponge, the module-name flag can be ignored")
 
@@ -136,8 +142,13 @@ func runGenHandlerCommand(moduleName string, codes map[string]string, outPath st
 func addHandlerFields(moduleName string, r replacer.Replacer, codes map[string]string) []replacer.Field {
 	var fields []replacer.Field
 
+	fields = append(fields, daoRelationFields(codes[parser.CodeTypeDAORelationInterface], codes[parser.CodeTypeDAORelation])...)
+	fields = append(fields, softDeleteFields(r, codes[parser.SoftDelete] == "true", daoFile, daoTestFile,
+		handlerFile, handlerCodeFile, handlerTestFile, routerFile, ecodeHTTPFile)...)
+	fields = append(fields, shardingFields(r, codes[parser.Sharding] == "true", daoFile)...)
+	fields = append(fields, indexFields(codes)...)
 	fields = append(fields, deleteFieldsMark(r, modelFile, startMark, endMark)...)
-	fields = append(fields, deleteFieldsMark(r, daoFile, startMark, endMark)...)
+	fields = append(fields, deleteAllFieldsMark(r, daoFile, startMark, endMark)...)
 	fields = append(fields, deleteFieldsMark(r, daoTestFile, startMark, endMark)...)
 	fields = append(fields, deleteFieldsMark(r, handlerFile, startMark, endMark)...)
 	fields = append(fields, deleteFieldsMark(r, handlerTestFile, startMark, endMark)...)
@@ -150,6 +161,10 @@ func addHandlerFields(moduleName string, r replacer.Replacer, codes map[string]s
 			Old: daoFileMark,
 			New: codes[parser.CodeTypeDAO],
 		},
+		{
+			Old: daoColumnsFileMark,
+			New: codes[parser.CodeTypeDAOColumns],
+		},
 		{ // replace the contents of the handler/userExample.go file
 			Old: handlerFileMark,
 			New: adjustmentOfIDType(codes[parser.CodeTypeHandler]),
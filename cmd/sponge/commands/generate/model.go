@@ -40,9 +40,12 @@ Examples:
   # generate model code, structure fields correspond to the column names of the table.
   sponge %s model --db-dsn=root:123456@(192.168.3.37:3306)/test --db-table=user --embed=false
 
+  # generate model code from postgres table.
+  sponge %s model --db-driver=postgres --db-dsn="host=192.168.3.37 port=5432 user=root password=123456 dbname=test sslmode=disable" --db-table=user
+
   # generate model code and specify the server directory, Note: code generation will be canceled when the latest generated file already exists.
   sponge %s model --db-dsn=root:123456@(192.168.3.37:3306)/test --db-table=user --out=./yourServerDir
-`, parentName, parentName, parentName, parentName),
+`, parentName, parentName, parentName, parentName, parentName),
 		SilenceErrors: true,
 		SilenceUsage:  true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,10 +79,13 @@ using help:
 
 	cmd.Flags().StringVarP(&sqlArgs.DBDsn, "db-dsn", "d", "", "db content addr, e.g. user:password@(host:port)/database")
 	_ = cmd.MarkFlagRequired("db-dsn")
+	cmd.Flags().StringVar(&sqlArgs.DBDriver, "db-driver", "mysql", "database driver of the db-dsn, mysql or postgres, "+
+		"e.g. the postgres dsn is host=127.0.0.1 port=5432 user=root password=123456 dbname=test sslmode=disable")
 	cmd.Flags().StringVarP(&dbTables, "db-table", "t", "", "table name, multiple names separated by commas")
 	_ = cmd.MarkFlagRequired("db-table")
 	cmd.Flags().BoolVarP(&sqlArgs.IsEmbed, "embed", "e", true, "whether to embed gorm.model struct")
 	cmd.Flags().IntVarP(&sqlArgs.JSONNamedType, "json-name-type", "j", 1, "json tags name type, 0:snake case, 1:camel case")
+	cmd.Flags().StringVar(&sqlArgs.TemplateDir, "template-dir", "", "directory of the <name>.tmpl files which override the built-in code templates of the same name, e.g. ./templates")
 	cmd.Flags().StringVarP(&outPath, "out", "o", "", "output directory, default is ./model_<time>")
 
 	return cmd
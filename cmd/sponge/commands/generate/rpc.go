@@ -59,6 +59,7 @@ Examples:
 			var firstTable string
 			var servicesTableNames []string
 			tableNames := strings.Split(dbTables, ",")
+			sqlArgs.RelatedTables = tableNames // used to generate the associations between the tables
 			if len(tableNames) == 1 {
 				firstTable = tableNames[0]
 			} else if len(tableNames) > 1 {
@@ -115,10 +116,15 @@ using help:
 	_ = cmd.MarkFlagRequired("project-name")
 	cmd.Flags().StringVarP(&sqlArgs.DBDsn, "db-dsn", "d", "", "db content addr, e.g. user:password@(host:port)/database")
 	_ = cmd.MarkFlagRequired("db-dsn")
+	cmd.Flags().StringVar(&sqlArgs.DBDriver, "db-driver", "mysql", "database driver of the db-dsn, mysql or postgres, "+
+		"e.g. the postgres dsn is host=127.0.0.1 port=5432 user=root password=123456 dbname=test sslmode=disable")
 	cmd.Flags().StringVarP(&dbTables, "db-table", "t", "", "table name, multiple names separated by commas")
 	_ = cmd.MarkFlagRequired("db-table")
 	cmd.Flags().BoolVarP(&sqlArgs.IsEmbed, "embed", "e", true, "whether to embed gorm.model struct")
 	cmd.Flags().IntVarP(&sqlArgs.JSONNamedType, "json-name-type", "j", 1, "json tags name type, 0:snake case, 1:camel case")
+	cmd.Flags().StringVar(&sqlArgs.ShardingKey, "sharding-key", "", "column name of the sharding key, the tables containing the column are sharded, e.g. user_id")
+	cmd.Flags().StringVar(&sqlArgs.ShardingAlgorithm, "sharding-algorithm", "", "sharding algorithm, one of mod:n, hash:n, day:start:end, month:start:end, year:start:end, e.g. mod:64")
+	cmd.Flags().StringVar(&sqlArgs.TemplateDir, "template-dir", "", "directory of the <name>.tmpl files which override the built-in code templates of the same name, e.g. ./templates")
 	cmd.Flags().StringVarP(&repoAddr, "repo-addr", "r", "", "docker image repository address, excluding http and repository names")
 	cmd.Flags().StringVarP(&outPath, "out", "o", "", "output directory, default is ./serverName_rpc_<time>")
 
@@ -175,8 +181,14 @@ func addRPCFields(moduleName string, serverName string, projectName string, repo
 
 	repoHost, _ := parseImageRepoAddr(repoAddr)
 
+	fields = append(fields, daoRelationFields(codes[parser.CodeTypeDAORelationInterface], codes[parser.CodeTypeDAORelation])...)
+	fields = append(fields, softDeleteFields(r, codes[parser.SoftDelete] == "true", daoFile, daoTestFile,
+		serviceFile, serviceClientFile, ecodeRPCFile)...)
+	fields = append(fields, shardingFields(r, codes[parser.Sharding] == "true", daoFile)...)
+	fields = append(fields, indexFields(codes)...)
+	fields = append(fields, dbDriverFields(codes[parser.DBDriver], dbDSN)...)
 	fields = append(fields, deleteFieldsMark(r, modelFile, startMark, endMark)...)
-	fields = append(fields, deleteFieldsMark(r, daoFile, startMark, endMark)...)
+	fields = append(fields, deleteAllFieldsMark(r, daoFile, startMark, endMark)...)
 	fields = append(fields, deleteFieldsMark(r, daoTestFile, startMark, endMark)...)
 	fields = append(fields, deleteFieldsMark(r, protoFile, startMark, endMark)...)
 	fields = append(fields, deleteFieldsMark(r, serviceClientFile, startMark, endMark)...)
@@ -202,6 +214,10 @@ func addRPCFields(moduleName string, serverName string, projectName string, repo
 			Old: daoFileMark,
 			New: codes[parser.CodeTypeDAO],
 		},
+		{
+			Old: daoColumnsFileMark,
+			New: codes[parser.CodeTypeDAOColumns],
+		},
 		{ // replace the contents of the v1/userExample.proto file
 			Old: protoFileMark,
 			New: codes[parser.CodeTypeProto],
@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"github.com/hankyu66/sponge/cmd/sponge/commands/migrate"
+
+	"github.com/spf13/cobra"
+)
+
+// MigrateCommand versioned schema migrations
+func MigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "migrate",
+		Short:         "Command set for versioned schema migrations",
+		Long:          `command set for versioned schema migrations, the applied migrations are recorded in the table schema_migrations.`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.AddCommand(
+		migrate.CreateCommand(),
+		migrate.UpCommand(),
+		migrate.DownCommand(),
+		migrate.StatusCommand(),
+	)
+
+	return cmd
+}
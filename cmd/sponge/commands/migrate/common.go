@@ -0,0 +1,52 @@
+// Package migrate is the sponge migrate command set, it creates sql migration files in the project
+// and applies or rolls back them.
+package migrate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hankyu66/sponge/pkg/database"
+	"github.com/hankyu66/sponge/pkg/migrate"
+
+	"github.com/spf13/cobra"
+)
+
+// the directory of the migration files in the project generated by sponge
+const defaultMigrationDir = "internal/migrations"
+
+type dbArgs struct {
+	dsn    string
+	driver string
+	dir    string
+	table  string
+}
+
+func (a *dbArgs) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&a.dsn, "db-dsn", "d", "", "db content addr, e.g. user:password@(host:port)/database")
+	_ = cmd.MarkFlagRequired("db-dsn")
+	cmd.Flags().StringVarP(&a.driver, "db-driver", "k", database.DriverMysql, "database driver, supported mysql, postgres, sqlite")
+	cmd.Flags().StringVarP(&a.dir, "dir", "i", defaultMigrationDir, "directory of the migration files")
+	cmd.Flags().StringVarP(&a.table, "table", "t", "schema_migrations", "table name of the applied migrations")
+}
+
+func (a *dbArgs) newMigrator(opts ...migrate.Option) (*migrate.Migrator, func(), error) {
+	db, err := database.Init(a.driver, a.dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect database error: %v", err)
+	}
+	closeDB := func() {
+		if sqlDB, e := db.DB(); e == nil {
+			_ = sqlDB.Close()
+		}
+	}
+
+	opts = append(opts, migrate.WithDir(filepath.Clean(a.dir)), migrate.WithTableName(a.table))
+	m, err := migrate.New(db, opts...)
+	if err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+
+	return m, closeDB, nil
+}
@@ -0,0 +1,59 @@
+package migrate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hankyu66/sponge/pkg/migrate"
+
+	"github.com/spf13/cobra"
+)
+
+// CreateCommand create migration files
+func CreateCommand() *cobra.Command {
+	var (
+		name    string // migration name
+		outPath string // server directory
+		dir     string // directory of the migration files
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create up and down sql migration files",
+		Long: `create up and down sql migration files, the file names are <version>_<name>.up.sql and <version>_<name>.down.sql,
+the version is the current time. an embed.go file is created if it does not exist, the files are compiled into the service
+by migrations.FS, use it by migrate.WithFS(migrations.FS).
+
+Examples:
+  # create migration files in the directory internal/migrations of the current server.
+  sponge migrate create --name=create_user
+
+  # create migration files, and specify the server directory.
+  sponge migrate create --name=add_user_age --out=./yourServerDir
+`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(outPath, dir)
+			}
+
+			files, err := migrate.Create(dir, name)
+			if err != nil {
+				return err
+			}
+
+			for _, file := range files {
+				fmt.Printf("create file %s\n", file)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "migration name, only letters, digits and underscores are allowed, e.g. create_user")
+	_ = cmd.MarkFlagRequired("name")
+	cmd.Flags().StringVarP(&outPath, "out", "o", ".", "directory of the web or microservice generated by sponge")
+	cmd.Flags().StringVarP(&dir, "dir", "i", defaultMigrationDir, "directory of the migration files, relative to the out directory")
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hankyu66/sponge/pkg/migrate"
+
+	"github.com/spf13/cobra"
+)
+
+// DownCommand roll back migrations
+func DownCommand() *cobra.Command {
+	var (
+		args       = &dbArgs{}
+		steps      int
+		allowEmpty bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Roll back applied sql migrations",
+		Long: `roll back applied sql migrations, the last applied migration is rolled back first.
+the down sql without statements (e.g. only comments) is rejected, unless --allow-empty is set.
+
+Examples:
+  # roll back the last applied migration.
+  sponge migrate down --db-dsn=root:123456@(192.168.3.37:3306)/test
+
+  # roll back the last 3 applied migrations.
+  sponge migrate down --db-dsn=root:123456@(192.168.3.37:3306)/test --steps=3
+
+  # roll back the last applied migration whose down sql is empty.
+  sponge migrate down --db-dsn=root:123456@(192.168.3.37:3306)/test --allow-empty
+`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var opts []migrate.Option
+			if allowEmpty {
+				opts = append(opts, migrate.WithAllowEmpty())
+			}
+			m, closeDB, err := args.newMigrator(opts...)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			n, err := m.Down(context.Background(), steps)
+			if err != nil {
+				return err
+			}
+			version, err := m.Version(context.Background())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("rolled back %d migrations, current version is %d\n", n, version)
+			return nil
+		},
+	}
+
+	args.addFlags(cmd)
+	cmd.Flags().IntVarP(&steps, "steps", "s", 1, "number of migrations to roll back")
+	cmd.Flags().BoolVarP(&allowEmpty, "allow-empty", "e", false, "allow rolling back the migrations whose down sql has no statements")
+
+	return cmd
+}
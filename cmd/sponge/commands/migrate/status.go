@@ -0,0 +1,55 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// StatusCommand show migrations status
+func StatusCommand() *cobra.Command {
+	args := &dbArgs{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of sql migrations",
+		Long: `show the status of sql migrations.
+
+Examples:
+  # show the status of migrations in the directory internal/migrations.
+  sponge migrate status --db-dsn=root:123456@(192.168.3.37:3306)/test
+`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			m, closeDB, err := args.newMigrator()
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			list, err := m.Status(context.Background())
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+			for _, s := range list {
+				status, appliedAt := "pending", "-"
+				if s.IsApplied {
+					status, appliedAt = "applied", s.AppliedAt.Format("2006-01-02 15:04:05")
+				}
+				_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+			}
+			return w.Flush()
+		},
+	}
+
+	args.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hankyu66/sponge/pkg/migrate"
+
+	"github.com/spf13/cobra"
+)
+
+// UpCommand apply migrations
+func UpCommand() *cobra.Command {
+	var (
+		args       = &dbArgs{}
+		toVersion  int64
+		allowEmpty bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply pending sql migrations",
+		Long: `apply pending sql migrations, the migrations are executed in order of version.
+the up sql without statements (e.g. only comments) is rejected, unless --allow-empty is set.
+
+Examples:
+  # apply all pending migrations in the directory internal/migrations.
+  sponge migrate up --db-dsn=root:123456@(192.168.3.37:3306)/test
+
+  # apply pending migrations whose version is less than or equal to 20230101000000.
+  sponge migrate up --db-dsn=root:123456@(192.168.3.37:3306)/test --to=20230101000000
+
+  # apply migrations of postgres in the specified directory.
+  sponge migrate up --db-driver=postgres --db-dsn="host=192.168.3.37 port=5432 user=root password=123456 dbname=test sslmode=disable" --dir=./migrations
+`,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var opts []migrate.Option
+			if allowEmpty {
+				opts = append(opts, migrate.WithAllowEmpty())
+			}
+			m, closeDB, err := args.newMigrator(opts...)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+
+			n, err := m.UpTo(context.Background(), toVersion)
+			if err != nil {
+				return err
+			}
+			version, err := m.Version(context.Background())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("applied %d migrations, current version is %d\n", n, version)
+			return nil
+		},
+	}
+
+	args.addFlags(cmd)
+	cmd.Flags().Int64Var(&toVersion, "to", 0, "apply the migrations up to the version, default is all")
+	cmd.Flags().BoolVarP(&allowEmpty, "allow-empty", "e", false, "allow applying the migrations whose up sql has no statements")
+
+	return cmd
+}
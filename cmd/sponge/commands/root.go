@@ -36,6 +36,7 @@ web and microservice framework, basic development framework.`,
 		OpenUICommand(),
 		MergeCommand(),
 		PatchCommand(),
+		MigrateCommand(),
 	)
 
 	return cmd
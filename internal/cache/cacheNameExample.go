@@ -35,53 +35,46 @@ type CacheNameExampleCache interface {
 }
 
 type cacheNameExampleCache struct {
-	cache cache.Cache
+	cache *cache.Typed[keyTypeExample, valueTypeExample]
 }
 
 // NewCacheNameExampleCache create a new cache
 func NewCacheNameExampleCache(cacheType *model.CacheType) CacheNameExampleCache {
-	newObject := func() interface{} {
-		return ""
-	}
 	cachePrefix := ""
 	jsonEncoding := encoding.JSONEncoding{}
 
 	var c cache.Cache
-	if strings.ToLower(cacheType.CType) == "redis" {
-		c = cache.NewRedisCache(cacheType.Rdb, cachePrefix, jsonEncoding, newObject)
-	} else {
-		c = cache.NewMemoryCache(cachePrefix, jsonEncoding, newObject)
+	switch strings.ToLower(cacheType.CType) {
+	case "redis":
+		c = cache.NewRedisCache(cacheType.Rdb, cachePrefix, jsonEncoding, nil)
+	case "multi":
+		c = cacheType.MultiLevelCache(cachePrefix, jsonEncoding)
+	default:
+		c = cache.NewMemoryCache(cachePrefix, jsonEncoding, nil)
 	}
+	c = cache.NewInstrumentedCache(c, cacheType.InstrumentOptions()...)
 
 	return &cacheNameExampleCache{
-		cache: c,
+		cache: cache.NewTyped[keyTypeExample, valueTypeExample](c, getCacheNameExampleCacheKey),
 	}
 }
 
 // cache key
-func (c *cacheNameExampleCache) getCacheKey(keyNameExample keyTypeExample) string {
+func getCacheNameExampleCacheKey(keyNameExample keyTypeExample) string {
 	return fmt.Sprintf("%s%v", cacheNameExampleCachePrefixKey, keyNameExample)
 }
 
 // Set cache
 func (c *cacheNameExampleCache) Set(ctx context.Context, keyNameExample keyTypeExample, valueNameExample valueTypeExample, duration time.Duration) error {
-	cacheKey := c.getCacheKey(keyNameExample)
-	return c.cache.Set(ctx, cacheKey, &valueNameExample, duration)
+	return c.cache.Set(ctx, keyNameExample, valueNameExample, duration)
 }
 
 // Get cache
 func (c *cacheNameExampleCache) Get(ctx context.Context, keyNameExample keyTypeExample) (valueTypeExample, error) {
-	var valueNameExample valueTypeExample
-	cacheKey := c.getCacheKey(keyNameExample)
-	err := c.cache.Get(ctx, cacheKey, &valueNameExample)
-	if err != nil {
-		return valueNameExample, err
-	}
-	return valueNameExample, nil
+	return c.cache.Get(ctx, keyNameExample)
 }
 
 // Del delete cache
 func (c *cacheNameExampleCache) Del(ctx context.Context, keyNameExample keyTypeExample) error {
-	cacheKey := c.getCacheKey(keyNameExample)
-	return c.cache.Del(ctx, cacheKey)
+	return c.cache.Del(ctx, keyNameExample)
 }
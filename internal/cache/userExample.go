@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -9,14 +10,25 @@ import (
 
 	"github.com/hankyu66/sponge/pkg/cache"
 	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/mysql/query"
 	"github.com/hankyu66/sponge/pkg/utils"
 )
 
 const (
 	// cache prefix key, must end with a colon
 	userExampleCachePrefixKey = "userExample:"
+	// cache prefix key of the ids got by the unique indexes, must end with a colon
+	userExampleIndexCachePrefixKey = "userExample:index:"
+	// namespace of list cache keys, the version of the namespace is bumped when the table changes
+	userExampleListNamespace = "userExample:list"
 	// UserExampleExpireTime expire time
 	UserExampleExpireTime = 10 * time.Minute
+	// UserExampleListExpireTime expire time of list cache
+	UserExampleListExpireTime = 5 * time.Minute
+	// UserExampleBloomFilterItems expected number of records in the bloom filter
+	UserExampleBloomFilterItems = 1000000
+	// UserExampleBloomFilterRate false positive rate of the bloom filter
+	UserExampleBloomFilterRate = 0.001
 )
 
 var _ UserExampleCache = (*userExampleCache)(nil)
@@ -26,39 +38,69 @@ type UserExampleCache interface {
 	Set(ctx context.Context, id uint64, data *model.UserExample, duration time.Duration) error
 	Get(ctx context.Context, id uint64) (*model.UserExample, error)
 	MultiGet(ctx context.Context, ids []uint64) (map[uint64]*model.UserExample, error)
+	MultiGetWithPlaceholder(ctx context.Context, ids []uint64) (map[uint64]*model.UserExample, []uint64, error)
 	MultiSet(ctx context.Context, data []*model.UserExample, duration time.Duration) error
 	Del(ctx context.Context, id uint64) error
 	SetCacheWithNotFound(ctx context.Context, id uint64) error
+	BloomFilter() cache.BloomFilter
+
+	GetListKey(ctx context.Context, params *query.Params) (string, error)
+	GetList(ctx context.Context, key string) (*UserExampleList, error)
+	SetList(ctx context.Context, key string, data *UserExampleList, duration time.Duration) error
+	DelList(ctx context.Context) error
+
+	GetIndexID(ctx context.Context, index string, values ...interface{}) (uint64, error)
+	SetIndexID(ctx context.Context, id uint64, duration time.Duration, index string, values ...interface{}) error
+	DelIndexID(ctx context.Context, index string, values ...interface{}) error
+}
+
+// UserExampleList a page of records and the total number of records
+type UserExampleList struct {
+	Records []*model.UserExample `json:"records"`
+	Total   int64                `json:"total"`
 }
 
 // userExampleCache define a cache struct
 type userExampleCache struct {
-	cache cache.Cache
+	cache      *cache.Typed[uint64, *model.UserExample]
+	listCache  *cache.Typed[string, *UserExampleList]
+	indexCache *cache.Typed[string, uint64]
+	tagCache   cache.TagCache
+	filter     cache.BloomFilter
 }
 
 // NewUserExampleCache new a cache
 func NewUserExampleCache(cacheType *model.CacheType) UserExampleCache {
 	jsonEncoding := encoding.JSONEncoding{}
 	cachePrefix := ""
+
 	var c cache.Cache
-	if strings.ToLower(cacheType.CType) == "redis" {
-		c = cache.NewRedisCache(cacheType.Rdb, cachePrefix, jsonEncoding, func() interface{} {
-			return &model.UserExample{}
-		})
-	} else {
-		c = cache.NewMemoryCache(cachePrefix, jsonEncoding, func() interface{} {
-			return &model.UserExample{}
-		})
+	switch strings.ToLower(cacheType.CType) {
+	case "redis":
+		c = cache.NewRedisCache(cacheType.Rdb, cachePrefix, jsonEncoding, nil)
+	case "multi":
+		c = cacheType.MultiLevelCache(cachePrefix, jsonEncoding)
+	default:
+		c = cache.NewMemoryCache(cachePrefix, jsonEncoding, nil)
 	}
+	c = cache.NewInstrumentedCache(c, cacheType.InstrumentOptions()...)
 
 	return &userExampleCache{
-		cache: c,
+		cache:      cache.NewTyped[uint64, *model.UserExample](c, getUserExampleCacheKey),
+		listCache:  cache.NewTyped[string, *UserExampleList](c, func(key string) string { return key }),
+		indexCache: cache.NewTyped[string, uint64](c, func(key string) string { return userExampleIndexCachePrefixKey + key }),
+		tagCache:   c.(cache.TagCache),
+		filter:     cacheType.NewBloomFilter("userExample", UserExampleBloomFilterItems, UserExampleBloomFilterRate),
 	}
 }
 
+func getUserExampleCacheKey(id uint64) string {
+	return userExampleCachePrefixKey + utils.Uint64ToStr(id)
+}
+
 // GetUserExampleCacheKey cache key
 func (c *userExampleCache) GetUserExampleCacheKey(id uint64) string {
-	return userExampleCachePrefixKey + utils.Uint64ToStr(id)
+	return c.cache.Key(id)
 }
 
 // Set write to cache
@@ -66,82 +108,95 @@ func (c *userExampleCache) Set(ctx context.Context, id uint64, data *model.UserE
 	if data == nil || id == 0 {
 		return nil
 	}
-	cacheKey := c.GetUserExampleCacheKey(id)
-	err := c.cache.Set(ctx, cacheKey, data, duration)
-	if err != nil {
-		return err
-	}
-	return nil
+	return c.cache.Set(ctx, id, data, duration)
 }
 
 // Get cache value
 func (c *userExampleCache) Get(ctx context.Context, id uint64) (*model.UserExample, error) {
-	var data *model.UserExample
-	cacheKey := c.GetUserExampleCacheKey(id)
-	err := c.cache.Get(ctx, cacheKey, &data)
-	if err != nil {
-		return nil, err
-	}
-	return data, nil
+	return c.cache.Get(ctx, id)
 }
 
 // MultiSet multiple set cache
 func (c *userExampleCache) MultiSet(ctx context.Context, data []*model.UserExample, duration time.Duration) error {
-	valMap := make(map[string]interface{})
+	valMap := make(map[uint64]*model.UserExample, len(data))
 	for _, v := range data {
-		cacheKey := c.GetUserExampleCacheKey(v.ID)
-		valMap[cacheKey] = v
+		valMap[v.ID] = v
 	}
-
-	err := c.cache.MultiSet(ctx, valMap, duration)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return c.cache.MultiSet(ctx, valMap, duration)
 }
 
 // MultiGet multiple get cache, return key in map is id value
 func (c *userExampleCache) MultiGet(ctx context.Context, ids []uint64) (map[uint64]*model.UserExample, error) {
-	var keys []string
-	for _, v := range ids {
-		cacheKey := c.GetUserExampleCacheKey(v)
-		keys = append(keys, cacheKey)
-	}
-
-	itemMap := make(map[string]*model.UserExample)
-	err := c.cache.MultiGet(ctx, keys, itemMap)
-	if err != nil {
-		return nil, err
-	}
-
-	retMap := make(map[uint64]*model.UserExample)
-	for _, id := range ids {
-		val, ok := itemMap[c.GetUserExampleCacheKey(id)]
-		if ok {
-			retMap[id] = val
-		}
-	}
+	return c.cache.MultiGet(ctx, ids)
+}
 
-	return retMap, nil
+// MultiGetWithPlaceholder multiple get cache, the ids of not found placeholders are returned separately
+func (c *userExampleCache) MultiGetWithPlaceholder(ctx context.Context, ids []uint64) (map[uint64]*model.UserExample, []uint64, error) {
+	return c.cache.MultiGetWithPlaceholder(ctx, ids)
 }
 
 // Del delete cache
 func (c *userExampleCache) Del(ctx context.Context, id uint64) error {
-	cacheKey := c.GetUserExampleCacheKey(id)
-	err := c.cache.Del(ctx, cacheKey)
-	if err != nil {
-		return err
-	}
-	return nil
+	return c.cache.Del(ctx, id)
 }
 
 // SetCacheWithNotFound set empty cache
 func (c *userExampleCache) SetCacheWithNotFound(ctx context.Context, id uint64) error {
-	cacheKey := c.GetUserExampleCacheKey(id)
-	err := c.cache.SetCacheWithNotFound(ctx, cacheKey)
-	if err != nil {
-		return err
+	return c.cache.SetCacheWithNotFound(ctx, id)
+}
+
+// BloomFilter bloom filter of ids, return nil if not used
+func (c *userExampleCache) BloomFilter() cache.BloomFilter {
+	return c.filter
+}
+
+// GetListKey cache key of a page of records, it includes the current version of the list namespace,
+// get the key before querying the database, so that the result is not cached under a newer version
+func (c *userExampleCache) GetListKey(ctx context.Context, params *query.Params) (string, error) {
+	return c.tagCache.NamespaceKey(ctx, userExampleListNamespace, params.Hash())
+}
+
+// GetList get a page of records from cache
+func (c *userExampleCache) GetList(ctx context.Context, key string) (*UserExampleList, error) {
+	return c.listCache.Get(ctx, key)
+}
+
+// SetList write a page of records to cache
+func (c *userExampleCache) SetList(ctx context.Context, key string, data *UserExampleList, duration time.Duration) error {
+	if data == nil || key == "" {
+		return nil
+	}
+	return c.listCache.Set(ctx, key, data, duration)
+}
+
+// DelList make all cached pages stale by bumping the version of the list namespace
+func (c *userExampleCache) DelList(ctx context.Context) error {
+	return c.tagCache.BumpNamespace(ctx, userExampleListNamespace)
+}
+
+// GetIndexID get the id of the record by the values of the unique index from cache
+func (c *userExampleCache) GetIndexID(ctx context.Context, index string, values ...interface{}) (uint64, error) {
+	return c.indexCache.Get(ctx, getUserExampleIndexKey(index, values))
+}
+
+// SetIndexID write the id of the record by the values of the unique index to cache, the id is checked by the
+// caller after getting the record, so the stale id is deleted when the indexed columns are changed
+func (c *userExampleCache) SetIndexID(ctx context.Context, id uint64, duration time.Duration, index string, values ...interface{}) error {
+	if id == 0 {
+		return nil
+	}
+	return c.indexCache.Set(ctx, getUserExampleIndexKey(index, values), id, duration)
+}
+
+// DelIndexID delete the id of the record by the values of the unique index from cache
+func (c *userExampleCache) DelIndexID(ctx context.Context, index string, values ...interface{}) error {
+	return c.indexCache.Del(ctx, getUserExampleIndexKey(index, values))
+}
+
+func getUserExampleIndexKey(index string, values []interface{}) string {
+	key := index
+	for _, v := range values {
+		key += ":" + fmt.Sprint(v)
 	}
-	return nil
+	return key
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/hankyu66/sponge/internal/model"
 
 	"github.com/hankyu66/sponge/pkg/gotest"
+	"github.com/hankyu66/sponge/pkg/mysql/query"
 	"github.com/hankyu66/sponge/pkg/utils"
 
 	"github.com/stretchr/testify/assert"
@@ -128,10 +129,89 @@ func Test_userExampleCache_SetCacheWithNotFound(t *testing.T) {
 	}
 }
 
+func Test_userExampleCache_List(t *testing.T) {
+	c := newUserExampleCache()
+	defer c.Close()
+
+	var records []*model.UserExample
+	for _, data := range c.TestDataSlice {
+		records = append(records, data.(*model.UserExample))
+	}
+	params := &query.Params{Page: 0, Size: 10}
+
+	key, err := c.ICache.(UserExampleCache).GetListKey(c.Ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.ICache.(UserExampleCache).SetList(c.Ctx, key, &UserExampleList{Records: records, Total: 2}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.ICache.(UserExampleCache).GetList(c.Ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(2), got.Total)
+	assert.Equal(t, len(records), len(got.Records))
+
+	// the cached pages are stale after DelList
+	err = c.ICache.(UserExampleCache).DelList(c.Ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := c.ICache.(UserExampleCache).GetListKey(c.Ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, key, newKey)
+	_, err = c.ICache.(UserExampleCache).GetList(c.Ctx, newKey)
+	assert.Error(t, err)
+
+	// nil data
+	err = c.ICache.(UserExampleCache).SetList(c.Ctx, newKey, nil, time.Hour)
+	assert.NoError(t, err)
+}
+
+func Test_userExampleCache_IndexID(t *testing.T) {
+	c := newUserExampleCache()
+	defer c.Close()
+
+	record := c.TestDataSlice[0].(*model.UserExample)
+	err := c.ICache.(UserExampleCache).SetIndexID(c.Ctx, record.ID, time.Hour, "email", "foo@bar.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := c.ICache.(UserExampleCache).GetIndexID(c.Ctx, "email", "foo@bar.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, record.ID, id)
+	_, err = c.ICache.(UserExampleCache).GetIndexID(c.Ctx, "email", "bar@foo.com")
+	assert.Error(t, err)
+
+	err = c.ICache.(UserExampleCache).DelIndexID(c.Ctx, "email", "foo@bar.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.ICache.(UserExampleCache).GetIndexID(c.Ctx, "email", "foo@bar.com")
+	assert.Error(t, err)
+
+	// zero id
+	err = c.ICache.(UserExampleCache).SetIndexID(c.Ctx, 0, time.Hour, "email", "foo@bar.com")
+	assert.NoError(t, err)
+}
+
 func TestNewUserExampleCache(t *testing.T) {
 	c := NewUserExampleCache(&model.CacheType{
 		CType: "memory",
 	})
+	assert.NotNil(t, c)
 
+	gc := gotest.NewCache(nil)
+	defer gc.Close()
+	c = NewUserExampleCache(&model.CacheType{
+		CType: "multi",
+		Rdb:   gc.RedisClient,
+	})
 	assert.NotNil(t, c)
 }
@@ -31,6 +31,7 @@ func Set(conf *Config) {
 type Config struct {
 	App        App          `yaml:"app" json:"app"`
 	Consul     Consul       `yaml:"consul" json:"consul"`
+	Database   Database     `yaml:"database" json:"database"`
 	Etcd       Etcd         `yaml:"etcd" json:"etcd"`
 	Grpc       Grpc         `yaml:"grpc" json:"grpc"`
 	GrpcClient []GrpcClient `yaml:"grpcClient" json:"grpcClient"`
@@ -46,6 +47,10 @@ type Consul struct {
 	Addr string `yaml:"addr" json:"addr"`
 }
 
+type Database struct {
+	Driver string `yaml:"driver" json:"driver"`
+}
+
 type Etcd struct {
 	Addrs []string `yaml:"addrs" json:"addrs"`
 }
@@ -77,6 +82,7 @@ type ServerSecure struct {
 }
 
 type App struct {
+	BloomFilter           string  `yaml:"bloomFilter" json:"bloomFilter"`
 	CacheType             string  `yaml:"cacheType" json:"cacheType"`
 	EnableCircuitBreaker  bool    `yaml:"enableCircuitBreaker" json:"enableCircuitBreaker"`
 	EnableHTTPProfile     bool    `yaml:"enableHTTPProfile" json:"enableHTTPProfile"`
@@ -114,10 +120,15 @@ type Mysql struct {
 }
 
 type Redis struct {
-	DialTimeout  int    `yaml:"dialTimeout" json:"dialTimeout"`
-	Dsn          string `yaml:"dsn" json:"dsn"`
-	ReadTimeout  int    `yaml:"readTimeout" json:"readTimeout"`
-	WriteTimeout int    `yaml:"writeTimeout" json:"writeTimeout"`
+	Addrs        []string `yaml:"addrs" json:"addrs"`
+	DialTimeout  int      `yaml:"dialTimeout" json:"dialTimeout"`
+	Dsn          string   `yaml:"dsn" json:"dsn"`
+	MasterName   string   `yaml:"masterName" json:"masterName"`
+	Mode         string   `yaml:"mode" json:"mode"`
+	Password     string   `yaml:"password" json:"password"`
+	ReadTimeout  int      `yaml:"readTimeout" json:"readTimeout"`
+	Username     string   `yaml:"username" json:"username"`
+	WriteTimeout int      `yaml:"writeTimeout" json:"writeTimeout"`
 }
 
 type Grpc struct {
@@ -3,15 +3,18 @@ package dao
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/hankyu66/sponge/internal/cache"
 	"github.com/hankyu66/sponge/internal/model"
 
 	cacheBase "github.com/hankyu66/sponge/pkg/cache"
+	"github.com/hankyu66/sponge/pkg/logger"
+	"github.com/hankyu66/sponge/pkg/mysql"
 	"github.com/hankyu66/sponge/pkg/mysql/query"
-	"github.com/hankyu66/sponge/pkg/utils"
+	// sharding code start
+	"github.com/hankyu66/sponge/pkg/mysql/sharding"
+	// sharding code end
 
 	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
@@ -19,6 +22,28 @@ import (
 
 var _ UserExampleDao = (*userExampleDao)(nil)
 
+// todo generate the column schema code to here
+// delete the templates code start
+
+// userExampleColumns the columns that are allowed in query conditions and sort fields, other columns are rejected
+var userExampleColumns = query.NewColumnSchema(
+	query.ColumnRule{Name: "id", Type: query.TypeUint, Filter: true, Sort: true},
+	query.ColumnRule{Name: "created_at", Type: query.TypeTime, Filter: true, Sort: true},
+	query.ColumnRule{Name: "updated_at", Type: query.TypeTime, Filter: true, Sort: true},
+	query.ColumnRule{Name: "deleted_at", Type: query.TypeTime, Filter: true, Sort: true},
+	query.ColumnRule{Name: "name", Type: query.TypeString, Filter: true, Sort: true},
+	query.ColumnRule{Name: "password", Type: query.TypeString}, // sensitive column, set Filter or Sort to true to allow it
+	query.ColumnRule{Name: "email", Type: query.TypeString, Filter: true, Sort: true},
+	query.ColumnRule{Name: "phone", Type: query.TypeString, Filter: true, Sort: true},
+	query.ColumnRule{Name: "avatar", Type: query.TypeString, Filter: true, Sort: true},
+	query.ColumnRule{Name: "age", Type: query.TypeInt, Filter: true, Sort: true},
+	query.ColumnRule{Name: "gender", Type: query.TypeInt, Filter: true, Sort: true},
+	query.ColumnRule{Name: "status", Type: query.TypeInt, Filter: true, Sort: true},
+	query.ColumnRule{Name: "login_at", Type: query.TypeInt, Filter: true, Sort: true},
+)
+
+// delete the templates code end
+
 // UserExampleDao defining the dao interface
 type UserExampleDao interface {
 	Create(ctx context.Context, table *model.UserExample) error
@@ -30,67 +55,144 @@ type UserExampleDao interface {
 	GetByIDs(ctx context.Context, ids []uint64) (map[uint64]*model.UserExample, error)
 	GetByColumns(ctx context.Context, params *query.Params) ([]*model.UserExample, int64, error)
 
+	// soft delete code start
+	Restore(ctx context.Context, id uint64) error
+	ListDeleted(ctx context.Context, params *query.Params) ([]*model.UserExample, int64, error)
+	Purge(ctx context.Context, id uint64) error
+	// soft delete code end
+
 	CreateByTx(ctx context.Context, tx *gorm.DB, table *model.UserExample) (uint64, error)
 	UpdateByTx(ctx context.Context, tx *gorm.DB, table *model.UserExample) error
 	DeleteByTx(ctx context.Context, tx *gorm.DB, id uint64) error
+	// todo generate the relation methods declaration to here
+	// todo generate the index methods declaration to here
 }
 
 type userExampleDao struct {
-	db    *gorm.DB
-	cache cache.UserExampleCache
-	sfg   *singleflight.Group
+	db     *gorm.DB
+	cache  cache.UserExampleCache
+	loader *cacheBase.Loader[uint64, *model.UserExample]
+	sfg    *singleflight.Group
 }
 
 // NewUserExampleDao creating the dao interface
 func NewUserExampleDao(db *gorm.DB, xCache cache.UserExampleCache) UserExampleDao {
-	return &userExampleDao{
-		db:    db,
-		cache: xCache,
-		sfg:   new(singleflight.Group),
+	opts := []cacheBase.LoaderOption{
+		cacheBase.WithLoaderExpiration(cache.UserExampleExpireTime),
+		cacheBase.WithLoaderJitter(cache.UserExampleExpireTime / 10),
+		cacheBase.WithLoaderNotFoundErr(model.ErrRecordNotFound),
+	}
+	filter := xCache.BloomFilter()
+	if filter != nil {
+		opts = append(opts, cacheBase.WithLoaderBloomFilter(filter))
+	}
+
+	// sharding code start
+	// if the table is sharded by the rule generated in the model, the queries without the sharding key are executed
+	// on all shards, and the ids are generated by the IDGenerator of the rule, so they are unique across the shards
+	db = sharding.WithScatterIfSharded(db, &model.UserExample{})
+	// sharding code end
+
+	d := &userExampleDao{
+		db:     db,
+		cache:  xCache,
+		loader: cacheBase.NewLoader[uint64, *model.UserExample](xCache, opts...),
+		sfg:    new(singleflight.Group),
+	}
+	if filter != nil {
+		go d.fillBloomFilter()
+	}
+
+	return d
+}
+
+// fill the bloom filter with all ids in the table, ids that are not in the filter are rejected after it is filled
+func (d *userExampleDao) fillBloomFilter() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	err := d.loader.FillFilter(ctx, func(ctx context.Context, add func(ids ...uint64) error) error {
+		var lastID uint64
+		for {
+			var ids []uint64
+			err := d.db.WithContext(ctx).Model(&model.UserExample{}).Where("id > ?", lastID).
+				Order("id").Limit(1000).Pluck("id", &ids).Error
+			if err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+			if err = add(ids...); err != nil {
+				return err
+			}
+			lastID = ids[len(ids)-1]
+		}
+	})
+	if err != nil {
+		logger.Warn("fill bloom filter error", logger.Err(err), logger.String("table", "userExample"))
 	}
 }
 
-// Create a record, insert the record and the id value is written back to the table
+// Create a record, insert the record and the id value is written back to the table,
+// if ctx is in a transaction of mysql.Transaction, the record is inserted in the transaction and the cache is deleted after commit.
 func (d *userExampleDao) Create(ctx context.Context, table *model.UserExample) error {
-	err := d.db.WithContext(ctx).Create(table).Error
-	_ = d.cache.Del(ctx, table.ID)
-	return err
+	err := mysql.GetDB(ctx, d.db).Create(table).Error
+	if err != nil {
+		return err
+	}
+
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.loader.AddToFilter(ctx, table.ID)
+		_ = d.cache.Del(ctx, table.ID)
+		_ = d.cache.DelList(ctx)
+	})
+	return nil
 }
 
 // DeleteByID delete a record by id
 func (d *userExampleDao) DeleteByID(ctx context.Context, id uint64) error {
-	err := d.db.WithContext(ctx).Where("id = ?", id).Delete(&model.UserExample{}).Error
+	err := mysql.GetDB(ctx, d.db).Where("id = ?", id).Delete(&model.UserExample{}).Error
 	if err != nil {
 		return err
 	}
 
 	// delete cache
-	_ = d.cache.Del(ctx, id)
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.cache.Del(ctx, id)
+		_ = d.cache.DelList(ctx)
+	})
 
 	return nil
 }
 
 // DeleteByIDs delete records by batch id
 func (d *userExampleDao) DeleteByIDs(ctx context.Context, ids []uint64) error {
-	err := d.db.WithContext(ctx).Where("id IN (?)", ids).Delete(&model.UserExample{}).Error
+	err := mysql.GetDB(ctx, d.db).Where("id IN (?)", ids).Delete(&model.UserExample{}).Error
 	if err != nil {
 		return err
 	}
 
 	// delete cache
-	for _, id := range ids {
-		_ = d.cache.Del(ctx, id)
-	}
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		for _, id := range ids {
+			_ = d.cache.Del(ctx, id)
+		}
+		_ = d.cache.DelList(ctx)
+	})
 
 	return nil
 }
 
 // UpdateByID update a record by id
 func (d *userExampleDao) UpdateByID(ctx context.Context, table *model.UserExample) error {
-	err := d.updateDataByID(ctx, d.db, table)
+	err := d.updateDataByID(ctx, mysql.GetDB(ctx, d.db), table)
 
 	// delete cache
-	_ = d.cache.Del(ctx, table.ID)
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.cache.Del(ctx, table.ID)
+		_ = d.cache.DelList(ctx)
+	})
 
 	return err
 }
@@ -100,6 +202,7 @@ func (d *userExampleDao) updateDataByID(ctx context.Context, db *gorm.DB, table
 		return errors.New("id cannot be 0")
 	}
 
+	tx := db.WithContext(ctx).Model(table)
 	update := map[string]interface{}{}
 	// todo generate the update fields code to here
 	// delete the templates code start
@@ -129,62 +232,52 @@ func (d *userExampleDao) updateDataByID(ctx context.Context, db *gorm.DB, table
 	}
 	// delete the templates code end
 
-	return db.WithContext(ctx).Model(table).Updates(update).Error
-}
-
-// GetByID get a record by id
-func (d *userExampleDao) GetByID(ctx context.Context, id uint64) (*model.UserExample, error) {
-	record, err := d.cache.Get(ctx, id)
-	if err == nil {
-		return record, nil
+	result := tx.Updates(update)
+	if result.Error != nil {
+		return result.Error
 	}
-
-	if errors.Is(err, model.ErrCacheNotFound) {
-		// for the same id, prevent high concurrent simultaneous access to mysql
-		val, err, _ := d.sfg.Do(utils.Uint64ToStr(id), func() (interface{}, error) { //nolint
-			table := &model.UserExample{}
-			err = d.db.WithContext(ctx).Where("id = ?", id).First(table).Error
-			if err != nil {
-				// if data is empty, set not found cache to prevent cache penetration, default expiration time 10 minutes
-				if errors.Is(err, model.ErrRecordNotFound) {
-					err = d.cache.SetCacheWithNotFound(ctx, id)
-					if err != nil {
-						return nil, err
-					}
-					return nil, model.ErrRecordNotFound
-				}
-				return nil, err
-			}
-			// set cache
-			err = d.cache.Set(ctx, id, table, cache.UserExampleExpireTime)
-			if err != nil {
-				return nil, fmt.Errorf("cache.Set error: %v, id=%d", err, id)
-			}
-			return table, nil
-		})
+	// optimistic locking, no record is updated if the record does not exist or the version has been changed by others
+	if _, ok := update["version"]; ok && result.RowsAffected == 0 {
+		var count int64
+		err := db.WithContext(ctx).Model(&model.UserExample{}).Where("id = ?", table.ID).Count(&count).Error
 		if err != nil {
-			return nil, err
+			return err
 		}
-		table, ok := val.(*model.UserExample)
-		if !ok {
-			return nil, model.ErrRecordNotFound
+		if count == 0 {
+			return model.ErrRecordNotFound
 		}
-		return table, nil
-	} else if errors.Is(err, cacheBase.ErrPlaceholder) {
-		return nil, model.ErrRecordNotFound
+		return mysql.ErrVersionConflict
 	}
 
-	// fail fast, if cache error return, don't request to db
-	return nil, err
+	return nil
+}
+
+// GetByID get a record by id, get from cache first, if missed, get from mysql and set cache,
+// if ctx is in a transaction, get from the transaction without cache, so that the uncommitted changes are visible and not cached.
+func (d *userExampleDao) GetByID(ctx context.Context, id uint64) (*model.UserExample, error) {
+	if mysql.InTransaction(ctx) {
+		return d.getByID(ctx, id)
+	}
+	return d.loader.Load(ctx, id, d.getByID)
+}
+
+func (d *userExampleDao) getByID(ctx context.Context, id uint64) (*model.UserExample, error) {
+	table := &model.UserExample{}
+	err := mysql.GetDB(ctx, d.db).Where("id = ?", id).First(table).Error
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
 }
 
 // GetByCondition get a record by condition
 // query conditions:
 //
 //	name: column name
-//	exp: expressions, which default is "=",  support =, !=, >, >=, <, <=, like, in
-//	value: column value, if exp=in, multiple values are separated by commas
+//	exp: expressions, which default is "=",  support =, !=, >, >=, <, <=, like, in, notin, between, isnull, isnotnull, notlike, prefixlike, suffixlike
+//	value: column value, if exp=in, notin or between, multiple values are an array or separated by commas
 //	logic: logical type, defaults to and when value is null, only &(and), ||(or)
+//	group: sub-conditions enclosed in parentheses, e.g. (a = 1 OR b = 2) AND c > 3
 //
 // example: find a male aged 20
 //
@@ -196,17 +289,21 @@ func (d *userExampleDao) GetByID(ctx context.Context, id uint64) (*model.UserExa
 //		},
 //		{
 //			Name:  "gender",
-//			Value: "male",
+//			Value: 1,
 //		},
 //	}
 func (d *userExampleDao) GetByCondition(ctx context.Context, c *query.Conditions) (*model.UserExample, error) {
+	err := c.CheckColumns(userExampleColumns)
+	if err != nil {
+		return nil, errors.New("query params error: " + err.Error())
+	}
 	queryStr, args, err := c.ConvertToGorm()
 	if err != nil {
-		return nil, err
+		return nil, errors.New("query params error: " + err.Error())
 	}
 
 	table := &model.UserExample{}
-	err = d.db.WithContext(ctx).Where(queryStr, args...).First(table).Error
+	err = mysql.GetDB(ctx, d.db).Where(queryStr, args...).First(table).Error
 	if err != nil {
 		return nil, err
 	}
@@ -214,62 +311,30 @@ func (d *userExampleDao) GetByCondition(ctx context.Context, c *query.Conditions
 	return table, nil
 }
 
-// GetByIDs list of records by batch id
+// GetByIDs list of records by batch id, if ctx is in a transaction, get from the transaction without cache
 func (d *userExampleDao) GetByIDs(ctx context.Context, ids []uint64) (map[uint64]*model.UserExample, error) {
-	itemMap, err := d.cache.MultiGet(ctx, ids)
-	if err != nil {
-		return nil, err
+	if mysql.InTransaction(ctx) {
+		return d.getByIDs(ctx, ids)
 	}
+	return d.loader.LoadMany(ctx, ids, d.getByIDs)
+}
 
-	var missedIDs []uint64
-	for _, id := range ids {
-		_, ok := itemMap[id]
-		if !ok {
-			missedIDs = append(missedIDs, id)
-			continue
-		}
+func (d *userExampleDao) getByIDs(ctx context.Context, ids []uint64) (map[uint64]*model.UserExample, error) {
+	var records []*model.UserExample
+	err := mysql.GetDB(ctx, d.db).Where("id IN (?)", ids).Find(&records).Error
+	if err != nil {
+		return nil, err
 	}
-
-	// get missed data
-	if len(missedIDs) > 0 {
-		// find the id of an active placeholder, i.e. an id that does not exist in mysql
-		var realMissedIDs []uint64
-		for _, id := range missedIDs {
-			_, err = d.cache.Get(ctx, id)
-			if errors.Is(err, cacheBase.ErrPlaceholder) {
-				continue
-			}
-			realMissedIDs = append(realMissedIDs, id)
-		}
-
-		if len(realMissedIDs) > 0 {
-			var missedData []*model.UserExample
-			err = d.db.WithContext(ctx).Where("id IN (?)", realMissedIDs).Find(&missedData).Error
-			if err != nil {
-				return nil, err
-			}
-
-			if len(missedData) > 0 {
-				for _, data := range missedData {
-					itemMap[data.ID] = data
-				}
-				err = d.cache.MultiSet(ctx, missedData, cache.UserExampleExpireTime)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				for _, id := range realMissedIDs {
-					_ = d.cache.SetCacheWithNotFound(ctx, id)
-				}
-			}
-		}
+	itemMap := make(map[uint64]*model.UserExample, len(records))
+	for _, record := range records {
+		itemMap[record.ID] = record
 	}
-
 	return itemMap, nil
 }
 
-// GetByColumns get records by paging and column information,
-// Note: query performance degrades when table rows are very large because of the use of offset.
+// GetByColumns get records by paging and column information, get from cache first, if missed, get from mysql and set cache,
+// the cached pages are stale after the table is changed by the dao, if ctx is in a transaction, get from the transaction without cache.
+// Note: query performance degrades when table rows are very large because of the use of offset, use cursor instead.
 //
 // params includes paging parameters and query parameters
 // paging parameters (required):
@@ -277,13 +342,16 @@ func (d *userExampleDao) GetByIDs(ctx context.Context, ids []uint64) (map[uint64
 //	page: page number, starting from 0
 //	size: lines per page
 //	sort: sort fields, default is id backwards, you can add - sign before the field to indicate reverse order, no - sign to indicate ascending order, multiple fields separated by comma
+//	cursor: cursor of keyset pagination, get it from params.NextCursor(records) of the previous page,
+//	        page is ignored and total is not counted (returns 0) if it is not empty
 //
 // query parameters (not required):
 //
 //	name: column name
-//	exp: expressions, which default is "=",  support =, !=, >, >=, <, <=, like, in
-//	value: column value, if exp=in, multiple values are separated by commas
+//	exp: expressions, which default is "=",  support =, !=, >, >=, <, <=, like, in, notin, between, isnull, isnotnull, notlike, prefixlike, suffixlike
+//	value: column value, if exp=in, notin or between, multiple values are an array or separated by commas
 //	logic: logical type, defaults to and when value is null, only &(and), ||(or)
+//	group: sub-conditions enclosed in parentheses, e.g. (a = 1 OR b = 2) AND c > 3
 //
 // example: search for a male over 20 years of age
 //
@@ -302,14 +370,83 @@ func (d *userExampleDao) GetByIDs(ctx context.Context, ids []uint64) (map[uint64
 //		},
 //	}
 func (d *userExampleDao) GetByColumns(ctx context.Context, params *query.Params) ([]*model.UserExample, int64, error) {
+	err := checkUserExampleParams(params)
+	if err != nil {
+		return nil, 0, err
+	}
+	if mysql.InTransaction(ctx) {
+		return d.getByColumns(ctx, params)
+	}
+
+	// the key is got before querying mysql, if the table changes during the query, the result is cached under a stale version
+	key, err := d.cache.GetListKey(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	list, err := d.cache.GetList(ctx, key)
+	if err == nil {
+		return list.Records, list.Total, nil
+	}
+	// fail fast, if cache error return, don't request to mysql
+	if !errors.Is(err, model.ErrCacheNotFound) {
+		return nil, 0, err
+	}
+
+	// for the same page, prevent high concurrent simultaneous access to mysql
+	val, err, _ := d.sfg.Do(key, func() (interface{}, error) {
+		records, total, err := d.getByColumns(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		list := &cache.UserExampleList{Records: records, Total: total}
+		_ = d.cache.SetList(ctx, key, list, cache.UserExampleListExpireTime)
+		return list, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	list = val.(*cache.UserExampleList)
+
+	return list.Records, list.Total, nil
+}
+
+// check the query params before getting the cache key, the values of the query conditions are converted to the column types
+func checkUserExampleParams(params *query.Params) error {
+	// only the columns in the allow-list can be used, "ignore count" is not a sort field
+	checkParams := *params
+	if checkParams.Sort == "ignore count" {
+		checkParams.Sort = ""
+	}
+	err := checkParams.CheckColumns(userExampleColumns)
+	if err != nil {
+		return errors.New("query params error: " + err.Error())
+	}
+	_, _, err = params.ConvertToGormConditions()
+	if err != nil {
+		return errors.New("query params error: " + err.Error())
+	}
+	_, _, _, err = params.ConvertToCursor()
+	if err != nil {
+		return errors.New("query params error: " + err.Error())
+	}
+	return nil
+}
+
+func (d *userExampleDao) getByColumns(ctx context.Context, params *query.Params, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.UserExample, int64, error) {
 	queryStr, args, err := params.ConvertToGormConditions()
 	if err != nil {
 		return nil, 0, errors.New("query params error: " + err.Error())
 	}
 
+	// the order ends with id, so that the records have a unique order and the next cursor is valid in both paging modes
+	order, cursorStr, cursorArgs, err := params.ConvertToCursor()
+	if err != nil {
+		return nil, 0, errors.New("query params error: " + err.Error())
+	}
+
 	var total int64
-	if params.Sort != "ignore count" { // determine if count is required
-		err = d.db.WithContext(ctx).Model(&model.UserExample{}).Select([]string{"id"}).Where(queryStr, args...).Count(&total).Error
+	if params.Sort != "ignore count" && params.Cursor == "" { // determine if count is required
+		err = mysql.GetDB(ctx, d.db).Scopes(scopes...).Model(&model.UserExample{}).Select([]string{"id"}).Where(queryStr, args...).Count(&total).Error
 		if err != nil {
 			return nil, 0, err
 		}
@@ -319,23 +456,94 @@ func (d *userExampleDao) GetByColumns(ctx context.Context, params *query.Params)
 	}
 
 	records := []*model.UserExample{}
-	order, limit, offset := params.ConvertToPage()
-	err = d.db.WithContext(ctx).Order(order).Limit(limit).Offset(offset).Where(queryStr, args...).Find(&records).Error
+	_, limit, offset := params.ConvertToPage()
+	db := mysql.GetDB(ctx, d.db).Scopes(scopes...).Where(queryStr, args...)
+	if params.Cursor != "" { // keyset pagination, get the records after the cursor instead of skipping offset records
+		db = db.Where(cursorStr, cursorArgs...)
+		offset = 0
+	}
+	err = db.Order(order).Limit(limit).Offset(offset).Find(&records).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// soft delete code start
+
+// Restore restore a soft deleted record by id
+func (d *userExampleDao) Restore(ctx context.Context, id uint64) error {
+	result := mysql.GetDB(ctx, d.db).Scopes(mysql.Deleted).Model(&model.UserExample{}).
+		Where("id = ?", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrRecordNotFound
+	}
+
+	// delete cache, the record may be cached as not found after it was deleted
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.loader.AddToFilter(ctx, id)
+		_ = d.cache.Del(ctx, id)
+		_ = d.cache.DelList(ctx)
+	})
+
+	return nil
+}
+
+// ListDeleted get soft deleted records by paging and column information, the params are the same as GetByColumns,
+// the records are got from mysql without cache.
+func (d *userExampleDao) ListDeleted(ctx context.Context, params *query.Params) ([]*model.UserExample, int64, error) {
+	err := checkUserExampleParams(params)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	return records, total, err
+	return d.getByColumns(ctx, params, mysql.Deleted)
 }
 
-// CreateByTx create a record in the database using the provided transaction
+// Purge permanently delete a soft deleted record by id, records that are not soft deleted cannot be purged
+func (d *userExampleDao) Purge(ctx context.Context, id uint64) error {
+	result := mysql.GetDB(ctx, d.db).Scopes(mysql.Deleted).Where("id = ?", id).Delete(&model.UserExample{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.ErrRecordNotFound
+	}
+
+	// delete cache
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.cache.Del(ctx, id)
+	})
+
+	return nil
+}
+
+// soft delete code end
+
+// CreateByTx create a record in the database using the provided transaction,
+// it is recommended to use mysql.Transaction and Create instead. the cache is deleted after commit only if
+// tx is got by mysql.GetDB in mysql.Transaction, otherwise it is deleted immediately before the transaction is committed.
 func (d *userExampleDao) CreateByTx(ctx context.Context, tx *gorm.DB, table *model.UserExample) (uint64, error) {
+	ctx = mysql.TxContext(ctx, tx)
 	err := tx.WithContext(ctx).Create(table).Error
-	return table.ID, err
+	if err != nil {
+		return 0, err
+	}
+
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.loader.AddToFilter(ctx, table.ID)
+		_ = d.cache.DelList(ctx)
+	})
+	return table.ID, nil
 }
 
-// DeleteByTx delete a record by id in the database using the provided transaction
+// DeleteByTx delete a record by id in the database using the provided transaction, the cache is deleted as CreateByTx
 func (d *userExampleDao) DeleteByTx(ctx context.Context, tx *gorm.DB, id uint64) error {
+	ctx = mysql.TxContext(ctx, tx)
 	update := map[string]interface{}{
 		"deleted_at": time.Now(),
 	}
@@ -345,17 +553,28 @@ func (d *userExampleDao) DeleteByTx(ctx context.Context, tx *gorm.DB, id uint64)
 	}
 
 	// delete cache
-	_ = d.cache.Del(ctx, id)
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.cache.Del(ctx, id)
+		_ = d.cache.DelList(ctx)
+	})
 
 	return nil
 }
 
-// UpdateByTx update a record by id in the database using the provided transaction
+// UpdateByTx update a record by id in the database using the provided transaction, the cache is deleted as CreateByTx
 func (d *userExampleDao) UpdateByTx(ctx context.Context, tx *gorm.DB, table *model.UserExample) error {
+	ctx = mysql.TxContext(ctx, tx)
 	err := d.updateDataByID(ctx, tx, table)
 
 	// delete cache
-	_ = d.cache.Del(ctx, table.ID)
+	mysql.AfterCommit(ctx, func(ctx context.Context) {
+		_ = d.cache.Del(ctx, table.ID)
+		_ = d.cache.DelList(ctx)
+	})
 
 	return err
 }
+
+// todo generate the relation methods code to here
+
+// todo generate the index methods code to here
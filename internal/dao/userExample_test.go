@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/hankyu66/sponge/internal/model"
 
 	"github.com/hankyu66/sponge/pkg/gotest"
+	"github.com/hankyu66/sponge/pkg/mysql"
 	"github.com/hankyu66/sponge/pkg/mysql/query"
 	"github.com/hankyu66/sponge/pkg/utils"
 
@@ -181,6 +183,39 @@ func Test_userExampleDao_GetByID(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func Test_userExampleDao_BloomFilter(t *testing.T) {
+	testData := &model.UserExample{}
+	testData.ID = 1
+
+	c := gotest.NewCache(map[string]interface{}{utils.Uint64ToStr(testData.ID): testData})
+	c.ICache = cache.NewUserExampleCache(&model.CacheType{
+		CType:       "redis",
+		Rdb:         c.RedisClient,
+		BloomFilter: "memory",
+	})
+	d := gotest.NewDao(c, testData)
+	defer d.Close()
+
+	// fill the bloom filter with the ids in the table
+	d.SQLMock.ExpectQuery("SELECT .*").
+		WithArgs(0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testData.ID))
+	d.SQLMock.ExpectQuery("SELECT .*").
+		WithArgs(testData.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	d.IDao = NewUserExampleDao(d.DB, c.ICache.(cache.UserExampleCache))
+
+	// ids that are not in the filter are rejected without querying mysql
+	assert.Eventually(t, func() bool {
+		_, err := d.IDao.(UserExampleDao).GetByID(d.Ctx, 100)
+		return errors.Is(err, model.ErrRecordNotFound)
+	}, time.Second, time.Millisecond*10)
+	err := d.SQLMock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func Test_userExampleDao_GetByCondition(t *testing.T) {
 	d := newUserExampleDao()
 	defer d.Close()
@@ -223,6 +258,12 @@ func Test_userExampleDao_GetByCondition(t *testing.T) {
 		},
 	})
 	assert.Error(t, err)
+
+	// the value cannot be converted to the column type
+	_, err = d.IDao.(UserExampleDao).GetByCondition(d.Ctx, &query.Conditions{
+		Columns: []query.Column{{Name: "id", Value: "1 or 1=1"}},
+	})
+	assert.Error(t, err)
 }
 
 func Test_userExampleDao_GetByIDs(t *testing.T) {
@@ -270,11 +311,68 @@ func Test_userExampleDao_GetByColumns(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// hit the list cache, mysql is not queried
+	records, _, err := d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{
+		Page: 0,
+		Size: 10,
+		Sort: "ignore count",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(records))
+
 	err = d.SQLMock.ExpectationsWereMet()
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	// the list cache is stale after the table changes
+	d.SQLMock.ExpectBegin()
+	d.SQLMock.ExpectExec("UPDATE .*").
+		WithArgs(d.AnyTime, testData.ID).
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	d.SQLMock.ExpectCommit()
+	err = d.IDao.(UserExampleDao).DeleteByID(d.Ctx, testData.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SQLMock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+	records, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{
+		Page: 0,
+		Size: 10,
+		Sort: "ignore count",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(records))
+
+	err = d.SQLMock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keyset pagination, get the records after the cursor without counting
+	params := &query.Params{Size: 1}
+	params.Cursor, err = params.NextCursor([]*model.UserExample{testData})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SQLMock.ExpectQuery("SELECT .* WHERE .*id < .* ORDER BY id DESC").
+		WithArgs(int64(testData.ID)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.SQLMock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{Size: 1, Cursor: "foo"})
+	assert.Error(t, err)
+
 	// err test
 	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{
 		Page: 0,
@@ -289,12 +387,177 @@ func Test_userExampleDao_GetByColumns(t *testing.T) {
 	})
 	assert.Error(t, err)
 
+	// the columns are not in the allow-list or the values do not match the column types
+	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{Size: 10, Sort: "-age,(select 1)"})
+	assert.Error(t, err)
+	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{
+		Size:    10,
+		Columns: []query.Column{{Name: "1=1 or id", Value: 1}},
+	})
+	assert.Error(t, err)
+	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{
+		Size:    10,
+		Columns: []query.Column{{Name: "age", Exp: query.Like, Value: "1"}},
+	})
+	assert.Error(t, err)
+
+	// the sensitive column is not allowed in query conditions and sort fields
+	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{
+		Size:    10,
+		Columns: []query.Column{{Name: "password", Exp: query.PrefixLike, Value: "a"}},
+	})
+	assert.ErrorContains(t, err, "column 'password' is not allowed in conditions")
+	_, _, err = d.IDao.(UserExampleDao).GetByColumns(d.Ctx, &query.Params{Size: 10, Sort: "password"})
+	assert.ErrorContains(t, err, "column 'password' is not allowed in sort")
+
 	// error test
 	dao := &userExampleDao{}
 	_, _, err = dao.GetByColumns(context.Background(), &query.Params{Columns: []query.Column{{}}})
 	t.Log(err)
 }
 
+// soft delete code start
+
+func Test_userExampleDao_Restore(t *testing.T) {
+	d := newUserExampleDao()
+	defer d.Close()
+	testData := d.TestData.(*model.UserExample)
+
+	d.SQLMock.ExpectBegin()
+	d.SQLMock.ExpectExec("UPDATE .* deleted_at IS NOT NULL").
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	d.SQLMock.ExpectCommit()
+
+	err := d.IDao.(UserExampleDao).Restore(d.Ctx, testData.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the record is not soft deleted
+	d.SQLMock.ExpectBegin()
+	d.SQLMock.ExpectExec("UPDATE .*").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	d.SQLMock.ExpectCommit()
+	err = d.IDao.(UserExampleDao).Restore(d.Ctx, 2)
+	assert.ErrorIs(t, err, model.ErrRecordNotFound)
+
+	err = d.SQLMock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_userExampleDao_ListDeleted(t *testing.T) {
+	d := newUserExampleDao()
+	defer d.Close()
+	testData := d.TestData.(*model.UserExample)
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(testData.ID, testData.CreatedAt, testData.UpdatedAt, time.Now())
+	d.SQLMock.ExpectQuery("SELECT .* WHERE deleted_at IS NOT NULL .*").WillReturnRows(rows)
+
+	records, _, err := d.IDao.(UserExampleDao).ListDeleted(d.Ctx, &query.Params{
+		Page: 0,
+		Size: 10,
+		Sort: "ignore count",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(records))
+
+	err = d.SQLMock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// error test
+	_, _, err = d.IDao.(UserExampleDao).ListDeleted(d.Ctx, &query.Params{
+		Size:    10,
+		Columns: []query.Column{{Name: "unknown", Value: 1}},
+	})
+	assert.Error(t, err)
+}
+
+func Test_userExampleDao_Purge(t *testing.T) {
+	d := newUserExampleDao()
+	defer d.Close()
+	testData := d.TestData.(*model.UserExample)
+
+	d.SQLMock.ExpectBegin()
+	d.SQLMock.ExpectExec("DELETE FROM .* deleted_at IS NOT NULL").
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	d.SQLMock.ExpectCommit()
+
+	err := d.IDao.(UserExampleDao).Purge(d.Ctx, testData.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the record is not soft deleted
+	d.SQLMock.ExpectBegin()
+	d.SQLMock.ExpectExec("DELETE FROM .*").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	d.SQLMock.ExpectCommit()
+	err = d.IDao.(UserExampleDao).Purge(d.Ctx, 2)
+	assert.ErrorIs(t, err, model.ErrRecordNotFound)
+
+	err = d.SQLMock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// soft delete code end
+
+func Test_userExampleDao_Transaction(t *testing.T) {
+	d := newUserExampleDao()
+	defer d.Close()
+	testData := d.TestData.(*model.UserExample)
+	testData.Name = "foo"
+	xCache := d.Cache.ICache.(cache.UserExampleCache)
+	err := xCache.Set(d.Ctx, testData.ID, testData, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.SQLMock.ExpectBegin()
+	d.SQLMock.ExpectQuery("SELECT .*").
+		WithArgs(testData.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(testData.ID, "bar"))
+	d.SQLMock.ExpectExec("UPDATE .*").
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	d.SQLMock.ExpectCommit()
+
+	err = mysql.Transaction(d.Ctx, d.DB, func(ctx context.Context) error {
+		// get from the transaction rather than cache
+		record, err := d.IDao.(UserExampleDao).GetByID(ctx, testData.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, "bar", record.Name)
+
+		err = d.IDao.(UserExampleDao).UpdateByID(ctx, &model.UserExample{Model: record.Model, Name: "baz"})
+		if err != nil {
+			return err
+		}
+
+		// the cache is deleted after commit
+		_, err = xCache.Get(ctx, testData.ID)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = xCache.Get(d.Ctx, testData.ID)
+	assert.ErrorIs(t, err, model.ErrCacheNotFound)
+
+	err = d.SQLMock.ExpectationsWereMet()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func Test_userExampleDao_CreateByTx(t *testing.T) {
 	d := newUserExampleDao()
 	defer d.Close()
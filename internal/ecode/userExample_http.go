@@ -19,5 +19,8 @@ var (
 	ErrGetByConditionUserExample = errcode.NewError(userExampleBaseCode+6, "failed to get "+userExampleName+" details by conditions")
 	ErrListByIDsUserExample      = errcode.NewError(userExampleBaseCode+7, "failed to list by batch ids "+userExampleName)
 	ErrListUserExample           = errcode.NewError(userExampleBaseCode+8, "failed to list of "+userExampleName)
+	// soft delete code start
+	ErrListDeletedUserExample = errcode.NewError(userExampleBaseCode+9, "failed to list of deleted "+userExampleName)
+	// soft delete code end
 	// error codes are globally unique, adding 1 to the previous error code
 )
@@ -19,5 +19,8 @@ var (
 	StatusGetByConditionUserExample = errcode.NewRPCStatus(_userExampleBaseCode+6, "failed to get "+_userExampleName+" by conditions")
 	StatusListByIDsUserExample      = errcode.NewRPCStatus(_userExampleBaseCode+7, "failed to list by batch ids "+_userExampleName)
 	StatusListUserExample           = errcode.NewRPCStatus(_userExampleBaseCode+8, "failed to list of "+_userExampleName)
+	// soft delete code start
+	StatusListDeletedUserExample = errcode.NewRPCStatus(_userExampleBaseCode+9, "failed to list of deleted "+_userExampleName)
+	// soft delete code end
 	// error codes are globally unique, adding 1 to the previous error code
 )
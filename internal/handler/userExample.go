@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/hankyu66/sponge/internal/cache"
 	"github.com/hankyu66/sponge/internal/dao"
@@ -12,6 +13,7 @@ import (
 	"github.com/hankyu66/sponge/pkg/gin/middleware"
 	"github.com/hankyu66/sponge/pkg/gin/response"
 	"github.com/hankyu66/sponge/pkg/logger"
+	"github.com/hankyu66/sponge/pkg/mysql"
 	"github.com/hankyu66/sponge/pkg/mysql/query"
 	"github.com/hankyu66/sponge/pkg/utils"
 
@@ -31,6 +33,13 @@ type UserExampleHandler interface {
 	GetByCondition(c *gin.Context)
 	ListByIDs(c *gin.Context)
 	List(c *gin.Context)
+
+	// soft delete code start
+	Restore(c *gin.Context)
+	ListDeleted(c *gin.Context)
+	Purge(c *gin.Context)
+	// soft delete code end
+	// todo generate the index handler declaration to here
 }
 
 type userExampleHandler struct {
@@ -175,6 +184,16 @@ func (h *userExampleHandler) UpdateByID(c *gin.Context) {
 	ctx := middleware.WrapCtx(c)
 	err = h.iDao.UpdateByID(ctx, userExample)
 	if err != nil {
+		if errors.Is(err, mysql.ErrVersionConflict) {
+			logger.Warn("UpdateByID error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.AlreadyExists.WithDetails(err.Error()))
+			return
+		}
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("UpdateByID not found", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.NotFound)
+			return
+		}
 		logger.Error("UpdateByID error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
 		response.Output(c, ecode.InternalServerError.ToHTTPCode())
 		return
@@ -253,6 +272,9 @@ func (h *userExampleHandler) GetByCondition(c *gin.Context) {
 		if errors.Is(err, query.ErrNotFound) {
 			logger.Warn("GetByCondition not found", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
 			response.Error(c, ecode.NotFound)
+		} else if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("GetByCondition error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.InvalidParams.WithDetails(err.Error()))
 		} else {
 			logger.Error("GetByCondition error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
 			response.Output(c, ecode.InternalServerError.ToHTTPCode())
@@ -317,7 +339,7 @@ func (h *userExampleHandler) ListByIDs(c *gin.Context) {
 
 // List of records by query parameters
 // @Summary list of userExamples by query parameters
-// @Description list of userExamples by paging and conditions
+// @Description list of userExamples by paging and conditions, paging by page number or by cursor (nextCursor of the previous page)
 // @Tags userExample
 // @accept json
 // @Produce json
@@ -336,6 +358,11 @@ func (h *userExampleHandler) List(c *gin.Context) {
 	ctx := middleware.WrapCtx(c)
 	userExamples, total, err := h.iDao.GetByColumns(ctx, &form.Params)
 	if err != nil {
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("GetByColumns error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.InvalidParams.WithDetails(err.Error()))
+			return
+		}
 		logger.Error("GetByColumns error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
 		response.Output(c, ecode.InternalServerError.ToHTTPCode())
 		return
@@ -346,13 +373,138 @@ func (h *userExampleHandler) List(c *gin.Context) {
 		response.Error(c, ecode.ErrListUserExample)
 		return
 	}
+	nextCursor, err := form.Params.NextCursor(userExamples)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+		response.Error(c, ecode.ErrListUserExample)
+		return
+	}
 
 	response.Success(c, gin.H{
 		"userExamples": data,
 		"total":        total,
+		"nextCursor":   nextCursor,
 	})
 }
 
+// soft delete code start
+
+// Restore restore a deleted record by id
+// @Summary restore userExample
+// @Description restore a deleted userExample by id
+// @Tags userExample
+// @accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} types.RestoreUserExampleRespond{}
+// @Router /api/v1/userExample/{id}/restore [put]
+func (h *userExampleHandler) Restore(c *gin.Context) {
+	_, id, isAbort := getUserExampleIDFromPath(c)
+	if isAbort {
+		response.Error(c, ecode.InvalidParams)
+		return
+	}
+
+	ctx := middleware.WrapCtx(c)
+	err := h.iDao.Restore(ctx, id)
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("Restore not found", logger.Err(err), logger.Any("id", id), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.NotFound)
+		} else {
+			logger.Error("Restore error", logger.Err(err), logger.Any("id", id), middleware.GCtxRequestIDField(c))
+			response.Output(c, ecode.InternalServerError.ToHTTPCode())
+		}
+		return
+	}
+
+	response.Success(c)
+}
+
+// ListDeleted list of deleted records by query parameters
+// @Summary list of deleted userExamples by query parameters
+// @Description list of deleted userExamples by paging and conditions
+// @Tags userExample
+// @accept json
+// @Produce json
+// @Param data body types.Params true "query parameters"
+// @Success 200 {object} types.ListDeletedUserExamplesRespond{}
+// @Router /api/v1/userExample/list/deleted [post]
+func (h *userExampleHandler) ListDeleted(c *gin.Context) {
+	form := &types.ListDeletedUserExamplesRequest{}
+	err := c.ShouldBindJSON(form)
+	if err != nil {
+		logger.Warn("ShouldBindJSON error: ", logger.Err(err), middleware.GCtxRequestIDField(c))
+		response.Error(c, ecode.InvalidParams)
+		return
+	}
+
+	ctx := middleware.WrapCtx(c)
+	userExamples, total, err := h.iDao.ListDeleted(ctx, &form.Params)
+	if err != nil {
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("ListDeleted error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.InvalidParams.WithDetails(err.Error()))
+			return
+		}
+		logger.Error("ListDeleted error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+		response.Output(c, ecode.InternalServerError.ToHTTPCode())
+		return
+	}
+
+	data, err := convertUserExamples(userExamples)
+	if err != nil {
+		response.Error(c, ecode.ErrListDeletedUserExample)
+		return
+	}
+	nextCursor, err := form.Params.NextCursor(userExamples)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+		response.Error(c, ecode.ErrListDeletedUserExample)
+		return
+	}
+
+	response.Success(c, gin.H{
+		"userExamples": data,
+		"total":        total,
+		"nextCursor":   nextCursor,
+	})
+}
+
+// Purge permanently delete a deleted record by id
+// @Summary purge userExample
+// @Description permanently delete a deleted userExample by id, records that are not deleted cannot be purged
+// @Tags userExample
+// @accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} types.PurgeUserExampleRespond{}
+// @Router /api/v1/userExample/{id}/purge [delete]
+func (h *userExampleHandler) Purge(c *gin.Context) {
+	_, id, isAbort := getUserExampleIDFromPath(c)
+	if isAbort {
+		response.Error(c, ecode.InvalidParams)
+		return
+	}
+
+	ctx := middleware.WrapCtx(c)
+	err := h.iDao.Purge(ctx, id)
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("Purge not found", logger.Err(err), logger.Any("id", id), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.NotFound)
+		} else {
+			logger.Error("Purge error", logger.Err(err), logger.Any("id", id), middleware.GCtxRequestIDField(c))
+			response.Output(c, ecode.InternalServerError.ToHTTPCode())
+		}
+		return
+	}
+
+	response.Success(c)
+}
+
+// soft delete code end
+
 func getUserExampleIDFromPath(c *gin.Context) (string, uint64, bool) {
 	idStr := c.Param("id")
 	id, err := utils.StrToUint64E(idStr)
@@ -386,3 +538,5 @@ func convertUserExamples(fromValues []*model.UserExample) ([]*types.UserExampleO
 
 	return toValues, nil
 }
+
+// todo generate the index handler code to here
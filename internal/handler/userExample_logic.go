@@ -13,6 +13,7 @@ import (
 
 	"github.com/hankyu66/sponge/pkg/gin/middleware"
 	"github.com/hankyu66/sponge/pkg/logger"
+	"github.com/hankyu66/sponge/pkg/mysql"
 	"github.com/hankyu66/sponge/pkg/mysql/query"
 
 	"github.com/jinzhu/copier"
@@ -108,6 +109,14 @@ func (h *userExamplePbHandler) UpdateByID(ctx context.Context, req *serverNameEx
 
 	err = h.userExampleDao.UpdateByID(ctx, userExample)
 	if err != nil {
+		if errors.Is(err, mysql.ErrVersionConflict) {
+			logger.Warn("UpdateByID error", logger.Err(err), logger.Any("userExample", userExample), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.AlreadyExists.WithDetails(err.Error()).Err()
+		}
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("UpdateByID not found", logger.Err(err), logger.Any("userExample", userExample), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.NotFound.Err()
+		}
 		logger.Error("UpdateByID error", logger.Err(err), logger.Any("userExample", userExample), middleware.CtxRequestIDField(ctx))
 		return nil, ecode.InternalServerError.Err()
 	}
@@ -170,6 +179,10 @@ func (h *userExamplePbHandler) GetByCondition(ctx context.Context, req *serverNa
 			logger.Warn("GetByID error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
 			return nil, ecode.NotFound.Err()
 		}
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("GetByCondition error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.InvalidParams.WithDetails(err.Error()).Err()
+		}
 		logger.Error("GetByID error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
 		return nil, ecode.InternalServerError.Err()
 	}
@@ -235,7 +248,7 @@ func (h *userExamplePbHandler) List(ctx context.Context, req *serverNameExampleV
 	if err != nil {
 		if strings.Contains(err.Error(), "query params error:") {
 			logger.Warn("GetByColumns error", logger.Err(err), logger.Any("params", params), middleware.CtxRequestIDField(ctx))
-			return nil, ecode.InvalidParams.Err()
+			return nil, ecode.InvalidParams.WithDetails(err.Error()).Err()
 		}
 		logger.Error("GetByColumns error", logger.Err(err), logger.Any("params", params), middleware.CtxRequestIDField(ctx))
 		return nil, ecode.InternalServerError.Err()
@@ -250,13 +263,112 @@ func (h *userExamplePbHandler) List(ctx context.Context, req *serverNameExampleV
 		}
 		userExamples = append(userExamples, data)
 	}
+	nextCursor, err := params.NextCursor(records)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("params", params), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.ErrListUserExample.Err()
+	}
 
 	return &serverNameExampleV1.ListUserExampleReply{
 		Total:        total,
 		UserExamples: userExamples,
+		NextCursor:   nextCursor,
 	}, nil
 }
 
+// soft delete code start
+
+// Restore restore a deleted record by id
+func (h *userExamplePbHandler) Restore(ctx context.Context, req *serverNameExampleV1.RestoreUserExampleRequest) (*serverNameExampleV1.RestoreUserExampleReply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InvalidParams.Err()
+	}
+
+	err = h.userExampleDao.Restore(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("Restore error", logger.Err(err), logger.Any("id", req.Id), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.NotFound.Err()
+		}
+		logger.Error("Restore error", logger.Err(err), logger.Any("id", req.Id), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InternalServerError.Err()
+	}
+
+	return &serverNameExampleV1.RestoreUserExampleReply{}, nil
+}
+
+// ListDeleted list of deleted records by query parameters
+func (h *userExamplePbHandler) ListDeleted(ctx context.Context, req *serverNameExampleV1.ListDeletedUserExampleRequest) (*serverNameExampleV1.ListDeletedUserExampleReply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InvalidParams.Err()
+	}
+
+	params := &query.Params{}
+	err = copier.Copy(params, req.Params)
+	if err != nil {
+		return nil, ecode.ErrListDeletedUserExample.Err()
+	}
+	params.Size = int(req.Params.Limit)
+
+	records, total, err := h.userExampleDao.ListDeleted(ctx, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("ListDeleted error", logger.Err(err), logger.Any("params", params), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.InvalidParams.WithDetails(err.Error()).Err()
+		}
+		logger.Error("ListDeleted error", logger.Err(err), logger.Any("params", params), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InternalServerError.Err()
+	}
+
+	userExamples := []*serverNameExampleV1.UserExample{}
+	for _, record := range records {
+		data, err := convertUserExamplePb(record)
+		if err != nil {
+			logger.Warn("convertUserExample error", logger.Err(err), logger.Any("id", record.ID), middleware.CtxRequestIDField(ctx))
+			continue
+		}
+		userExamples = append(userExamples, data)
+	}
+	nextCursor, err := params.NextCursor(records)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("params", params), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.ErrListDeletedUserExample.Err()
+	}
+
+	return &serverNameExampleV1.ListDeletedUserExampleReply{
+		Total:        total,
+		UserExamples: userExamples,
+		NextCursor:   nextCursor,
+	}, nil
+}
+
+// Purge permanently delete a deleted record by id
+func (h *userExamplePbHandler) Purge(ctx context.Context, req *serverNameExampleV1.PurgeUserExampleRequest) (*serverNameExampleV1.PurgeUserExampleReply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InvalidParams.Err()
+	}
+
+	err = h.userExampleDao.Purge(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("Purge error", logger.Err(err), logger.Any("id", req.Id), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.NotFound.Err()
+		}
+		logger.Error("Purge error", logger.Err(err), logger.Any("id", req.Id), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InternalServerError.Err()
+	}
+
+	return &serverNameExampleV1.PurgeUserExampleReply{}, nil
+}
+
+// soft delete code end
+
 func convertUserExamplePb(record *model.UserExample) (*serverNameExampleV1.UserExample, error) {
 	value := &serverNameExampleV1.UserExample{}
 	err := copier.Copy(value, record)
@@ -268,3 +380,5 @@ func convertUserExamplePb(record *model.UserExample) (*serverNameExampleV1.UserE
 	value.UpdatedAt = record.UpdatedAt.Unix()
 	return value, nil
 }
+
+// todo generate the index pb handler code to here
@@ -170,6 +170,55 @@ func newUserExamplePbHandler() *gotest.Handler {
 				response.Success(c)
 			},
 		},
+		// soft delete code start
+		{
+			FuncName: "Restore",
+			Method:   http.MethodPut,
+			Path:     "/userExample/:id/restore",
+			HandlerFunc: func(c *gin.Context) {
+				req := &serverNameExampleV1.RestoreUserExampleRequest{
+					Id: utils.StrToUint64(c.Param("id")),
+				}
+				_, err := iHandler.Restore(c, req)
+				if err != nil {
+					response.Error(c, ecode.ErrUpdateByIDUserExample)
+					return
+				}
+				response.Success(c)
+			},
+		},
+		{
+			FuncName: "ListDeleted",
+			Method:   http.MethodPost,
+			Path:     "/userExample/list/deleted",
+			HandlerFunc: func(c *gin.Context) {
+				req := &serverNameExampleV1.ListDeletedUserExampleRequest{}
+				_ = c.ShouldBindJSON(req)
+				_, err := iHandler.ListDeleted(c, req)
+				if err != nil {
+					response.Error(c, ecode.ErrListDeletedUserExample)
+					return
+				}
+				response.Success(c)
+			},
+		},
+		{
+			FuncName: "Purge",
+			Method:   http.MethodDelete,
+			Path:     "/userExample/:id/purge",
+			HandlerFunc: func(c *gin.Context) {
+				req := &serverNameExampleV1.PurgeUserExampleRequest{
+					Id: utils.StrToUint64(c.Param("id")),
+				}
+				_, err := iHandler.Purge(c, req)
+				if err != nil {
+					response.Error(c, ecode.ErrDeleteByIDUserExample)
+					return
+				}
+				response.Success(c)
+			},
+		},
+		// soft delete code end
 	}
 
 	h.GoRunHTTPServer(testFns)
@@ -466,6 +515,102 @@ func Test_userExamplePbHandler_List(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// soft delete code start
+
+func Test_userExamplePbHandler_Restore(t *testing.T) {
+	h := newUserExamplePbHandler()
+	defer h.Close()
+	testData := h.TestData.(*model.UserExample)
+
+	h.MockDao.SQLMock.ExpectBegin()
+	h.MockDao.SQLMock.ExpectExec("UPDATE .*").
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	h.MockDao.SQLMock.ExpectCommit()
+
+	result := &gohttp.StdResult{}
+	err := gohttp.Put(result, h.GetRequestURL("Restore", testData.ID), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 0 {
+		t.Fatalf("%+v", result)
+	}
+
+	// zero id error test
+	err = gohttp.Put(result, h.GetRequestURL("Restore", 0), nil)
+	assert.NoError(t, err)
+
+	// restore error test
+	err = gohttp.Put(result, h.GetRequestURL("Restore", 111), nil)
+	assert.NoError(t, err)
+}
+
+func Test_userExamplePbHandler_ListDeleted(t *testing.T) {
+	h := newUserExamplePbHandler()
+	defer h.Close()
+	testData := h.TestData.(*model.UserExample)
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(testData.ID, testData.CreatedAt, testData.UpdatedAt, time.Now())
+
+	h.MockDao.SQLMock.ExpectQuery("SELECT .*").WillReturnRows(rows)
+
+	result := &gohttp.StdResult{}
+	err := gohttp.Post(result, h.GetRequestURL("ListDeleted"), &serverNameExampleV1.ListDeletedUserExampleRequest{
+		Params: &types.Params{
+			Page:  0,
+			Limit: 10,
+			Sort:  "ignore count", // ignore test count
+		}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 0 {
+		t.Fatalf("%+v", result)
+	}
+
+	// nil params error test
+	err = gohttp.Post(result, h.GetRequestURL("ListDeleted"), &serverNameExampleV1.ListDeletedUserExampleRequest{})
+	assert.NoError(t, err)
+
+	// get error test
+	err = gohttp.Post(result, h.GetRequestURL("ListDeleted"), &serverNameExampleV1.ListDeletedUserExampleRequest{Params: &types.Params{
+		Page:  0,
+		Limit: 10,
+	}})
+	assert.NoError(t, err)
+}
+
+func Test_userExamplePbHandler_Purge(t *testing.T) {
+	h := newUserExamplePbHandler()
+	defer h.Close()
+	testData := h.TestData.(*model.UserExample)
+
+	h.MockDao.SQLMock.ExpectBegin()
+	h.MockDao.SQLMock.ExpectExec("DELETE FROM .*").
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	h.MockDao.SQLMock.ExpectCommit()
+
+	result := &gohttp.StdResult{}
+	err := gohttp.Delete(result, h.GetRequestURL("Purge", testData.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 0 {
+		t.Fatalf("%+v", result)
+	}
+
+	// zero id error test
+	err = gohttp.Delete(result, h.GetRequestURL("Purge", 0))
+	assert.NoError(t, err)
+
+	// purge error test
+	err = gohttp.Delete(result, h.GetRequestURL("Purge", 111))
+	assert.NoError(t, err)
+}
+
+// soft delete code end
+
 func TestNewUserExamplePbHandler(t *testing.T) {
 	defer func() {
 		recover()
@@ -92,6 +92,26 @@ func newUserExampleHandler() *gotest.Handler {
 			Path:        "/userExample/list",
 			HandlerFunc: iHandler.List,
 		},
+		// soft delete code start
+		{
+			FuncName:    "Restore",
+			Method:      http.MethodPut,
+			Path:        "/userExample/:id/restore",
+			HandlerFunc: iHandler.Restore,
+		},
+		{
+			FuncName:    "ListDeleted",
+			Method:      http.MethodPost,
+			Path:        "/userExample/list/deleted",
+			HandlerFunc: iHandler.ListDeleted,
+		},
+		{
+			FuncName:    "Purge",
+			Method:      http.MethodDelete,
+			Path:        "/userExample/:id/purge",
+			HandlerFunc: iHandler.Purge,
+		},
+		// soft delete code end
 	}
 
 	h.GoRunHTTPServer(testFns)
@@ -348,7 +368,7 @@ func Test_userExampleHandler_List(t *testing.T) {
 	h.MockDao.SQLMock.ExpectQuery("SELECT .*").WillReturnRows(rows)
 
 	result := &gohttp.StdResult{}
-	err := gohttp.Post(result, h.GetRequestURL("List"), &types.ListUserExamplesRequest{query.Params{
+	err := gohttp.Post(result, h.GetRequestURL("List"), &types.ListUserExamplesRequest{Params: query.Params{
 		Page: 0,
 		Size: 10,
 		Sort: "ignore count", // ignore test count
@@ -360,16 +380,149 @@ func Test_userExampleHandler_List(t *testing.T) {
 		t.Fatalf("%+v", result)
 	}
 
+	// get the next page by cursor
+	h.MockDao.SQLMock.ExpectQuery("SELECT COUNT.*").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	rows = sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+		AddRow(testData.ID, testData.CreatedAt, testData.UpdatedAt)
+	h.MockDao.SQLMock.ExpectQuery("SELECT .*").WillReturnRows(rows)
+	err = gohttp.Post(result, h.GetRequestURL("List"), &types.ListUserExamplesRequest{Params: query.Params{
+		Size: 1,
+		Sort: "id",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextCursor := result.Data.(map[string]interface{})["nextCursor"].(string)
+	assert.NotEmpty(t, nextCursor)
+	h.MockDao.SQLMock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+	err = gohttp.Post(result, h.GetRequestURL("List"), &types.ListUserExamplesRequest{Params: query.Params{
+		Size:   1,
+		Sort:   "id",
+		Cursor: nextCursor,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "", result.Data.(map[string]interface{})["nextCursor"])
+
 	// nil params error test
 	err = gohttp.Post(result, h.GetRequestURL("List"), nil)
 
 	// get error test
-	err = gohttp.Post(result, h.GetRequestURL("List"), &types.ListUserExamplesRequest{query.Params{
+	err = gohttp.Post(result, h.GetRequestURL("List"), &types.ListUserExamplesRequest{Params: query.Params{
 		Page: 0,
 		Size: 10,
 	}})
 }
 
+// soft delete code start
+
+func Test_userExampleHandler_Restore(t *testing.T) {
+	h := newUserExampleHandler()
+	defer h.Close()
+	testData := h.TestData.(*model.UserExample)
+
+	h.MockDao.SQLMock.ExpectBegin()
+	h.MockDao.SQLMock.ExpectExec("UPDATE .*").
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	h.MockDao.SQLMock.ExpectCommit()
+
+	result := &gohttp.StdResult{}
+	err := gohttp.Put(result, h.GetRequestURL("Restore", testData.ID), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 0 {
+		t.Fatalf("%+v", result)
+	}
+
+	// the record is not soft deleted
+	h.MockDao.SQLMock.ExpectBegin()
+	h.MockDao.SQLMock.ExpectExec("UPDATE .*").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	h.MockDao.SQLMock.ExpectCommit()
+	err = gohttp.Put(result, h.GetRequestURL("Restore", 2), nil)
+	assert.NoError(t, err)
+	assert.NotZero(t, result.Code)
+
+	// zero id error test
+	err = gohttp.Put(result, h.GetRequestURL("Restore", 0), nil)
+	assert.NoError(t, err)
+
+	// restore error test
+	err = gohttp.Put(result, h.GetRequestURL("Restore", 111), nil)
+	assert.Error(t, err)
+}
+
+func Test_userExampleHandler_ListDeleted(t *testing.T) {
+	h := newUserExampleHandler()
+	defer h.Close()
+	testData := h.TestData.(*model.UserExample)
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(testData.ID, testData.CreatedAt, testData.UpdatedAt, time.Now())
+
+	h.MockDao.SQLMock.ExpectQuery("SELECT .*").WillReturnRows(rows)
+
+	result := &gohttp.StdResult{}
+	err := gohttp.Post(result, h.GetRequestURL("ListDeleted"), &types.ListDeletedUserExamplesRequest{Params: query.Params{
+		Page: 0,
+		Size: 10,
+		Sort: "ignore count", // ignore test count
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 0 {
+		t.Fatalf("%+v", result)
+	}
+
+	// nil params error test
+	err = gohttp.Post(result, h.GetRequestURL("ListDeleted"), nil)
+
+	// unknown column error test
+	err = gohttp.Post(result, h.GetRequestURL("ListDeleted"), &types.ListDeletedUserExamplesRequest{Params: query.Params{
+		Size:    10,
+		Columns: []query.Column{{Name: "unknown", Value: 1}},
+	}})
+
+	// get error test
+	err = gohttp.Post(result, h.GetRequestURL("ListDeleted"), &types.ListDeletedUserExamplesRequest{Params: query.Params{
+		Page: 0,
+		Size: 10,
+	}})
+}
+
+func Test_userExampleHandler_Purge(t *testing.T) {
+	h := newUserExampleHandler()
+	defer h.Close()
+	testData := h.TestData.(*model.UserExample)
+
+	h.MockDao.SQLMock.ExpectBegin()
+	h.MockDao.SQLMock.ExpectExec("DELETE FROM .*").
+		WillReturnResult(sqlmock.NewResult(int64(testData.ID), 1))
+	h.MockDao.SQLMock.ExpectCommit()
+
+	result := &gohttp.StdResult{}
+	err := gohttp.Delete(result, h.GetRequestURL("Purge", testData.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != 0 {
+		t.Fatalf("%+v", result)
+	}
+
+	// zero id error test
+	err = gohttp.Delete(result, h.GetRequestURL("Purge", 0))
+	assert.NoError(t, err)
+
+	// purge error test
+	err = gohttp.Delete(result, h.GetRequestURL("Purge", 111))
+	assert.Error(t, err)
+}
+
+// soft delete code end
+
 func TestNewUserExampleHandler(t *testing.T) {
 	defer func() {
 		recover()
@@ -2,11 +2,16 @@
 package model
 
 import (
+	"io"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/hankyu66/sponge/internal/config"
 
+	"github.com/hankyu66/sponge/pkg/cache"
+	"github.com/hankyu66/sponge/pkg/database"
+	"github.com/hankyu66/sponge/pkg/encoding"
 	"github.com/hankyu66/sponge/pkg/goredis"
 	"github.com/hankyu66/sponge/pkg/logger"
 	"github.com/hankyu66/sponge/pkg/mysql"
@@ -27,45 +32,51 @@ var (
 	db    *gorm.DB
 	once1 sync.Once
 
-	redisCli *redis.Client
+	redisCli redis.UniversalClient
 	once2    sync.Once
 
 	cacheType *CacheType
 	once3     sync.Once
 )
 
-// InitMysql connect mysql
+// InitMysql connect database, the driver is set by database.driver in the configuration file, mysql, postgres or sqlite
 func InitMysql() {
-	opts := []mysql.Option{
-		mysql.WithMaxIdleConns(config.Get().Mysql.MaxIdleConns),
-		mysql.WithMaxOpenConns(config.Get().Mysql.MaxOpenConns),
-		mysql.WithConnMaxLifetime(time.Duration(config.Get().Mysql.ConnMaxLifetime) * time.Minute),
+	opts := []database.Option{
+		database.WithMaxIdleConns(config.Get().Mysql.MaxIdleConns),
+		database.WithMaxOpenConns(config.Get().Mysql.MaxOpenConns),
+		database.WithConnMaxLifetime(time.Duration(config.Get().Mysql.ConnMaxLifetime) * time.Minute),
 	}
 	if config.Get().Mysql.EnableLog {
 		opts = append(opts,
-			mysql.WithLogging(logger.Get()),
-			mysql.WithLogRequestIDKey("request_id"),
+			database.WithLogging(logger.Get()),
+			database.WithLogRequestIDKey("request_id"),
 		)
 	}
 
 	if config.Get().App.EnableTrace {
-		opts = append(opts, mysql.WithEnableTrace())
+		opts = append(opts, database.WithEnableTrace())
+	}
+	if config.Get().App.EnableMetrics {
+		opts = append(opts, database.WithEnableMetrics())
 	}
 
 	// setting mysql slave and master dsn addresses,
 	// if there is no read/write separation, you can comment out the following piece of code
-	opts = append(opts, mysql.WithRWSeparation(
+	opts = append(opts, database.WithRWSeparation(
 		config.Get().Mysql.SlavesDsn,
 		config.Get().Mysql.MastersDsn...,
 	))
 
+	// record the rows changed by create, update and delete to the table audit_log
+	//opts = append(opts, database.WithAudit(), database.WithAuditExcludeColumns("password"))
+
 	// add custom gorm plugin
-	//opts = append(opts, mysql.WithGormPlugin(yourPlugin))
+	//opts = append(opts, database.WithGormPlugin(yourPlugin))
 
 	var err error
-	db, err = mysql.Init(config.Get().Mysql.Dsn, opts...)
+	db, err = database.Init(config.Get().Database.Driver, config.Get().Mysql.Dsn, opts...)
 	if err != nil {
-		panic("mysql.Init error: " + err.Error())
+		panic("database.Init error: " + err.Error())
 	}
 }
 
@@ -86,31 +97,93 @@ func CloseMysql() error {
 		return nil
 	}
 
-	sqlDB, err := db.DB()
-	if err != nil {
-		return err
-	}
-	if sqlDB != nil {
-		return sqlDB.Close()
-	}
-	return nil
+	// close the pools of the master and replicas, and remove their stats from the metrics
+	return mysql.CloseDB(db)
 }
 
 // ------------------------------------------------------------------------------------------
 
 // CacheType cache type
 type CacheType struct {
-	CType string        // cache type  memory or redis
-	Rdb   *redis.Client // if CType=redis, Rdb cannot be empty
+	CType string                // cache type  memory, redis or multi
+	Rdb   redis.UniversalClient // if CType=redis or multi, Rdb cannot be empty
+
+	EnableMetrics bool   // whether to export cache metrics
+	EnableTrace   bool   // whether to create spans for cache operations
+	BloomFilter   string // bloom filter type of record ids, memory or redis, if empty, not used
+
+	mu          sync.Mutex
+	multiCaches map[string]cache.Cache // cache prefix -> multi level cache shared by the caches of the process
+}
+
+// MultiLevelCache get the multi level cache of the prefix, the caches of the process share one multi level cache
+// per prefix, which holds the local memory and the subscription of the invalidation channel, close them by CloseCache.
+func (c *CacheType) MultiLevelCache(cachePrefix string, encode encoding.Encoding) cache.Cache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.multiCaches == nil {
+		c.multiCaches = make(map[string]cache.Cache)
+	}
+	mc, ok := c.multiCaches[cachePrefix]
+	if !ok {
+		mc = cache.NewMultiLevelCache(c.Rdb, cachePrefix, encode, nil)
+		c.multiCaches[cachePrefix] = mc
+	}
+	return mc
+}
+
+// Close close the shared multi level caches
+func (c *CacheType) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var err error
+	for prefix, mc := range c.multiCaches {
+		if closer, ok := mc.(io.Closer); ok {
+			if e := closer.Close(); e != nil {
+				err = e
+			}
+		}
+		delete(c.multiCaches, prefix)
+	}
+	return err
+}
+
+// InstrumentOptions options of metrics and trace for cache
+func (c *CacheType) InstrumentOptions() []cache.InstrumentOption {
+	var opts []cache.InstrumentOption
+	if c.EnableMetrics {
+		opts = append(opts, cache.WithEnableMetrics())
+	}
+	if c.EnableTrace {
+		opts = append(opts, cache.WithEnableTrace())
+	}
+	return opts
+}
+
+// NewBloomFilter create a bloom filter of record ids for a table, return nil if the bloom filter is not used
+func (c *CacheType) NewBloomFilter(name string, expectedItems uint64, falsePositiveRate float64) cache.BloomFilter {
+	switch strings.ToLower(c.BloomFilter) {
+	case "memory":
+		return cache.NewMemoryBloomFilter(expectedItems, falsePositiveRate)
+	case "redis":
+		if c.Rdb == nil {
+			c.Rdb = GetRedisCli()
+		}
+		return cache.NewRedisBloomFilter(c.Rdb, "bloom:"+name, expectedItems, falsePositiveRate)
+	}
+	return nil
 }
 
 // InitCache initial cache
 func InitCache(cType string) {
 	cacheType = &CacheType{
-		CType: cType,
+		CType:         cType,
+		EnableMetrics: config.Get().App.EnableMetrics,
+		EnableTrace:   config.Get().App.EnableTrace,
+		BloomFilter:   config.Get().App.BloomFilter,
 	}
 
-	if cType == "redis" {
+	if cType == "redis" || cType == "multi" {
 		cacheType.Rdb = GetRedisCli()
 	}
 }
@@ -137,15 +210,23 @@ func InitRedis() {
 		opts = append(opts, goredis.WithEnableTrace())
 	}
 
-	var err error
-	redisCli, err = goredis.Init(config.Get().Redis.Dsn, opts...)
-	if err != nil {
-		panic("goredis.Init error: " + err.Error())
+	redisCfg := config.Get().Redis
+	switch strings.ToLower(redisCfg.Mode) {
+	case "sentinel":
+		redisCli = goredis.InitSentinel(redisCfg.MasterName, redisCfg.Addrs, redisCfg.Username, redisCfg.Password, opts...)
+	case "cluster":
+		redisCli = goredis.InitCluster(redisCfg.Addrs, redisCfg.Username, redisCfg.Password, opts...)
+	default: // single
+		var err error
+		redisCli, err = goredis.Init(redisCfg.Dsn, opts...)
+		if err != nil {
+			panic("goredis.Init error: " + err.Error())
+		}
 	}
 }
 
-// GetRedisCli get redis client
-func GetRedisCli() *redis.Client {
+// GetRedisCli get redis client, it may be a single, sentinel or cluster client depending on redis.mode
+func GetRedisCli() redis.UniversalClient {
 	if redisCli == nil {
 		once2.Do(func() {
 			InitRedis()
@@ -155,6 +236,14 @@ func GetRedisCli() *redis.Client {
 	return redisCli
 }
 
+// CloseCache close the multi level caches shared by the caches of the process, call it before CloseRedis
+func CloseCache() error {
+	if cacheType == nil {
+		return nil
+	}
+	return cacheType.Close()
+}
+
 // CloseRedis close redis
 func CloseRedis() error {
 	if redisCli == nil {
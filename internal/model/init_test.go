@@ -8,6 +8,8 @@ import (
 	"github.com/hankyu66/sponge/configs"
 	"github.com/hankyu66/sponge/internal/config"
 
+	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/gotest"
 	"github.com/hankyu66/sponge/pkg/utils"
 
 	"github.com/stretchr/testify/assert"
@@ -83,6 +85,17 @@ func TestInitRedis(t *testing.T) {
 	redisCli = nil
 	_ = CloseRedis()
 	_ = GetRedisCli()
+
+	// sentinel and cluster mode
+	config.Get().Redis.Addrs = []string{"127.0.0.1:6379"}
+	config.Get().Redis.Mode = "sentinel"
+	InitRedis()
+	assert.NotNil(t, redisCli)
+	_ = CloseRedis()
+	config.Get().Redis.Mode = "cluster"
+	InitRedis()
+	assert.NotNil(t, redisCli)
+	_ = CloseRedis()
 }
 
 func TestTableName(t *testing.T) {
@@ -90,15 +103,17 @@ func TestTableName(t *testing.T) {
 }
 
 func TestGetCacheType(t *testing.T) {
-	InitCache("memory")
-	ct := GetCacheType()
-	assert.NotNil(t, ct)
-
 	err := config.Init(configs.Path("serverNameExample.yml"))
 	if err != nil {
 		panic(err)
 	}
 
+	InitCache("memory")
+	ct := GetCacheType()
+	assert.NotNil(t, ct)
+	assert.Equal(t, config.Get().App.EnableMetrics, ct.EnableMetrics)
+	assert.Equal(t, len(ct.InstrumentOptions()) > 0, ct.EnableMetrics || ct.EnableTrace)
+
 	cacheType = nil
 	defer func() { recover() }()
 	ct = GetCacheType()
@@ -109,3 +124,22 @@ func TestGetCacheType(t *testing.T) {
 	ct = GetCacheType()
 	assert.NotNil(t, ct)
 }
+
+func TestCacheType_MultiLevelCache(t *testing.T) {
+	rc := gotest.NewCache(nil)
+	defer rc.Close()
+
+	ct := &CacheType{CType: "multi", Rdb: rc.RedisClient}
+	c1 := ct.MultiLevelCache("", encoding.JSONEncoding{})
+	c2 := ct.MultiLevelCache("", encoding.JSONEncoding{})
+	c3 := ct.MultiLevelCache("prefix", encoding.JSONEncoding{})
+	assert.Same(t, c1, c2)
+	assert.NotSame(t, c1, c3)
+
+	cacheType = ct
+	defer func() { cacheType = nil }()
+	assert.NoError(t, CloseCache())
+	assert.Empty(t, ct.multiCaches)
+	assert.NotSame(t, c1, ct.MultiLevelCache("", encoding.JSONEncoding{}))
+	assert.NoError(t, ct.Close())
+}
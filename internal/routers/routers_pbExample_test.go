@@ -90,3 +90,15 @@ func (m mockGw) ListByIDs(ctx context.Context, req *serverNameExampleV1.ListUser
 func (m mockGw) UpdateByID(ctx context.Context, req *serverNameExampleV1.UpdateUserExampleByIDRequest) (*serverNameExampleV1.UpdateUserExampleByIDReply, error) {
 	return nil, nil
 }
+
+func (m mockGw) Restore(ctx context.Context, req *serverNameExampleV1.RestoreUserExampleRequest) (*serverNameExampleV1.RestoreUserExampleReply, error) {
+	return nil, nil
+}
+
+func (m mockGw) ListDeleted(ctx context.Context, req *serverNameExampleV1.ListDeletedUserExampleRequest) (*serverNameExampleV1.ListDeletedUserExampleReply, error) {
+	return nil, nil
+}
+
+func (m mockGw) Purge(ctx context.Context, req *serverNameExampleV1.PurgeUserExampleRequest) (*serverNameExampleV1.PurgeUserExampleReply, error) {
+	return nil, nil
+}
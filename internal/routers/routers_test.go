@@ -60,6 +60,11 @@ func (u mock) GetByID(c *gin.Context)        { return }
 func (u mock) GetByCondition(c *gin.Context) { return }
 func (u mock) ListByIDs(c *gin.Context)      { return }
 func (u mock) List(c *gin.Context)           { return }
+func (u mock) Restore(c *gin.Context)        { return }
+func (u mock) ListDeleted(c *gin.Context)    { return }
+func (u mock) Purge(c *gin.Context)          { return }
+
+// todo generate the index mock methods to here
 
 func Test_userExampleRouter(t *testing.T) {
 	gin.SetMode(gin.ReleaseMode)
@@ -22,4 +22,11 @@ func userExampleRouter(group *gin.RouterGroup, h handler.UserExampleHandler) {
 	group.POST("/userExample/condition", h.GetByCondition)
 	group.POST("/userExample/list/ids", h.ListByIDs)
 	group.POST("/userExample/list", h.List)
+
+	// soft delete code start
+	group.PUT("/userExample/:id/restore", h.Restore)
+	group.POST("/userExample/list/deleted", h.ListDeleted)
+	group.DELETE("/userExample/:id/purge", h.Purge)
+	// soft delete code end
+	// todo generate the index routes to here
 }
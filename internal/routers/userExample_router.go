@@ -75,4 +75,7 @@ func userExampleMiddlewares(c *middlewareConfig) {
 	//c.setSinglePath("POST", "/api/v1/userExample/list", middleware.Auth())
 	//c.setSinglePath("POST", "/api/v1/userExample", middleware.Auth())
 	//c.setSinglePath("POST", "/api/v1/userExample/condition", middleware.Auth())
+	//c.setSinglePath("PUT", "/api/v1/userExample/:id/restore", middleware.Auth())
+	//c.setSinglePath("POST", "/api/v1/userExample/list/deleted", middleware.Auth())
+	//c.setSinglePath("DELETE", "/api/v1/userExample/:id/purge", middleware.Auth())
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hankyu66/sponge/pkg/grpc/interceptor"
 	"github.com/hankyu66/sponge/pkg/logger"
+	"github.com/hankyu66/sponge/pkg/mysql"
 	"github.com/hankyu66/sponge/pkg/mysql/query"
 
 	"github.com/jinzhu/copier"
@@ -121,6 +122,10 @@ func (s *userExample) UpdateByID(ctx context.Context, req *serverNameExampleV1.U
 	ctx = context.WithValue(ctx, interceptor.ContextRequestIDKey, interceptor.ServerCtxRequestID(ctx)) //nolint
 	err = s.iDao.UpdateByID(ctx, record)
 	if err != nil {
+		if errors.Is(err, mysql.ErrVersionConflict) {
+			logger.Warn("UpdateByID error", logger.Err(err), logger.Any("userExample", record), interceptor.ServerCtxRequestIDField(ctx))
+			return nil, ecode.StatusAborted.Err(ecode.Any("err", err))
+		}
 		logger.Error("UpdateByID error", logger.Err(err), logger.Any("userExample", record), interceptor.ServerCtxRequestIDField(ctx))
 		return nil, ecode.StatusInternalServerError.ToRPCErr()
 	}
@@ -183,6 +188,10 @@ func (s *userExample) GetByCondition(ctx context.Context, req *serverNameExample
 			logger.Warn("GetByCondition error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
 			return nil, ecode.StatusNotFound.Err()
 		}
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("GetByCondition error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+			return nil, ecode.StatusInvalidParams.Err(ecode.Any("err", err))
+		}
 		logger.Error("GetByCondition error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
 		return nil, ecode.StatusInternalServerError.ToRPCErr()
 	}
@@ -248,7 +257,7 @@ func (s *userExample) List(ctx context.Context, req *serverNameExampleV1.ListUse
 	if err != nil {
 		if strings.Contains(err.Error(), "query params error:") {
 			logger.Warn("GetByColumns error", logger.Err(err), logger.Any("params", params), interceptor.ServerCtxRequestIDField(ctx))
-			return nil, ecode.StatusInvalidParams.Err()
+			return nil, ecode.StatusInvalidParams.Err(ecode.Any("err", err))
 		}
 		logger.Error("GetByColumns error", logger.Err(err), logger.Any("params", params), interceptor.ServerCtxRequestIDField(ctx))
 		return nil, ecode.StatusInternalServerError.ToRPCErr()
@@ -263,13 +272,115 @@ func (s *userExample) List(ctx context.Context, req *serverNameExampleV1.ListUse
 		}
 		userExamples = append(userExamples, data)
 	}
+	nextCursor, err := params.NextCursor(records)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("params", params), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusListUserExample.Err()
+	}
 
 	return &serverNameExampleV1.ListUserExampleReply{
 		Total:        total,
 		UserExamples: userExamples,
+		NextCursor:   nextCursor,
+	}, nil
+}
+
+// soft delete code start
+
+// Restore restore a deleted record by id
+func (s *userExample) Restore(ctx context.Context, req *serverNameExampleV1.RestoreUserExampleRequest) (*serverNameExampleV1.RestoreUserExampleReply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInvalidParams.Err()
+	}
+
+	ctx = context.WithValue(ctx, interceptor.ContextRequestIDKey, interceptor.ServerCtxRequestID(ctx)) //nolint
+	err = s.iDao.Restore(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("Restore error", logger.Err(err), logger.Any("id", req.Id), interceptor.ServerCtxRequestIDField(ctx))
+			return nil, ecode.StatusNotFound.Err()
+		}
+		logger.Error("Restore error", logger.Err(err), logger.Any("id", req.Id), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInternalServerError.ToRPCErr()
+	}
+
+	return &serverNameExampleV1.RestoreUserExampleReply{}, nil
+}
+
+// ListDeleted list of deleted records by query parameters
+func (s *userExample) ListDeleted(ctx context.Context, req *serverNameExampleV1.ListDeletedUserExampleRequest) (*serverNameExampleV1.ListDeletedUserExampleReply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInvalidParams.Err()
+	}
+
+	params := &query.Params{}
+	err = copier.Copy(params, req.Params)
+	if err != nil {
+		return nil, ecode.StatusListDeletedUserExample.Err()
+	}
+	params.Size = int(req.Params.Limit)
+
+	ctx = context.WithValue(ctx, interceptor.ContextRequestIDKey, interceptor.ServerCtxRequestID(ctx)) //nolint
+	records, total, err := s.iDao.ListDeleted(ctx, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("ListDeleted error", logger.Err(err), logger.Any("params", params), interceptor.ServerCtxRequestIDField(ctx))
+			return nil, ecode.StatusInvalidParams.Err(ecode.Any("err", err))
+		}
+		logger.Error("ListDeleted error", logger.Err(err), logger.Any("params", params), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInternalServerError.ToRPCErr()
+	}
+
+	userExamples := []*serverNameExampleV1.UserExample{}
+	for _, record := range records {
+		data, err := convertUserExample(record)
+		if err != nil {
+			logger.Warn("convertUserExample error", logger.Err(err), logger.Any("id", record.ID), interceptor.ServerCtxRequestIDField(ctx))
+			continue
+		}
+		userExamples = append(userExamples, data)
+	}
+	nextCursor, err := params.NextCursor(records)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("params", params), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusListDeletedUserExample.Err()
+	}
+
+	return &serverNameExampleV1.ListDeletedUserExampleReply{
+		Total:        total,
+		UserExamples: userExamples,
+		NextCursor:   nextCursor,
 	}, nil
 }
 
+// Purge permanently delete a deleted record by id
+func (s *userExample) Purge(ctx context.Context, req *serverNameExampleV1.PurgeUserExampleRequest) (*serverNameExampleV1.PurgeUserExampleReply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInvalidParams.Err()
+	}
+
+	ctx = context.WithValue(ctx, interceptor.ContextRequestIDKey, interceptor.ServerCtxRequestID(ctx)) //nolint
+	err = s.iDao.Purge(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("Purge error", logger.Err(err), logger.Any("id", req.Id), interceptor.ServerCtxRequestIDField(ctx))
+			return nil, ecode.StatusNotFound.Err()
+		}
+		logger.Error("Purge error", logger.Err(err), logger.Any("id", req.Id), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInternalServerError.ToRPCErr()
+	}
+
+	return &serverNameExampleV1.PurgeUserExampleReply{}, nil
+}
+
+// soft delete code end
+
 func convertUserExample(record *model.UserExample) (*serverNameExampleV1.UserExample, error) {
 	value := &serverNameExampleV1.UserExample{}
 	err := copier.Copy(value, record)
@@ -281,3 +392,5 @@ func convertUserExample(record *model.UserExample) (*serverNameExampleV1.UserExa
 	value.UpdatedAt = record.UpdatedAt.Unix()
 	return value, nil
 }
+
+// todo generate the index service code to here
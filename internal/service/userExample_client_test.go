@@ -151,6 +151,48 @@ func Test_service_userExample_methods(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		// soft delete code start
+
+		{
+			name: "Restore",
+			fn: func() (interface{}, error) {
+				// todo type in the parameters to test
+				req := &serverNameExampleV1.RestoreUserExampleRequest{
+					Id: 100,
+				}
+				return cli.Restore(ctx, req)
+			},
+			wantErr: false,
+		},
+
+		{
+			name: "ListDeleted",
+			fn: func() (interface{}, error) {
+				// todo type in the parameters to test
+				req := &serverNameExampleV1.ListDeletedUserExampleRequest{
+					Params: &types.Params{
+						Page:  0,
+						Limit: 10,
+						Sort:  "",
+					},
+				}
+				return cli.ListDeleted(ctx, req)
+			},
+			wantErr: false,
+		},
+
+		{
+			name: "Purge",
+			fn: func() (interface{}, error) {
+				// todo type in the parameters to test
+				req := &serverNameExampleV1.PurgeUserExampleRequest{
+					Id: 100,
+				}
+				return cli.Purge(ctx, req)
+			},
+			wantErr: false,
+		},
+		// soft delete code end
 	}
 
 	for _, tt := range tests {
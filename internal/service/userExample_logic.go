@@ -69,3 +69,21 @@ func (c *userExampleClient) List(ctx context.Context, req *serverNameExampleV1.L
 	// If required, fill in the code to fetch data from other rpc servers here.
 	return c.userExampleCli.List(ctx, req)
 }
+
+func (c *userExampleClient) Restore(ctx context.Context, req *serverNameExampleV1.RestoreUserExampleRequest) (*serverNameExampleV1.RestoreUserExampleReply, error) {
+	// implement me
+	// If required, fill in the code to fetch data from other rpc servers here.
+	return c.userExampleCli.Restore(ctx, req)
+}
+
+func (c *userExampleClient) ListDeleted(ctx context.Context, req *serverNameExampleV1.ListDeletedUserExampleRequest) (*serverNameExampleV1.ListDeletedUserExampleReply, error) {
+	// implement me
+	// If required, fill in the code to fetch data from other rpc servers here.
+	return c.userExampleCli.ListDeleted(ctx, req)
+}
+
+func (c *userExampleClient) Purge(ctx context.Context, req *serverNameExampleV1.PurgeUserExampleRequest) (*serverNameExampleV1.PurgeUserExampleReply, error) {
+	// implement me
+	// If required, fill in the code to fetch data from other rpc servers here.
+	return c.userExampleCli.Purge(ctx, req)
+}
@@ -288,6 +288,94 @@ func Test_userExampleService_List(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// soft delete code start
+
+func Test_userExampleService_Restore(t *testing.T) {
+	s := newUserExampleService()
+	defer s.Close()
+	testData := &serverNameExampleV1.RestoreUserExampleRequest{
+		Id: s.TestData.(*model.UserExample).ID,
+	}
+
+	s.MockDao.SQLMock.ExpectBegin()
+	s.MockDao.SQLMock.ExpectExec("UPDATE .*").
+		WillReturnResult(sqlmock.NewResult(int64(testData.Id), 1))
+	s.MockDao.SQLMock.ExpectCommit()
+
+	reply, err := s.IServiceClient.(serverNameExampleV1.UserExampleClient).Restore(s.Ctx, testData)
+	assert.NoError(t, err)
+	t.Log(reply.String())
+
+	// zero id error test
+	testData.Id = 0
+	reply, err = s.IServiceClient.(serverNameExampleV1.UserExampleClient).Restore(s.Ctx, testData)
+	assert.Error(t, err)
+
+	// restore error test
+	testData.Id = 111
+	reply, err = s.IServiceClient.(serverNameExampleV1.UserExampleClient).Restore(s.Ctx, testData)
+	assert.Error(t, err)
+}
+
+func Test_userExampleService_ListDeleted(t *testing.T) {
+	s := newUserExampleService()
+	defer s.Close()
+	testData := s.TestData.(*model.UserExample)
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(testData.ID, testData.CreatedAt, testData.UpdatedAt, time.Now())
+
+	s.MockDao.SQLMock.ExpectQuery("SELECT .*").WillReturnRows(rows)
+
+	reply, err := s.IServiceClient.(serverNameExampleV1.UserExampleClient).ListDeleted(s.Ctx, &serverNameExampleV1.ListDeletedUserExampleRequest{
+		Params: &types.Params{
+			Page:  0,
+			Limit: 10,
+			Sort:  "ignore count", // ignore test count
+		},
+	})
+	assert.NoError(t, err)
+	t.Log(reply.String())
+
+	// get error test
+	reply, err = s.IServiceClient.(serverNameExampleV1.UserExampleClient).ListDeleted(s.Ctx, &serverNameExampleV1.ListDeletedUserExampleRequest{
+		Params: &types.Params{
+			Page:  0,
+			Limit: 10,
+		},
+	})
+	assert.Error(t, err)
+}
+
+func Test_userExampleService_Purge(t *testing.T) {
+	s := newUserExampleService()
+	defer s.Close()
+	testData := &serverNameExampleV1.PurgeUserExampleRequest{
+		Id: s.TestData.(*model.UserExample).ID,
+	}
+
+	s.MockDao.SQLMock.ExpectBegin()
+	s.MockDao.SQLMock.ExpectExec("DELETE FROM .*").
+		WillReturnResult(sqlmock.NewResult(int64(testData.Id), 1))
+	s.MockDao.SQLMock.ExpectCommit()
+
+	reply, err := s.IServiceClient.(serverNameExampleV1.UserExampleClient).Purge(s.Ctx, testData)
+	assert.NoError(t, err)
+	t.Log(reply.String())
+
+	// zero id error test
+	testData.Id = 0
+	reply, err = s.IServiceClient.(serverNameExampleV1.UserExampleClient).Purge(s.Ctx, testData)
+	assert.Error(t, err)
+
+	// purge error test
+	testData.Id = 111
+	reply, err = s.IServiceClient.(serverNameExampleV1.UserExampleClient).Purge(s.Ctx, testData)
+	assert.Error(t, err)
+}
+
+// soft delete code end
+
 func Test_convertUserExample(t *testing.T) {
 	testData := &model.UserExample{}
 	testData.ID = 1
@@ -13,19 +13,21 @@ type Result struct {
 
 // Params query parameters
 type Params struct {
-	Page int    `json:"page"`           // page number, starting from page 0
-	Size int    `json:"size"`           // lines per page
-	Sort string `json:"sort,omitempty"` // sorted fields, multi-column sorting separated by commas
+	Page   int    `json:"page"`             // page number, starting from page 0
+	Size   int    `json:"size"`             // lines per page
+	Sort   string `json:"sort,omitempty"`   // sorted fields, multi-column sorting separated by commas
+	Cursor string `json:"cursor,omitempty"` // cursor of keyset pagination, it is the nextCursor of the previous page, page is ignored if it is not empty
 
 	Columns []Column `json:"columns,omitempty"` // query conditions
 }
 
 // Column information
 type Column struct {
-	Name  string      `json:"name"`  // column name
-	Exp   string      `json:"exp"`   // expressions, which default to = when the value is null, have =, !=, >, >=, <, <=, like
-	Value interface{} `json:"value"` // column value
-	Logic string      `json:"logic"` // logical type, defaults to and when value is null, only &(and), ||(or)
+	Name  string      `json:"name"`            // column name
+	Exp   string      `json:"exp"`             // expressions, which default to = when the value is null, have =, !=, >, >=, <, <=, like, in, notin, between, isnull, isnotnull, notlike, prefixlike, suffixlike
+	Value interface{} `json:"value"`           // column value, multiple values of in, notin and between are an array or separated by commas
+	Logic string      `json:"logic"`           // logical type, defaults to and when value is null, only &(and), ||(or)
+	Group []Column    `json:"group,omitempty"` // sub-conditions enclosed in parentheses, name, exp and value are ignored if it is not empty
 }
 
 // Conditions query conditions
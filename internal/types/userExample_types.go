@@ -131,5 +131,37 @@ type ListUserExamplesRespond struct {
 	Msg  string `json:"msg"`  // return information description
 	Data struct {
 		UserExamples []UserExampleObjDetail `json:"userExamples"`
+		Total        int64                  `json:"total"`      // total number of records, it is 0 in cursor mode
+		NextCursor   string                 `json:"nextCursor"` // cursor of the next page, empty if there are no more records
 	} `json:"data"` // return data
 }
+
+// soft delete code start
+
+// RestoreUserExampleRespond only for api docs
+type RestoreUserExampleRespond struct {
+	Result
+}
+
+// ListDeletedUserExamplesRequest request params
+type ListDeletedUserExamplesRequest struct {
+	query.Params
+}
+
+// ListDeletedUserExamplesRespond only for api docs
+type ListDeletedUserExamplesRespond struct {
+	Code int    `json:"code"` // return code
+	Msg  string `json:"msg"`  // return information description
+	Data struct {
+		UserExamples []UserExampleObjDetail `json:"userExamples"`
+		Total        int64                  `json:"total"`      // total number of records, it is 0 in cursor mode
+		NextCursor   string                 `json:"nextCursor"` // cursor of the next page, empty if there are no more records
+	} `json:"data"` // return data
+}
+
+// PurgeUserExampleRespond only for api docs
+type PurgeUserExampleRespond struct {
+	Result
+}
+
+// soft delete code end
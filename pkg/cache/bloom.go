@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// maximum number of bits of a redis bitmap
+const maxRedisBitmapBits = uint64(1) << 32
+
+// BloomFilter probabilistic set of keys, Exists returns false only if the key has definitely not been added,
+// it is used to reject the keys of records that do not exist before accessing the cache and the database.
+type BloomFilter interface {
+	Add(ctx context.Context, keys ...string) error
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// calculate the number of bits and hash functions from the expected number of items and the false positive rate
+func bloomParams(expectedItems uint64, falsePositiveRate float64) (uint64, uint64) {
+	if expectedItems == 0 {
+		expectedItems = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	m := uint64(math.Ceil(-float64(expectedItems) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if m < 64 {
+		m = 64
+	}
+	k := uint64(math.Round(float64(m) / float64(expectedItems) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return m, k
+}
+
+// positions of the key in a bitmap of m bits, use double hashing to simulate k hash functions
+func bloomLocations(key string, m uint64, k uint64) []uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	h1 := h.Sum64()
+	h = fnv.New64()
+	_, _ = h.Write([]byte(key))
+	h2 := h.Sum64() | 1
+
+	locations := make([]uint64, k)
+	for i := uint64(0); i < k; i++ {
+		locations[i] = (h1 + i*h2) % m
+	}
+	return locations
+}
+
+// ------------------------------------------------------------------------------------------
+
+type memoryBloomFilter struct {
+	mu   sync.RWMutex
+	bits []uint64
+	m    uint64
+	k    uint64
+}
+
+// NewMemoryBloomFilter create a bloom filter in memory, it is only visible to the current process,
+// so it is suitable for a single instance, use NewRedisBloomFilter for multiple instances.
+func NewMemoryBloomFilter(expectedItems uint64, falsePositiveRate float64) BloomFilter {
+	m, k := bloomParams(expectedItems, falsePositiveRate)
+	return &memoryBloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// Add keys
+func (f *memoryBloomFilter) Add(ctx context.Context, keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		for _, loc := range bloomLocations(key, f.m, f.k) {
+			f.bits[loc/64] |= 1 << (loc % 64)
+		}
+	}
+	return nil
+}
+
+// Exists whether the key may have been added
+func (f *memoryBloomFilter) Exists(ctx context.Context, key string) (bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, loc := range bloomLocations(key, f.m, f.k) {
+		if f.bits[loc/64]&(1<<(loc%64)) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ------------------------------------------------------------------------------------------
+
+type redisBloomFilter struct {
+	client redis.UniversalClient
+	key    string
+	m      uint64
+	k      uint64
+}
+
+// NewRedisBloomFilter create a bloom filter on a redis bitmap, it is shared by all instances,
+// the bitmap is a single key, so it works in redis cluster too.
+func NewRedisBloomFilter(client redis.UniversalClient, key string, expectedItems uint64, falsePositiveRate float64) BloomFilter {
+	m, k := bloomParams(expectedItems, falsePositiveRate)
+	if m > maxRedisBitmapBits {
+		m = maxRedisBitmapBits
+	}
+	return &redisBloomFilter{
+		client: client,
+		key:    key,
+		m:      m,
+		k:      k,
+	}
+}
+
+// Add keys
+func (f *redisBloomFilter) Add(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	pipeline := f.client.Pipeline()
+	for _, key := range keys {
+		for _, loc := range bloomLocations(key, f.m, f.k) {
+			pipeline.SetBit(ctx, f.key, int64(loc), 1)
+		}
+	}
+	_, err := pipeline.Exec(ctx)
+	return err
+}
+
+// Exists whether the key may have been added
+func (f *redisBloomFilter) Exists(ctx context.Context, key string) (bool, error) {
+	pipeline := f.client.Pipeline()
+	locations := bloomLocations(key, f.m, f.k)
+	cmds := make([]*redis.IntCmd, 0, len(locations))
+	for _, loc := range locations {
+		cmds = append(cmds, pipeline.GetBit(ctx, f.key, int64(loc)))
+	}
+	_, err := pipeline.Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, cmd := range cmds {
+		if cmd.Val() == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hankyu66/sponge/pkg/gotest"
+	"github.com/hankyu66/sponge/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_bloomParams(t *testing.T) {
+	m, k := bloomParams(1000000, 0.001)
+	assert.Equal(t, uint64(14377588), m)
+	assert.Equal(t, uint64(10), k)
+
+	m, k = bloomParams(0, 0)
+	assert.Equal(t, uint64(64), m)
+	assert.Equal(t, uint64(44), k)
+}
+
+func testBloomFilter(t *testing.T, f BloomFilter) {
+	ctx := context.Background()
+	keys := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		keys = append(keys, utils.IntToStr(i))
+	}
+	err := f.Add(ctx, keys...)
+	assert.NoError(t, err)
+
+	for _, key := range keys {
+		ok, err := f.Exists(ctx, key)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	falsePositives := 0
+	for i := 1000; i < 2000; i++ {
+		ok, err := f.Exists(ctx, utils.IntToStr(i))
+		assert.NoError(t, err)
+		if ok {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 50)
+}
+
+func TestMemoryBloomFilter(t *testing.T) {
+	testBloomFilter(t, NewMemoryBloomFilter(1000, 0.01))
+}
+
+func TestRedisBloomFilter(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+
+	f := NewRedisBloomFilter(c.RedisClient, "bloom:test", 1000, 0.01)
+	testBloomFilter(t, f)
+	assert.NoError(t, f.Add(c.Ctx))
+
+	// shared by other instances
+	f2 := NewRedisBloomFilter(c.RedisClient, "bloom:test", 1000, 0.01)
+	ok, err := f2.Exists(c.Ctx, "1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// redis error
+	c.Close()
+	_, err = f2.Exists(c.Ctx, "1")
+	assert.Error(t, err)
+}
@@ -23,6 +23,8 @@ var (
 	ErrPlaceholder = errors.New("cache: placeholder")
 	// ErrSetMemoryWithNotFound .
 	ErrSetMemoryWithNotFound = errors.New("cache: set memory cache err for not found")
+	// ErrNewObjectNil MultiGet is not supported when newObject is nil, use Typed instead
+	ErrNewObjectNil = errors.New("cache: newObject is nil")
 )
 
 // Cache driver interface
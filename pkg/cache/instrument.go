@@ -0,0 +1,318 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	metricsNamespace = "cache"
+
+	cacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "hits_total",
+			Help:      "Total number of cache hits.",
+		}, []string{"prefix"},
+	)
+
+	cacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "misses_total",
+			Help:      "Total number of cache misses.",
+		}, []string{"prefix"},
+	)
+
+	cachePlaceholderHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "placeholder_hits_total",
+			Help:      "Total number of cache hits on the not found placeholder.",
+		}, []string{"prefix"},
+	)
+
+	cacheErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "errors_total",
+			Help:      "Total number of cache operation errors.",
+		}, []string{"prefix", "operation"},
+	)
+
+	cacheDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "operation_duration_seconds",
+			Help:      "Cache operation latencies in seconds.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"prefix", "operation"},
+	)
+
+	metricsOnce sync.Once
+
+	tracerName = "github.com/hankyu66/sponge/pkg/cache"
+)
+
+// registers the prometheus metrics to the default registerer
+func registerMetrics() {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(cacheHits, cacheMisses, cachePlaceholderHits, cacheErrors, cacheDuration)
+	})
+}
+
+// InstrumentOption set the instrumented cache options.
+type InstrumentOption func(*instrumentOptions)
+
+type instrumentOptions struct {
+	enableMetrics bool
+	enableTrace   bool
+}
+
+func (o *instrumentOptions) apply(opts ...InstrumentOption) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// WithEnableMetrics export prometheus metrics
+func WithEnableMetrics() InstrumentOption {
+	return func(o *instrumentOptions) {
+		o.enableMetrics = true
+	}
+}
+
+// WithEnableTrace create child spans for cache operations
+func WithEnableTrace() InstrumentOption {
+	return func(o *instrumentOptions) {
+		o.enableTrace = true
+	}
+}
+
+// instrumentedCache a decorator of Cache that records metrics and spans
+type instrumentedCache struct {
+	cache         Cache
+	enableMetrics bool
+	enableTrace   bool
+	tracer        trace.Tracer
+}
+
+// NewInstrumentedCache wrap a cache with prometheus metrics and opentelemetry spans,
+// metrics are labelled by the key prefix, which is the part of the key before the first colon.
+// If neither metrics nor trace is enabled, the cache is returned unchanged.
+func NewInstrumentedCache(c Cache, opts ...InstrumentOption) Cache {
+	o := &instrumentOptions{}
+	o.apply(opts...)
+	if !o.enableMetrics && !o.enableTrace {
+		return c
+	}
+
+	if o.enableMetrics {
+		registerMetrics()
+	}
+
+	return &instrumentedCache{
+		cache:         c,
+		enableMetrics: o.enableMetrics,
+		enableTrace:   o.enableTrace,
+		tracer:        otel.Tracer(tracerName),
+	}
+}
+
+// record the latency, error and span of an operation, the returned function must be called when the operation is finished
+func (c *instrumentedCache) start(ctx context.Context, operation string, keys ...string) (context.Context, func(err error)) {
+	prefix := getKeyPrefix(keys...)
+	begin := time.Now()
+
+	var span trace.Span
+	if c.enableTrace {
+		ctx, span = c.tracer.Start(ctx, "cache."+operation, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("cache.prefix", prefix),
+				attribute.Int("cache.keys", len(keys)),
+			),
+		)
+		if len(keys) == 1 {
+			span.SetAttributes(attribute.String("cache.key", keys[0]))
+		}
+	}
+
+	return ctx, func(err error) {
+		isErr := err != nil && !errors.Is(err, CacheNotFound) && !errors.Is(err, ErrPlaceholder)
+		if c.enableMetrics {
+			cacheDuration.WithLabelValues(prefix, operation).Observe(time.Since(begin).Seconds())
+			if isErr {
+				cacheErrors.WithLabelValues(prefix, operation).Inc()
+			}
+		}
+		if span != nil {
+			if isErr {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
+
+func (c *instrumentedCache) recordHits(prefix string, hits int, misses int, placeholders int) {
+	if !c.enableMetrics {
+		return
+	}
+	if hits > 0 {
+		cacheHits.WithLabelValues(prefix).Add(float64(hits))
+	}
+	if misses > 0 {
+		cacheMisses.WithLabelValues(prefix).Add(float64(misses))
+	}
+	if placeholders > 0 {
+		cachePlaceholderHits.WithLabelValues(prefix).Add(float64(placeholders))
+	}
+}
+
+// Set data
+func (c *instrumentedCache) Set(ctx context.Context, key string, val interface{}, expiration time.Duration) error {
+	ctx, end := c.start(ctx, "Set", key)
+	err := c.cache.Set(ctx, key, val, expiration)
+	end(err)
+	return err
+}
+
+// Get data
+func (c *instrumentedCache) Get(ctx context.Context, key string, val interface{}) error {
+	ctx, end := c.start(ctx, "Get", key)
+	err := c.cache.Get(ctx, key, val)
+	end(err)
+
+	prefix := getKeyPrefix(key)
+	switch {
+	case err == nil:
+		c.recordHits(prefix, 1, 0, 0)
+	case errors.Is(err, ErrPlaceholder):
+		c.recordHits(prefix, 0, 0, 1)
+	case errors.Is(err, CacheNotFound):
+		c.recordHits(prefix, 0, 1, 0)
+	}
+	return err
+}
+
+// MultiSet multiple set data
+func (c *instrumentedCache) MultiSet(ctx context.Context, valMap map[string]interface{}, expiration time.Duration) error {
+	keys := make([]string, 0, len(valMap))
+	for key := range valMap {
+		keys = append(keys, key)
+	}
+	ctx, end := c.start(ctx, "MultiSet", keys...)
+	err := c.cache.MultiSet(ctx, valMap, expiration)
+	end(err)
+	return err
+}
+
+// MultiGet multiple get data
+func (c *instrumentedCache) MultiGet(ctx context.Context, keys []string, valueMap interface{}) error {
+	ctx, end := c.start(ctx, "MultiGet", keys...)
+	before := mapLen(valueMap)
+	err := c.cache.MultiGet(ctx, keys, valueMap)
+	end(err)
+
+	if err == nil {
+		hits := mapLen(valueMap) - before
+		c.recordHits(getKeyPrefix(keys...), hits, len(keys)-hits, 0)
+	}
+	return err
+}
+
+// multiGetIter keep the reflection-free path of Typed
+func (c *instrumentedCache) multiGetIter(ctx context.Context, keys []string, fn func(key string, decode func(val interface{}) error)) error {
+	ctx, end := c.start(ctx, "MultiGet", keys...)
+
+	var err error
+	hits, placeholders := 0, 0
+	if iter, ok := c.cache.(multiGetIterator); ok {
+		err = iter.multiGetIter(ctx, keys, func(key string, decode func(val interface{}) error) {
+			fn(key, func(val interface{}) error {
+				e := decode(val)
+				if errors.Is(e, ErrPlaceholder) {
+					placeholders++
+				} else {
+					hits++
+				}
+				return e
+			})
+		})
+	} else {
+		// fall back to getting one by one
+		for _, key := range keys {
+			fn(key, func(val interface{}) error {
+				e := c.cache.Get(ctx, key, val)
+				if e == nil {
+					hits++
+				} else if errors.Is(e, ErrPlaceholder) {
+					placeholders++
+				}
+				return e
+			})
+		}
+	}
+	end(err)
+
+	if err == nil {
+		c.recordHits(getKeyPrefix(keys...), hits, len(keys)-hits-placeholders, placeholders)
+	}
+	return err
+}
+
+// Del delete data
+func (c *instrumentedCache) Del(ctx context.Context, keys ...string) error {
+	ctx, end := c.start(ctx, "Del", keys...)
+	err := c.cache.Del(ctx, keys...)
+	end(err)
+	return err
+}
+
+// SetCacheWithNotFound set not found placeholder
+func (c *instrumentedCache) SetCacheWithNotFound(ctx context.Context, key string) error {
+	ctx, end := c.start(ctx, "SetCacheWithNotFound", key)
+	err := c.cache.SetCacheWithNotFound(ctx, key)
+	end(err)
+	return err
+}
+
+// Close close the wrapped cache if it implements io.Closer, e.g. the multi level cache
+func (c *instrumentedCache) Close() error {
+	if closer, ok := c.cache.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// the part of the first key before the first colon, e.g. the prefix of "userExample:1" is "userExample",
+// keys without colon are labelled as empty prefix to avoid high cardinality
+func getKeyPrefix(keys ...string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	if i := strings.Index(keys[0], ":"); i > 0 {
+		return keys[0][:i]
+	}
+	return ""
+}
+
+func mapLen(m interface{}) int {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return 0
+	}
+	return v.Len()
+}
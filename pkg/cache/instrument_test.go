@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/gotest"
+	"github.com/hankyu66/sponge/pkg/utils"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type instrumentUser struct {
+	ID   uint64
+	Name string
+}
+
+func TestNewInstrumentedCache(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+	rc := NewRedisCache(c.RedisClient, "", encoding.JSONEncoding{}, func() interface{} {
+		return &instrumentUser{}
+	})
+
+	// neither metrics nor trace is enabled
+	iCache := NewInstrumentedCache(rc)
+	assert.Equal(t, rc, iCache)
+
+	iCache = NewInstrumentedCache(rc, WithEnableMetrics(), WithEnableTrace())
+	key := "instrumentUser:1"
+	err := iCache.Set(c.Ctx, key, &instrumentUser{ID: 1, Name: "foo"}, time.Minute)
+	assert.NoError(t, err)
+
+	val := &instrumentUser{}
+	err = iCache.Get(c.Ctx, key, val)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", val.Name)
+	err = iCache.Get(c.Ctx, "instrumentUser:2", val)
+	assert.ErrorIs(t, err, CacheNotFound)
+	err = iCache.SetCacheWithNotFound(c.Ctx, "instrumentUser:3")
+	assert.NoError(t, err)
+	err = iCache.Get(c.Ctx, "instrumentUser:3", val)
+	assert.ErrorIs(t, err, ErrPlaceholder)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheHits.WithLabelValues("instrumentUser")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheMisses.WithLabelValues("instrumentUser")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(cachePlaceholderHits.WithLabelValues("instrumentUser")))
+
+	err = iCache.MultiSet(c.Ctx, map[string]interface{}{key: &instrumentUser{ID: 1}}, time.Minute)
+	assert.NoError(t, err)
+	vals := make(map[string]*instrumentUser)
+	err = iCache.MultiGet(c.Ctx, []string{key, "instrumentUser:4"}, vals)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(vals))
+	assert.Equal(t, float64(2), testutil.ToFloat64(cacheHits.WithLabelValues("instrumentUser")))
+
+	// the typed cache keeps working through the decorator
+	tc := NewTyped[uint64, *instrumentUser](iCache, func(id uint64) string {
+		return "instrumentUser:" + utils.Uint64ToStr(id)
+	})
+	itemMap, err := tc.MultiGet(c.Ctx, []uint64{1, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(itemMap))
+
+	err = iCache.Del(c.Ctx, key)
+	assert.NoError(t, err)
+
+	// error
+	err = iCache.Set(c.Ctx, "", nil, time.Minute)
+	assert.Error(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheErrors.WithLabelValues("", "Set")))
+}
+
+func Test_getKeyPrefix(t *testing.T) {
+	assert.Equal(t, "", getKeyPrefix())
+	assert.Equal(t, "", getKeyPrefix("foo"))
+	assert.Equal(t, "foo", getKeyPrefix("foo:1", "bar:2"))
+}
@@ -0,0 +1,402 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// TypedCache the cache used by Loader, Typed and the generated caches implement it
+type TypedCache[K comparable, V any] interface {
+	Get(ctx context.Context, k K) (V, error)
+	Set(ctx context.Context, k K, val V, expiration time.Duration) error
+	MultiGetWithPlaceholder(ctx context.Context, ks []K) (map[K]V, []K, error)
+	SetCacheWithNotFound(ctx context.Context, k K) error
+}
+
+// LoadFunc load a record from the data source, such as database
+type LoadFunc[K comparable, V any] func(ctx context.Context, k K) (V, error)
+
+// BatchLoadFunc load multiple records from the data source, keys that do not exist are not included in the returned map
+type BatchLoadFunc[K comparable, V any] func(ctx context.Context, ks []K) (map[K]V, error)
+
+// ScanFunc scan the keys of all records in the data source in batches, and call add for each batch
+type ScanFunc[K comparable] func(ctx context.Context, add func(ks ...K) error) error
+
+// LoaderOption set the loader options.
+type LoaderOption func(*loaderOptions)
+
+type loaderOptions struct {
+	expiration     time.Duration
+	softExpiration time.Duration
+	jitter         time.Duration
+	refreshTimeout time.Duration
+	notFoundErr    error
+	filter         BloomFilter
+}
+
+func (o *loaderOptions) apply(opts ...LoaderOption) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// default settings
+func defaultLoaderOptions() *loaderOptions {
+	return &loaderOptions{
+		expiration:     DefaultExpireTime, // cache expiration time
+		softExpiration: 0,                 // if greater than 0, stale values are served while refreshing in the background
+		jitter:         0,                 // if greater than 0, a random duration in [0, jitter) is added to expiration
+		refreshTimeout: 10 * time.Second,  // timeout of refreshing in the background
+		notFoundErr:    ErrPlaceholder,    // error returned when the record does not exist
+	}
+}
+
+// WithLoaderExpiration set cache expiration time
+func WithLoaderExpiration(d time.Duration) LoaderOption {
+	return func(o *loaderOptions) {
+		o.expiration = d
+	}
+}
+
+// WithLoaderSoftExpiration set soft expiration time, it should be less than expiration,
+// after the soft expiration the cached value is still returned and refreshed in the background
+func WithLoaderSoftExpiration(d time.Duration) LoaderOption {
+	return func(o *loaderOptions) {
+		o.softExpiration = d
+	}
+}
+
+// WithLoaderJitter set the maximum random duration added to expiration, prevent many keys from expiring at the same time
+func WithLoaderJitter(d time.Duration) LoaderOption {
+	return func(o *loaderOptions) {
+		o.jitter = d
+	}
+}
+
+// WithLoaderRefreshTimeout set timeout of refreshing in the background
+func WithLoaderRefreshTimeout(d time.Duration) LoaderOption {
+	return func(o *loaderOptions) {
+		if d > 0 {
+			o.refreshTimeout = d
+		}
+	}
+}
+
+// WithLoaderNotFoundErr set the error that indicates the record does not exist, such as gorm.ErrRecordNotFound,
+// when the load function returns this error, a not found placeholder is set to cache,
+// and the error is returned when the placeholder is hit.
+func WithLoaderNotFoundErr(err error) LoaderOption {
+	return func(o *loaderOptions) {
+		if err != nil {
+			o.notFoundErr = err
+		}
+	}
+}
+
+// WithLoaderBloomFilter set a bloom filter of the keys that exist in the data source,
+// after the filter is filled by FillFilter, keys that definitely do not exist are rejected
+// before accessing the cache and the data source, instead of setting not found placeholders.
+func WithLoaderBloomFilter(f BloomFilter) LoaderOption {
+	return func(o *loaderOptions) {
+		o.filter = f
+	}
+}
+
+// Loader cache-aside loader, get from cache first, if missed, load from data source and set cache,
+// concurrent loads of the same key are merged by singleflight.
+// Note: the soft expiration is tracked by each instance, the deadline of a key is removed when the cached value
+// is missed (deleted or expired), and the deadlines of the keys that are not accessed after the cache expiration are swept.
+type Loader[K comparable, V any] struct {
+	cache TypedCache[K, V]
+	sfg   *singleflight.Group
+	opts  *loaderOptions
+
+	softDeadlines sync.Map     // key: K, value: softDeadline
+	nextSweep     atomic.Int64 // unix nano of the next sweep of the expired deadlines
+	filterReady   atomic.Bool
+}
+
+// the soft deadline of a cached value, expireAt is the deadline of the cached value
+type softDeadline struct {
+	refreshAt time.Time
+	expireAt  time.Time
+}
+
+// NewLoader create a cache-aside loader
+func NewLoader[K comparable, V any](c TypedCache[K, V], opts ...LoaderOption) *Loader[K, V] {
+	o := defaultLoaderOptions()
+	o.apply(opts...)
+
+	return &Loader[K, V]{
+		cache: c,
+		sfg:   new(singleflight.Group),
+		opts:  o,
+	}
+}
+
+// Load get a record, if the record does not exist, the not found error is returned
+func (l *Loader[K, V]) Load(ctx context.Context, k K, fn LoadFunc[K, V]) (V, error) {
+	var zero V
+
+	if !l.mayExist(ctx, k) {
+		return zero, l.opts.notFoundErr
+	}
+
+	val, err := l.cache.Get(ctx, k)
+	if err == nil {
+		if l.isSoftExpired(k) {
+			l.refresh(k, fn)
+		}
+		return val, nil
+	}
+
+	if errors.Is(err, ErrPlaceholder) {
+		return zero, l.opts.notFoundErr
+	}
+	// fail fast, if cache error return, don't request to data source
+	if !errors.Is(err, CacheNotFound) {
+		return zero, err
+	}
+	l.softDeadlines.Delete(k)
+
+	// for the same key, prevent high concurrent simultaneous access to data source
+	v, err, _ := l.sfg.Do(sfgKey(k), func() (interface{}, error) {
+		return l.load(ctx, k, fn)
+	})
+	if err != nil {
+		return zero, err
+	}
+	val, _ = v.(V)
+	return val, nil
+}
+
+// LoadMany get multiple records, keys that do not exist are not included in the returned map
+func (l *Loader[K, V]) LoadMany(ctx context.Context, ks []K, fn BatchLoadFunc[K, V]) (map[K]V, error) {
+	if l.isFilterReady() {
+		existKeys := make([]K, 0, len(ks))
+		for _, k := range ks {
+			if l.mayExist(ctx, k) {
+				existKeys = append(existKeys, k)
+			}
+		}
+		ks = existKeys
+		if len(ks) == 0 {
+			return map[K]V{}, nil
+		}
+	}
+
+	itemMap, placeholderKeys, err := l.cache.MultiGetWithPlaceholder(ctx, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	// skip the keys of placeholders, i.e. the records do not exist in data source
+	isPlaceholder := make(map[K]struct{}, len(placeholderKeys))
+	for _, k := range placeholderKeys {
+		isPlaceholder[k] = struct{}{}
+	}
+
+	var missedKeys []K
+	var refreshKeys []K
+	for _, k := range ks {
+		if _, ok := itemMap[k]; ok {
+			if l.isSoftExpired(k) {
+				refreshKeys = append(refreshKeys, k)
+			}
+			continue
+		}
+		if _, ok := isPlaceholder[k]; ok {
+			continue
+		}
+		l.softDeadlines.Delete(k)
+		missedKeys = append(missedKeys, k)
+	}
+
+	if len(refreshKeys) > 0 {
+		l.refreshMany(refreshKeys, fn)
+	}
+
+	if len(missedKeys) > 0 {
+		missedMap, err := l.loadMany(ctx, missedKeys, fn)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range missedMap {
+			itemMap[k] = v
+		}
+	}
+
+	return itemMap, nil
+}
+
+// FillFilter fill the bloom filter with all keys in the data source, the filter takes effect after it is filled successfully,
+// it is usually called in the background when the service starts.
+func (l *Loader[K, V]) FillFilter(ctx context.Context, fn ScanFunc[K]) error {
+	if l.opts.filter == nil {
+		return nil
+	}
+	err := fn(ctx, func(ks ...K) error {
+		return l.AddToFilter(ctx, ks...)
+	})
+	if err != nil {
+		return err
+	}
+	l.filterReady.Store(true)
+	return nil
+}
+
+// AddToFilter add the keys of new records to the bloom filter, it should be called after the records are created
+func (l *Loader[K, V]) AddToFilter(ctx context.Context, ks ...K) error {
+	if l.opts.filter == nil || len(ks) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(ks))
+	for _, k := range ks {
+		keys = append(keys, sfgKey(k))
+	}
+	return l.opts.filter.Add(ctx, keys...)
+}
+
+func (l *Loader[K, V]) isFilterReady() bool {
+	return l.opts.filter != nil && l.filterReady.Load()
+}
+
+// if the filter is not ready or fails, the key is treated as existing
+func (l *Loader[K, V]) mayExist(ctx context.Context, k K) bool {
+	if !l.isFilterReady() {
+		return true
+	}
+	ok, err := l.opts.filter.Exists(ctx, sfgKey(k))
+	if err != nil {
+		return true
+	}
+	return ok
+}
+
+func (l *Loader[K, V]) load(ctx context.Context, k K, fn LoadFunc[K, V]) (V, error) {
+	var zero V
+	val, err := fn(ctx, k)
+	if err != nil {
+		// if data is empty, set not found cache to prevent cache penetration
+		if errors.Is(err, l.opts.notFoundErr) {
+			err = l.cache.SetCacheWithNotFound(ctx, k)
+			if err != nil {
+				return zero, err
+			}
+			return zero, l.opts.notFoundErr
+		}
+		return zero, err
+	}
+
+	expiration := l.expiration()
+	err = l.cache.Set(ctx, k, val, expiration)
+	if err != nil {
+		return zero, fmt.Errorf("cache.Set error: %v, key=%v", err, k)
+	}
+	l.setSoftDeadline(k, expiration)
+
+	return val, nil
+}
+
+func (l *Loader[K, V]) loadMany(ctx context.Context, ks []K, fn BatchLoadFunc[K, V]) (map[K]V, error) {
+	itemMap, err := fn(ctx, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range ks {
+		val, ok := itemMap[k]
+		if !ok {
+			_ = l.cache.SetCacheWithNotFound(ctx, k)
+			continue
+		}
+		expiration := l.expiration()
+		err = l.cache.Set(ctx, k, val, expiration)
+		if err != nil {
+			return nil, fmt.Errorf("cache.Set error: %v, key=%v", err, k)
+		}
+		l.setSoftDeadline(k, expiration)
+	}
+
+	return itemMap, nil
+}
+
+// refresh the value in the background, the caller gets the stale value
+func (l *Loader[K, V]) refresh(k K, fn LoadFunc[K, V]) {
+	l.setSoftDeadline(k, l.opts.expiration) // prevent repeated refreshing
+	l.sfg.DoChan(sfgKey(k), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), l.opts.refreshTimeout)
+		defer cancel()
+		return l.load(ctx, k, fn)
+	})
+}
+
+func (l *Loader[K, V]) refreshMany(ks []K, fn BatchLoadFunc[K, V]) {
+	for _, k := range ks {
+		l.setSoftDeadline(k, l.opts.expiration)
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), l.opts.refreshTimeout)
+		defer cancel()
+		_, _ = l.loadMany(ctx, ks, fn)
+	}()
+}
+
+// expiration with random jitter
+func (l *Loader[K, V]) expiration() time.Duration {
+	if l.opts.jitter <= 0 {
+		return l.opts.expiration
+	}
+	return l.opts.expiration + time.Duration(rand.Int63n(int64(l.opts.jitter))) //nolint
+}
+
+func (l *Loader[K, V]) setSoftDeadline(k K, expiration time.Duration) {
+	if l.opts.softExpiration <= 0 {
+		return
+	}
+	now := time.Now()
+	l.softDeadlines.Store(k, softDeadline{
+		refreshAt: now.Add(l.opts.softExpiration),
+		expireAt:  now.Add(expiration),
+	})
+	l.sweep(now)
+}
+
+func (l *Loader[K, V]) isSoftExpired(k K) bool {
+	if l.opts.softExpiration <= 0 {
+		return false
+	}
+	v, ok := l.softDeadlines.Load(k)
+	if !ok {
+		// the value was set by another instance, start timing from now
+		l.setSoftDeadline(k, l.opts.expiration)
+		return false
+	}
+	return time.Now().After(v.(softDeadline).refreshAt)
+}
+
+// sweep remove the deadlines of the expired cached values at most once per cache expiration,
+// so that the deadlines of the keys that are never accessed again don't accumulate.
+func (l *Loader[K, V]) sweep(now time.Time) {
+	next := l.nextSweep.Load()
+	if now.UnixNano() < next || !l.nextSweep.CompareAndSwap(next, now.Add(l.opts.expiration).UnixNano()) {
+		return
+	}
+	l.softDeadlines.Range(func(k, v interface{}) bool {
+		if now.After(v.(softDeadline).expireAt) {
+			l.softDeadlines.Delete(k)
+		}
+		return true
+	})
+}
+
+func sfgKey(k interface{}) string {
+	return fmt.Sprintf("%v", k)
+}
@@ -0,0 +1,301 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/gotest"
+	"github.com/hankyu66/sponge/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type loaderUser struct {
+	ID   uint64
+	Name string
+}
+
+var errLoaderNotFound = errors.New("record not found")
+
+func newLoaderCache(c *gotest.Cache) *Typed[uint64, *loaderUser] {
+	return NewTyped[uint64, *loaderUser](NewRedisCache(c.RedisClient, "", encoding.JSONEncoding{}, nil), func(id uint64) string {
+		return "loaderUser:" + utils.Uint64ToStr(id)
+	})
+}
+
+func TestLoader_Load(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+
+	var count int32
+	fn := func(ctx context.Context, id uint64) (*loaderUser, error) {
+		atomic.AddInt32(&count, 1)
+		if id > 10 {
+			return nil, errLoaderNotFound
+		}
+		return &loaderUser{ID: id, Name: "foo"}, nil
+	}
+
+	loader := NewLoader[uint64, *loaderUser](newLoaderCache(c),
+		WithLoaderExpiration(time.Minute),
+		WithLoaderJitter(time.Second),
+		WithLoaderNotFoundErr(errLoaderNotFound),
+	)
+
+	val, err := loader.Load(c.Ctx, 1, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", val.Name)
+	val, err = loader.Load(c.Ctx, 1, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", val.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	// not found, the second time hits the placeholder
+	_, err = loader.Load(c.Ctx, 11, fn)
+	assert.ErrorIs(t, err, errLoaderNotFound)
+	_, err = loader.Load(c.Ctx, 11, fn)
+	assert.ErrorIs(t, err, errLoaderNotFound)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+
+	// load error
+	_, err = loader.Load(c.Ctx, 12, func(ctx context.Context, id uint64) (*loaderUser, error) {
+		return nil, errors.New("db error")
+	})
+	assert.Error(t, err)
+}
+
+func TestLoader_SoftExpiration(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+
+	var count int32
+	fn := func(ctx context.Context, id uint64) (*loaderUser, error) {
+		n := atomic.AddInt32(&count, 1)
+		return &loaderUser{ID: id, Name: utils.IntToStr(int(n))}, nil
+	}
+
+	loader := NewLoader[uint64, *loaderUser](newLoaderCache(c),
+		WithLoaderExpiration(time.Minute),
+		WithLoaderSoftExpiration(time.Millisecond*50),
+		WithLoaderRefreshTimeout(time.Second),
+	)
+
+	val, err := loader.Load(c.Ctx, 1, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val.Name)
+
+	// stale value is returned, and refreshed in the background
+	time.Sleep(time.Millisecond * 100)
+	val, err = loader.Load(c.Ctx, 1, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val.Name)
+
+	time.Sleep(time.Millisecond * 100)
+	val, err = loader.Load(c.Ctx, 1, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", val.Name)
+}
+
+func TestLoader_LoadMany(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+
+	var count int32
+	fn := func(ctx context.Context, ids []uint64) (map[uint64]*loaderUser, error) {
+		atomic.AddInt32(&count, 1)
+		itemMap := make(map[uint64]*loaderUser)
+		for _, id := range ids {
+			if id <= 10 {
+				itemMap[id] = &loaderUser{ID: id, Name: "foo"}
+			}
+		}
+		return itemMap, nil
+	}
+
+	loader := NewLoader[uint64, *loaderUser](newLoaderCache(c), WithLoaderSoftExpiration(time.Millisecond*50))
+
+	itemMap, err := loader.LoadMany(c.Ctx, []uint64{1, 2, 11}, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(itemMap))
+
+	// all hit or placeholder, not loaded from data source
+	itemMap, err = loader.LoadMany(c.Ctx, []uint64{1, 2, 11}, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(itemMap))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	// refresh in the background
+	time.Sleep(time.Millisecond * 100)
+	_, err = loader.LoadMany(c.Ctx, []uint64{1, 2}, fn)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+
+	// load error
+	_, err = loader.LoadMany(c.Ctx, []uint64{3}, func(ctx context.Context, ids []uint64) (map[uint64]*loaderUser, error) {
+		return nil, errors.New("db error")
+	})
+	assert.Error(t, err)
+}
+
+// getCountingCache count the calls of Get
+type getCountingCache struct {
+	*Typed[uint64, *loaderUser]
+	gets int32
+}
+
+func (c *getCountingCache) Get(ctx context.Context, id uint64) (*loaderUser, error) {
+	atomic.AddInt32(&c.gets, 1)
+	return c.Typed.Get(ctx, id)
+}
+
+func TestLoader_LoadManyPlaceholder(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+
+	var loadedIDs []uint64
+	fn := func(ctx context.Context, ids []uint64) (map[uint64]*loaderUser, error) {
+		loadedIDs = append(loadedIDs, ids...)
+		return map[uint64]*loaderUser{1: {ID: 1, Name: "foo"}}, nil
+	}
+
+	tc := &getCountingCache{Typed: newLoaderCache(c)}
+	loader := NewLoader[uint64, *loaderUser](tc)
+
+	itemMap, err := loader.LoadMany(c.Ctx, []uint64{1, 11, 12}, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(itemMap))
+
+	// the placeholders are detected from the result of MultiGetWithPlaceholder, not by getting one by one
+	itemMap, err = loader.LoadMany(c.Ctx, []uint64{1, 11, 12, 13}, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(itemMap))
+	assert.Equal(t, []uint64{1, 11, 12, 13}, loadedIDs)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&tc.gets))
+}
+
+func TestLoader_softDeadlines(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+
+	fn := func(ctx context.Context, id uint64) (*loaderUser, error) {
+		return &loaderUser{ID: id, Name: "foo"}, nil
+	}
+	deadlines := func(l *Loader[uint64, *loaderUser]) int {
+		n := 0
+		l.softDeadlines.Range(func(k, v interface{}) bool {
+			n++
+			return true
+		})
+		return n
+	}
+
+	tc := newLoaderCache(c)
+	loader := NewLoader[uint64, *loaderUser](tc,
+		WithLoaderExpiration(time.Millisecond*100),
+		WithLoaderSoftExpiration(time.Millisecond*50),
+	)
+
+	for id := uint64(1); id <= 3; id++ {
+		_, err := loader.Load(c.Ctx, id, fn)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, deadlines(loader))
+
+	// the deadline is removed when the cached value is deleted
+	err := tc.Del(c.Ctx, 1)
+	assert.NoError(t, err)
+	_, err = loader.Load(c.Ctx, 1, func(ctx context.Context, id uint64) (*loaderUser, error) {
+		return nil, ErrPlaceholder
+	})
+	assert.ErrorIs(t, err, ErrPlaceholder)
+	assert.Equal(t, 2, deadlines(loader))
+
+	// the deadlines of the expired cached values are swept
+	time.Sleep(time.Millisecond * 150)
+	_, err = loader.Load(c.Ctx, 4, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deadlines(loader))
+}
+
+func TestLoader_BloomFilter(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+
+	var count int32
+	fn := func(ctx context.Context, id uint64) (*loaderUser, error) {
+		atomic.AddInt32(&count, 1)
+		if id > 10 {
+			return nil, errLoaderNotFound
+		}
+		return &loaderUser{ID: id, Name: "foo"}, nil
+	}
+	batchFn := func(ctx context.Context, ids []uint64) (map[uint64]*loaderUser, error) {
+		atomic.AddInt32(&count, 1)
+		itemMap := make(map[uint64]*loaderUser)
+		for _, id := range ids {
+			if id <= 10 {
+				itemMap[id] = &loaderUser{ID: id, Name: "foo"}
+			}
+		}
+		return itemMap, nil
+	}
+
+	tc := newLoaderCache(c)
+	loader := NewLoader[uint64, *loaderUser](tc,
+		WithLoaderNotFoundErr(errLoaderNotFound),
+		WithLoaderBloomFilter(NewMemoryBloomFilter(1000, 0.001)),
+	)
+
+	// the filter does not take effect before it is filled
+	_, err := loader.Load(c.Ctx, 20, fn)
+	assert.ErrorIs(t, err, errLoaderNotFound)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	err = loader.FillFilter(c.Ctx, func(ctx context.Context, add func(ids ...uint64) error) error {
+		for id := uint64(1); id <= 10; id++ {
+			if err := add(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// rejected by the filter, neither the cache nor the data source is accessed
+	_, err = loader.Load(c.Ctx, 21, fn)
+	assert.ErrorIs(t, err, errLoaderNotFound)
+	_, err = tc.Get(c.Ctx, 21)
+	assert.ErrorIs(t, err, CacheNotFound)
+	itemMap, err := loader.LoadMany(c.Ctx, []uint64{22, 23}, batchFn)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(itemMap))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+
+	val, err := loader.Load(c.Ctx, 1, fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", val.Name)
+	itemMap, err = loader.LoadMany(c.Ctx, []uint64{2, 3, 24}, batchFn)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(itemMap))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&count))
+
+	// new record
+	err = loader.AddToFilter(c.Ctx, 25)
+	assert.NoError(t, err)
+	_, err = loader.Load(c.Ctx, 25, fn)
+	assert.ErrorIs(t, err, errLoaderNotFound)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&count))
+
+	// fill error
+	loader = NewLoader[uint64, *loaderUser](tc, WithLoaderBloomFilter(NewMemoryBloomFilter(1000, 0.001)))
+	err = loader.FillFilter(c.Ctx, func(ctx context.Context, add func(ids ...uint64) error) error {
+		return errors.New("db error")
+	})
+	assert.Error(t, err)
+	assert.False(t, loader.isFilterReady())
+}
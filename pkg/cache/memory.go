@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/hankyu66/sponge/pkg/encoding"
@@ -18,6 +19,11 @@ type memoryCache struct {
 	encoding          encoding.Encoding
 	DefaultExpireTime time.Duration
 	newObject         func() interface{}
+
+	mu       sync.Mutex
+	tags     map[string]map[string]struct{} // tag -> keys
+	keyTags  map[keyHash]*taggedKey         // hash of cache key -> key and its tags
+	versions map[string]int64               // namespace -> version
 }
 
 // NewMemoryCache create a memory cache
@@ -29,13 +35,19 @@ func NewMemoryCache(keyPrefix string, encode encoding.Encoding, newObject func()
 		MaxCost:     1 << 30, // maximum cost of cache (1GB).
 		BufferItems: 64,      // number of keys per Get buffer.
 	}
-	store, _ := ristretto.NewCache(config)
-	return &memoryCache{
-		client:    store,
+	m := &memoryCache{
 		KeyPrefix: keyPrefix,
 		encoding:  encode,
 		newObject: newObject,
+		tags:      make(map[string]map[string]struct{}),
+		keyTags:   make(map[keyHash]*taggedKey),
+		versions:  make(map[string]int64),
 	}
+	// the evicted, expired and rejected keys are removed from their tags
+	config.OnEvict = m.untagItem
+	config.OnReject = m.untagItem
+	m.client, _ = ristretto.NewCache(config)
+	return m
 }
 
 // Set data
@@ -86,12 +98,14 @@ func (m *memoryCache) Del(_ context.Context, keys ...string) error {
 		return nil
 	}
 
-	key := keys[0]
-	cacheKey, err := BuildCacheKey(m.KeyPrefix, key)
-	if err != nil {
-		return fmt.Errorf("build cache key error, err=%v, key=%s", err, key)
+	for _, key := range keys {
+		cacheKey, err := BuildCacheKey(m.KeyPrefix, key)
+		if err != nil {
+			return fmt.Errorf("build cache key error, err=%v, key=%s", err, key)
+		}
+		m.client.Del(cacheKey)
+		m.untagKey(cacheKey)
 	}
-	m.client.Del(cacheKey)
 	return nil
 }
 
@@ -109,6 +123,10 @@ func (m *memoryCache) MultiSet(ctx context.Context, valueMap map[string]interfac
 
 // MultiGet multiple get data
 func (m *memoryCache) MultiGet(ctx context.Context, keys []string, value interface{}) error {
+	if m.newObject == nil {
+		return ErrNewObjectNil
+	}
+
 	valueMap := reflect.ValueOf(value)
 	var err error
 	for _, key := range keys {
@@ -123,6 +141,28 @@ func (m *memoryCache) MultiGet(ctx context.Context, keys []string, value interfa
 	return nil
 }
 
+// multiGetIter the values found are decoded by fn, the decode function of placeholders returns ErrPlaceholder
+func (m *memoryCache) multiGetIter(_ context.Context, keys []string, fn func(key string, decode func(val interface{}) error)) error {
+	for _, key := range keys {
+		cacheKey, err := BuildCacheKey(m.KeyPrefix, key)
+		if err != nil {
+			return fmt.Errorf("BuildCacheKey error: %v, key=%s", err, key)
+		}
+		data, ok := m.client.Get(cacheKey)
+		if !ok {
+			continue
+		}
+		buf := data.([]byte)
+		fn(key, func(val interface{}) error {
+			if string(buf) == NotFoundPlaceholder {
+				return ErrPlaceholder
+			}
+			return encoding.Unmarshal(m.encoding, buf, val)
+		})
+	}
+	return nil
+}
+
 // SetCacheWithNotFound set not found
 func (m *memoryCache) SetCacheWithNotFound(_ context.Context, key string) error {
 	cacheKey, err := BuildCacheKey(m.KeyPrefix, key)
@@ -0,0 +1,430 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/krand"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultInvalidateChannel the redis pub/sub channel used to notify other instances to delete local cache
+var DefaultInvalidateChannel = "sponge:cache:invalidate"
+
+// MultiLevelOption set the multi level cache options.
+type MultiLevelOption func(*multiLevelOptions)
+
+type multiLevelOptions struct {
+	localExpiration time.Duration
+	channel         string
+}
+
+func (o *multiLevelOptions) apply(opts ...MultiLevelOption) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// default settings
+func defaultMultiLevelOptions() *multiLevelOptions {
+	return &multiLevelOptions{
+		localExpiration: time.Minute,              // maximum lifetime of data in local cache
+		channel:         DefaultInvalidateChannel, // redis pub/sub channel
+	}
+}
+
+// WithLocalExpiration set the maximum lifetime of data in local cache,
+// the local cache expiration time is the smaller of this value and the expiration passed in Set
+func WithLocalExpiration(d time.Duration) MultiLevelOption {
+	return func(o *multiLevelOptions) {
+		if d > 0 {
+			o.localExpiration = d
+		}
+	}
+}
+
+// WithInvalidateChannel set redis pub/sub channel for cache invalidation notifications
+func WithInvalidateChannel(channel string) MultiLevelOption {
+	return func(o *multiLevelOptions) {
+		if channel != "" {
+			o.channel = channel
+		}
+	}
+}
+
+// invalidateMessage notification of deleting local cache keys
+type invalidateMessage struct {
+	InstanceID string   `json:"instanceID"`
+	Keys       []string `json:"keys"`
+}
+
+// multiLevelCache local memory cache as level 1 and redis cache as level 2
+type multiLevelCache struct {
+	local  Cache
+	remote Cache
+
+	client     redis.UniversalClient
+	instanceID string
+	KeyPrefix  string
+	newObject  func() interface{}
+
+	localExpiration time.Duration
+	channel         string
+	closeOnce       sync.Once
+}
+
+// NewMultiLevelCache create a two-level cache, the memory cache is read first and then the redis cache,
+// writes go through to both levels, and changes are broadcast via redis pub/sub so that
+// other instances drop their local copies. The caches of the same redis client and channel share
+// one subscription, call Close to unsubscribe the cache that is no longer used.
+func NewMultiLevelCache(client redis.UniversalClient, keyPrefix string, encode encoding.Encoding, newObject func() interface{}, opts ...MultiLevelOption) Cache {
+	o := defaultMultiLevelOptions()
+	o.apply(opts...)
+
+	c := &multiLevelCache{
+		local:           NewMemoryCache(keyPrefix, encode, newObject),
+		remote:          NewRedisCache(client, keyPrefix, encode, newObject),
+		client:          client,
+		instanceID:      krand.String(krand.R_All, 16),
+		KeyPrefix:       keyPrefix,
+		newObject:       newObject,
+		localExpiration: o.localExpiration,
+		channel:         o.channel,
+	}
+
+	subscribe(c)
+
+	return c
+}
+
+// Close unsubscribe the invalidation notifications, the subscription is closed when all caches sharing it are closed
+func (c *multiLevelCache) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = unsubscribe(c)
+	})
+	return err
+}
+
+type subscriberKey struct {
+	client  redis.UniversalClient
+	channel string
+}
+
+// subscriber the subscription of the invalidate channel shared by the caches of a redis client
+type subscriber struct {
+	pubSub *redis.PubSub
+	caches map[string]*multiLevelCache // instance id -> cache
+}
+
+var (
+	subscribersMu sync.Mutex
+	subscribers   = make(map[subscriberKey]*subscriber)
+)
+
+func subscribe(c *multiLevelCache) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	key := subscriberKey{client: c.client, channel: c.channel}
+	s, ok := subscribers[key]
+	if !ok {
+		// subscribe must be ready before returning, otherwise notifications may be lost
+		s = &subscriber{
+			pubSub: c.client.Subscribe(context.Background(), c.channel),
+			caches: make(map[string]*multiLevelCache),
+		}
+		_, err := s.pubSub.Receive(context.Background())
+		if err != nil {
+			fmt.Printf("subscribe to channel %s error: %v\n", c.channel, err)
+		}
+		subscribers[key] = s
+		go s.listen()
+	}
+	s.caches[c.instanceID] = c
+}
+
+func unsubscribe(c *multiLevelCache) error {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+
+	key := subscriberKey{client: c.client, channel: c.channel}
+	s, ok := subscribers[key]
+	if !ok {
+		return nil
+	}
+	delete(s.caches, c.instanceID)
+	if len(s.caches) > 0 {
+		return nil
+	}
+	delete(subscribers, key)
+	return s.pubSub.Close()
+}
+
+// listen for invalidation notifications and delete the local cache of all caches except the sender
+func (s *subscriber) listen() {
+	for msg := range s.pubSub.Channel() {
+		im := &invalidateMessage{}
+		err := json.Unmarshal([]byte(msg.Payload), im)
+		if err != nil {
+			fmt.Printf("json.Unmarshal error: %v, payload=%s\n", err, msg.Payload)
+			continue
+		}
+
+		subscribersMu.Lock()
+		caches := make([]*multiLevelCache, 0, len(s.caches))
+		for instanceID, c := range s.caches {
+			if instanceID != im.InstanceID {
+				caches = append(caches, c)
+			}
+		}
+		subscribersMu.Unlock()
+
+		for _, c := range caches {
+			_ = c.delLocal(context.Background(), im.Keys...)
+		}
+	}
+}
+
+// publish notify other instances to delete local cache
+func (c *multiLevelCache) publish(ctx context.Context, keys ...string) error {
+	data, err := json.Marshal(&invalidateMessage{InstanceID: c.instanceID, Keys: keys})
+	if err != nil {
+		return err
+	}
+	err = c.client.Publish(ctx, c.channel, data).Err()
+	if err != nil {
+		return fmt.Errorf("c.client.Publish error: %v, channel=%s", err, c.channel)
+	}
+	return nil
+}
+
+func (c *multiLevelCache) delLocal(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		err := c.local.Del(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *multiLevelCache) getLocalExpiration(expiration time.Duration) time.Duration {
+	if expiration <= 0 || expiration > c.localExpiration {
+		return c.localExpiration
+	}
+	return expiration
+}
+
+// write the values got from redis back to local memory, the local copy expires no later than the redis one
+func (c *multiLevelCache) writeBack(ctx context.Context, values map[string]interface{}) {
+	if len(values) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(values))
+	cmds := make([]*redis.DurationCmd, 0, len(values))
+	pipe := c.client.Pipeline()
+	for key := range values {
+		cacheKey, err := BuildCacheKey(c.KeyPrefix, key)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+		cmds = append(cmds, pipe.PTTL(ctx, cacheKey))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return
+	}
+
+	for i, key := range keys {
+		ttl := cmds[i].Val()
+		switch {
+		case ttl == -2: // the key has been deleted in redis
+			continue
+		case ttl < 0: // the key has no expiration in redis
+			ttl = 0
+		case ttl == 0:
+			continue
+		}
+		_ = c.local.Set(ctx, key, values[key], c.getLocalExpiration(ttl))
+	}
+}
+
+// Set data to redis and local memory
+func (c *multiLevelCache) Set(ctx context.Context, key string, val interface{}, expiration time.Duration) error {
+	err := c.remote.Set(ctx, key, val, expiration)
+	if err != nil {
+		return err
+	}
+	_ = c.publish(ctx, key)
+	return c.local.Set(ctx, key, val, c.getLocalExpiration(expiration))
+}
+
+// Get data from local memory first, if not found, get from redis and write back to local memory
+func (c *multiLevelCache) Get(ctx context.Context, key string, val interface{}) error {
+	err := c.local.Get(ctx, key, val)
+	if err == nil || err == ErrPlaceholder {
+		return err
+	}
+
+	err = c.remote.Get(ctx, key, val)
+	if err != nil {
+		if err == ErrPlaceholder {
+			_ = c.local.SetCacheWithNotFound(ctx, key)
+		}
+		return err
+	}
+
+	c.writeBack(ctx, map[string]interface{}{key: val})
+	return nil
+}
+
+// MultiSet multiple set data to redis and local memory
+func (c *multiLevelCache) MultiSet(ctx context.Context, valueMap map[string]interface{}, expiration time.Duration) error {
+	if len(valueMap) == 0 {
+		return nil
+	}
+
+	err := c.remote.MultiSet(ctx, valueMap, expiration)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(valueMap))
+	for key := range valueMap {
+		keys = append(keys, key)
+	}
+	_ = c.publish(ctx, keys...)
+
+	return c.local.MultiSet(ctx, valueMap, c.getLocalExpiration(expiration))
+}
+
+// MultiGet multiple get data, keys missed in local memory are fetched from redis,
+// the key in value map is the cache key
+func (c *multiLevelCache) MultiGet(ctx context.Context, keys []string, value interface{}) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if c.newObject == nil {
+		return ErrNewObjectNil
+	}
+
+	valueMap := reflect.ValueOf(value)
+	var missedKeys []string
+	for _, key := range keys {
+		cacheKey, err := BuildCacheKey(c.KeyPrefix, key)
+		if err != nil {
+			return fmt.Errorf("BuildCacheKey error: %v, key=%s", err, key)
+		}
+		object := c.newObject()
+		err = c.local.Get(ctx, key, object)
+		if err != nil {
+			if err != ErrPlaceholder {
+				missedKeys = append(missedKeys, key)
+			}
+			continue
+		}
+		valueMap.SetMapIndex(reflect.ValueOf(cacheKey), reflect.ValueOf(object))
+	}
+
+	if len(missedKeys) == 0 {
+		return nil
+	}
+
+	err := c.remote.MultiGet(ctx, missedKeys, value)
+	if err != nil {
+		return err
+	}
+
+	// write back to local memory
+	values := make(map[string]interface{}, len(missedKeys))
+	for _, key := range missedKeys {
+		cacheKey, _ := BuildCacheKey(c.KeyPrefix, key)
+		v := valueMap.MapIndex(reflect.ValueOf(cacheKey))
+		if v.IsValid() {
+			values[key] = v.Interface()
+		}
+	}
+	c.writeBack(ctx, values)
+
+	return nil
+}
+
+// multiGetIter the values missed in local memory are fetched from redis and written back to local memory
+func (c *multiLevelCache) multiGetIter(ctx context.Context, keys []string, fn func(key string, decode func(val interface{}) error)) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	hitKeys := make(map[string]struct{}, len(keys))
+	err := c.local.(multiGetIterator).multiGetIter(ctx, keys, func(key string, decode func(val interface{}) error) {
+		hitKeys[key] = struct{}{}
+		fn(key, decode)
+	})
+	if err != nil {
+		return err
+	}
+
+	var missedKeys []string
+	for _, key := range keys {
+		if _, ok := hitKeys[key]; !ok {
+			missedKeys = append(missedKeys, key)
+		}
+	}
+	if len(missedKeys) == 0 {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(missedKeys))
+	err = c.remote.(multiGetIterator).multiGetIter(ctx, missedKeys, func(key string, decode func(val interface{}) error) {
+		fn(key, func(val interface{}) error {
+			err := decode(val)
+			if err != nil {
+				if err == ErrPlaceholder {
+					_ = c.local.SetCacheWithNotFound(ctx, key)
+				}
+				return err
+			}
+			values[key] = val
+			return nil
+		})
+	})
+	c.writeBack(ctx, values)
+	return err
+}
+
+// Del delete data from redis and local memory, and notify other instances to delete local cache
+func (c *multiLevelCache) Del(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	err := c.remote.Del(ctx, keys...)
+	if err != nil {
+		return err
+	}
+	err = c.delLocal(ctx, keys...)
+	if err != nil {
+		return err
+	}
+
+	return c.publish(ctx, keys...)
+}
+
+// SetCacheWithNotFound set not found placeholder to redis and local memory
+func (c *multiLevelCache) SetCacheWithNotFound(ctx context.Context, key string) error {
+	err := c.remote.SetCacheWithNotFound(ctx, key)
+	if err != nil {
+		return err
+	}
+	_ = c.publish(ctx, key)
+	return c.local.SetCacheWithNotFound(ctx, key)
+}
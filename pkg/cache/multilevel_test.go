@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/gotest"
+	"github.com/hankyu66/sponge/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type multiLevelUser struct {
+	ID   uint64
+	Name string
+}
+
+func newMultiLevelCache() *gotest.Cache {
+	record1 := &multiLevelUser{
+		ID:   1,
+		Name: "foo",
+	}
+	record2 := &multiLevelUser{
+		ID:   2,
+		Name: "bar",
+	}
+
+	testData := map[string]interface{}{
+		utils.Uint64ToStr(record1.ID): record1,
+		utils.Uint64ToStr(record2.ID): record2,
+	}
+
+	c := gotest.NewCache(testData)
+	cachePrefix := ""
+	c.ICache = NewMultiLevelCache(c.RedisClient, cachePrefix, encoding.JSONEncoding{}, func() interface{} {
+		return &multiLevelUser{}
+	}, WithLocalExpiration(time.Minute), WithInvalidateChannel("test:invalidate"))
+
+	return c
+}
+
+func TestMultiLevelCache(t *testing.T) {
+	c := newMultiLevelCache()
+	defer c.Close()
+	testData := c.TestDataSlice[0].(*multiLevelUser)
+	iCache := c.ICache.(Cache)
+
+	key := utils.Uint64ToStr(testData.ID)
+	err := iCache.Set(c.Ctx, key, c.TestDataMap[key], time.Minute)
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 10)
+	val := &multiLevelUser{}
+	err = iCache.Get(c.Ctx, key, val)
+	assert.NoError(t, err)
+	assert.Equal(t, testData.Name, val.Name)
+
+	err = iCache.Del(c.Ctx, key)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	err = iCache.Get(c.Ctx, key, val)
+	assert.Error(t, err)
+
+	err = iCache.MultiSet(c.Ctx, c.TestDataMap, time.Minute)
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 10)
+	var keys []string
+	for k := range c.TestDataMap {
+		keys = append(keys, k)
+	}
+	vals := make(map[string]*multiLevelUser)
+	err = iCache.MultiGet(c.Ctx, keys, vals)
+	assert.NoError(t, err)
+	assert.Equal(t, len(c.TestDataSlice), len(vals))
+
+	err = iCache.SetCacheWithNotFound(c.Ctx, "not_found")
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	err = iCache.Get(c.Ctx, "not_found", val)
+	assert.Equal(t, ErrPlaceholder, err)
+
+	_ = iCache.MultiSet(c.Ctx, nil, time.Minute)
+	_ = iCache.MultiGet(c.Ctx, nil, nil)
+	err = iCache.Del(c.Ctx)
+	assert.NoError(t, err)
+}
+
+func TestMultiLevelCache_Invalidate(t *testing.T) {
+	c := newMultiLevelCache()
+	defer c.Close()
+	testData := c.TestDataSlice[0].(*multiLevelUser)
+	key := utils.Uint64ToStr(testData.ID)
+
+	// two instances share the same redis
+	c1 := c.ICache.(Cache)
+	c2 := NewMultiLevelCache(c.RedisClient, "", encoding.JSONEncoding{}, func() interface{} {
+		return &multiLevelUser{}
+	}, WithInvalidateChannel("test:invalidate"))
+
+	err := c1.Set(c.Ctx, key, testData, time.Minute)
+	assert.NoError(t, err)
+
+	// c2 reads from redis and fills its local cache
+	val := &multiLevelUser{}
+	err = c2.Get(c.Ctx, key, val)
+	assert.NoError(t, err)
+	assert.Equal(t, testData.Name, val.Name)
+	time.Sleep(time.Millisecond * 10)
+
+	// c1 deletes the key, c2 drops its local copy after receiving the notification
+	err = c1.Del(c.Ctx, key)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 100)
+	err = c2.Get(c.Ctx, key, val)
+	assert.ErrorIs(t, err, CacheNotFound)
+}
+
+func TestMultiLevelCache_sharedSubscription(t *testing.T) {
+	c := newMultiLevelCache()
+	defer c.Close()
+	testData := c.TestDataSlice[0].(*multiLevelUser)
+	key := utils.Uint64ToStr(testData.ID)
+	newObject := func() interface{} { return &multiLevelUser{} }
+
+	c1 := c.ICache.(*multiLevelCache)
+	c2 := NewMultiLevelCache(c.RedisClient, "", encoding.JSONEncoding{}, newObject, WithInvalidateChannel("test:invalidate"))
+	c3 := NewInstrumentedCache(NewMultiLevelCache(c.RedisClient, "", encoding.JSONEncoding{}, newObject, WithInvalidateChannel("test:invalidate")))
+	sKey := subscriberKey{client: c.RedisClient, channel: "test:invalidate"}
+	subscribersMu.Lock()
+	assert.Equal(t, 3, len(subscribers[sKey].caches))
+	subscribersMu.Unlock()
+
+	// the closed cache no longer receives the notifications, the others still do
+	assert.NoError(t, c3.(io.Closer).Close())
+	assert.NoError(t, c1.Set(c.Ctx, key, testData, time.Minute))
+	val := &multiLevelUser{}
+	assert.NoError(t, c2.Get(c.Ctx, key, val))
+	time.Sleep(time.Millisecond * 10)
+	assert.NoError(t, c1.Del(c.Ctx, key))
+	time.Sleep(time.Millisecond * 100)
+	assert.ErrorIs(t, c2.(*multiLevelCache).local.Get(c.Ctx, key, val), CacheNotFound)
+
+	// the subscription is closed with the last cache
+	assert.NoError(t, c2.(io.Closer).Close())
+	assert.NoError(t, c2.(io.Closer).Close())
+	assert.NoError(t, c1.Close())
+	subscribersMu.Lock()
+	_, ok := subscribers[sKey]
+	subscribersMu.Unlock()
+	assert.False(t, ok)
+}
+
+func TestMultiLevelCache_localExpiration(t *testing.T) {
+	c := newMultiLevelCache()
+	defer c.Close()
+	mc := c.ICache.(*multiLevelCache)
+	testData := c.TestDataSlice[0].(*multiLevelUser)
+	key := utils.Uint64ToStr(testData.ID)
+	val := &multiLevelUser{}
+
+	// the local copy expires with the redis one
+	assert.NoError(t, mc.remote.Set(c.Ctx, key, testData, time.Millisecond*100))
+	assert.NoError(t, mc.Get(c.Ctx, key, val))
+	time.Sleep(time.Millisecond * 10)
+	assert.NoError(t, mc.local.Get(c.Ctx, key, val))
+	time.Sleep(time.Millisecond * 150)
+	assert.ErrorIs(t, mc.local.Get(c.Ctx, key, val), CacheNotFound)
+
+	// MultiGet
+	assert.NoError(t, mc.remote.Set(c.Ctx, key, testData, time.Millisecond*100))
+	vals := make(map[string]*multiLevelUser)
+	assert.NoError(t, mc.MultiGet(c.Ctx, []string{key}, vals))
+	assert.Equal(t, 1, len(vals))
+	time.Sleep(time.Millisecond * 10)
+	assert.NoError(t, mc.local.Get(c.Ctx, key, val))
+	time.Sleep(time.Millisecond * 150)
+	assert.ErrorIs(t, mc.local.Get(c.Ctx, key, val), CacheNotFound)
+
+	// the key without expiration in redis is kept for the local expiration
+	assert.NoError(t, mc.remote.Set(c.Ctx, key, testData, 0))
+	assert.NoError(t, mc.Get(c.Ctx, key, val))
+	time.Sleep(time.Millisecond * 150)
+	assert.NoError(t, mc.local.Get(c.Ctx, key, val))
+	assert.Equal(t, testData.Name, val.Name)
+}
@@ -18,15 +18,17 @@ var CacheNotFound = redis.Nil
 
 // redisCache redis cache object
 type redisCache struct {
-	client            *redis.Client
+	client            redis.UniversalClient
 	KeyPrefix         string
 	encoding          encoding.Encoding
 	DefaultExpireTime time.Duration
 	newObject         func() interface{}
 }
 
-// NewRedisCache new a cache, client parameter can be passed in for unit testing
-func NewRedisCache(client *redis.Client, keyPrefix string, encode encoding.Encoding, newObject func() interface{}) Cache {
+// NewRedisCache new a cache, client parameter can be passed in for unit testing,
+// client can be a single, sentinel(failover) or cluster client,
+// if it is a cluster client, multi-key operations are split by hash slot.
+func NewRedisCache(client redis.UniversalClient, keyPrefix string, encode encoding.Encoding, newObject func() interface{}) Cache {
 	return &redisCache{
 		client:    client,
 		KeyPrefix: keyPrefix,
@@ -94,8 +96,8 @@ func (c *redisCache) MultiSet(ctx context.Context, valueMap map[string]interface
 	//	expiration = DefaultExpireTime
 	//}
 
-	// the key-value is paired and has twice the capacity of a map
-	paris := make([]interface{}, 0, 2*len(valueMap))
+	bufMap := make(map[string][]byte, len(valueMap))
+	cacheKeys := make([]string, 0, len(valueMap))
 	for key, value := range valueMap {
 		buf, err := encoding.Marshal(c.encoding, value)
 		if err != nil {
@@ -107,23 +109,26 @@ func (c *redisCache) MultiSet(ctx context.Context, valueMap map[string]interface
 			fmt.Printf("BuildCacheKey error, %v, key:%v\n", err, key)
 			continue
 		}
-		paris = append(paris, []byte(cacheKey))
-		paris = append(paris, buf)
+		bufMap[cacheKey] = buf
+		cacheKeys = append(cacheKeys, cacheKey)
 	}
+	if len(cacheKeys) == 0 {
+		return nil
+	}
+
 	pipeline := c.client.Pipeline()
-	err := pipeline.MSet(ctx, paris...).Err()
-	if err != nil {
-		return fmt.Errorf("pipeline.MSet error: %v", err)
-	}
-	for i := 0; i < len(paris); i = i + 2 {
-		switch paris[i].(type) {
-		case []byte:
-			pipeline.Expire(ctx, string(paris[i].([]byte)), expiration)
-		default:
-			fmt.Printf("redis expire is unsupported key type: %+v\n", reflect.TypeOf(paris[i]))
+	for _, keys := range c.groupBySlot(cacheKeys) {
+		// the key-value is paired and has twice the capacity of keys
+		paris := make([]interface{}, 0, 2*len(keys))
+		for _, key := range keys {
+			paris = append(paris, key, bufMap[key])
+		}
+		pipeline.MSet(ctx, paris...)
+		for _, key := range keys {
+			pipeline.Expire(ctx, key, expiration)
 		}
 	}
-	_, err = pipeline.Exec(ctx)
+	_, err := pipeline.Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("pipeline.Exec error: %v", err)
 	}
@@ -135,6 +140,9 @@ func (c *redisCache) MultiGet(ctx context.Context, keys []string, value interfac
 	if len(keys) == 0 {
 		return nil
 	}
+	if c.newObject == nil {
+		return ErrNewObjectNil
+	}
 	cacheKeys := make([]string, len(keys))
 	for index, key := range keys {
 		cacheKey, err := BuildCacheKey(c.KeyPrefix, key)
@@ -143,9 +151,9 @@ func (c *redisCache) MultiGet(ctx context.Context, keys []string, value interfac
 		}
 		cacheKeys[index] = cacheKey
 	}
-	values, err := c.client.MGet(ctx, cacheKeys...).Result()
+	values, err := c.mGet(ctx, cacheKeys)
 	if err != nil {
-		return fmt.Errorf("c.client.MGet error: %v, keys=%+v", err, cacheKeys)
+		return err
 	}
 
 	// Injection into map via reflection
@@ -165,6 +173,39 @@ func (c *redisCache) MultiGet(ctx context.Context, keys []string, value interfac
 	return nil
 }
 
+// multiGetIter the values found are decoded by fn, the decode function of placeholders returns ErrPlaceholder
+func (c *redisCache) multiGetIter(ctx context.Context, keys []string, fn func(key string, decode func(val interface{}) error)) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	cacheKeys := make([]string, len(keys))
+	for index, key := range keys {
+		cacheKey, err := BuildCacheKey(c.KeyPrefix, key)
+		if err != nil {
+			return fmt.Errorf("BuildCacheKey error: %v, key=%s", err, key)
+		}
+		cacheKeys[index] = cacheKey
+	}
+	values, err := c.mGet(ctx, cacheKeys)
+	if err != nil {
+		return err
+	}
+
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok || str == "" {
+			continue
+		}
+		fn(keys[i], func(val interface{}) error {
+			if str == NotFoundPlaceholder {
+				return ErrPlaceholder
+			}
+			return encoding.Unmarshal(c.encoding, []byte(str), val)
+		})
+	}
+	return nil
+}
+
 // Del delete multiple values
 func (c *redisCache) Del(ctx context.Context, keys ...string) error {
 	if len(keys) == 0 {
@@ -179,9 +220,23 @@ func (c *redisCache) Del(ctx context.Context, keys ...string) error {
 		}
 		cacheKeys[index] = cacheKey
 	}
-	err := c.client.Del(ctx, cacheKeys...).Err()
+
+	groups := c.groupBySlot(cacheKeys)
+	if len(groups) == 1 {
+		err := c.client.Del(ctx, cacheKeys...).Err()
+		if err != nil {
+			return fmt.Errorf("c.client.Del error: %v, keys=%+v", err, cacheKeys)
+		}
+		return nil
+	}
+
+	pipeline := c.client.Pipeline()
+	for _, group := range groups {
+		pipeline.Del(ctx, group...)
+	}
+	_, err := pipeline.Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("c.client.Del error: %v, keys=%+v", err, cacheKeys)
+		return fmt.Errorf("pipeline.Exec error: %v, keys=%+v", err, cacheKeys)
 	}
 	return nil
 }
@@ -196,6 +251,49 @@ func (c *redisCache) SetCacheWithNotFound(ctx context.Context, key string) error
 	return c.client.Set(ctx, cacheKey, NotFoundPlaceholder, DefaultNotFoundExpireTime).Err()
 }
 
+// mGet get multiple values, the returned values are in the same order as the keys
+func (c *redisCache) mGet(ctx context.Context, cacheKeys []string) ([]interface{}, error) {
+	groups := c.groupBySlot(cacheKeys)
+	if len(groups) == 1 {
+		values, err := c.client.MGet(ctx, cacheKeys...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("c.client.MGet error: %v, keys=%+v", err, cacheKeys)
+		}
+		return values, nil
+	}
+
+	pipeline := c.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, 0, len(groups))
+	for _, group := range groups {
+		cmds = append(cmds, pipeline.MGet(ctx, group...))
+	}
+	_, err := pipeline.Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline.Exec error: %v, keys=%+v", err, cacheKeys)
+	}
+
+	valueMap := make(map[string]interface{}, len(cacheKeys))
+	for i, cmd := range cmds {
+		for j, v := range cmd.Val() {
+			valueMap[groups[i][j]] = v
+		}
+	}
+	values := make([]interface{}, len(cacheKeys))
+	for i, key := range cacheKeys {
+		values[i] = valueMap[key]
+	}
+	return values, nil
+}
+
+// groupBySlot split keys by hash slot if the client is a cluster client,
+// multi-key commands in redis cluster require all keys to be in the same slot.
+func (c *redisCache) groupBySlot(keys []string) [][]string {
+	if _, ok := c.client.(*redis.ClusterClient); !ok {
+		return [][]string{keys}
+	}
+	return groupKeysBySlot(keys)
+}
+
 // BuildCacheKey construct a cache key with a prefix
 func BuildCacheKey(keyPrefix string, key string) (string, error) {
 	if key == "" {
@@ -8,6 +8,7 @@ import (
 	"github.com/hankyu66/sponge/pkg/gotest"
 	"github.com/hankyu66/sponge/pkg/utils"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -127,3 +128,34 @@ func TestBuildCacheKey(t *testing.T) {
 	_, err = BuildCacheKey("foo", "bar")
 	assert.NoError(t, err)
 }
+
+func TestRedisCache_Cluster(t *testing.T) {
+	c := newRedisCache()
+	defer c.Close()
+
+	// miniredis acts as a cluster with a single node that owns all slots
+	clusterClient := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{c.RedisClient.Options().Addr}})
+	defer clusterClient.Close()
+	iCache := NewRedisCache(clusterClient, "", encoding.JSONEncoding{}, func() interface{} {
+		return &redisUser{}
+	})
+
+	err := iCache.MultiSet(c.Ctx, c.TestDataMap, time.Minute)
+	assert.NoError(t, err)
+
+	var keys []string
+	for k := range c.TestDataMap {
+		keys = append(keys, k)
+	}
+	vals := make(map[string]*redisUser)
+	err = iCache.MultiGet(c.Ctx, keys, vals)
+	assert.NoError(t, err)
+	assert.Equal(t, len(c.TestDataSlice), len(vals))
+
+	err = iCache.Del(c.Ctx, keys...)
+	assert.NoError(t, err)
+	vals = make(map[string]*redisUser)
+	err = iCache.MultiGet(c.Ctx, keys, vals)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(vals))
+}
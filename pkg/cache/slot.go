@@ -0,0 +1,51 @@
+package cache
+
+import "strings"
+
+// number of hash slots in redis cluster
+const slotNumber = 16384
+
+// hashSlot calculate the hash slot of the key, the same as redis cluster,
+// if the key contains a hash tag {...}, only the tag is hashed.
+// see: https://redis.io/docs/reference/cluster-spec/#hash-tags
+func hashSlot(key string) int {
+	if s := strings.IndexByte(key, '{'); s > -1 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			key = key[s+1 : s+e+1]
+		}
+	}
+	return int(crc16(key) % slotNumber)
+}
+
+// groupKeysBySlot group keys by hash slot, keep the order of first occurrence
+func groupKeysBySlot(keys []string) [][]string {
+	indexes := make(map[int]int)
+	var groups [][]string
+	for _, key := range keys {
+		slot := hashSlot(key)
+		index, ok := indexes[slot]
+		if !ok {
+			index = len(groups)
+			indexes[slot] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], key)
+	}
+	return groups
+}
+
+// crc16 CCITT(XMODEM) used by redis cluster
+func crc16(key string) uint16 {
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,20 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_hashSlot(t *testing.T) {
+	// values are from redis command CLUSTER KEYSLOT
+	assert.Equal(t, 12182, hashSlot("foo"))
+	assert.Equal(t, 5061, hashSlot("bar"))
+	assert.Equal(t, hashSlot("user"), hashSlot("{user}:1"))
+	assert.Equal(t, hashSlot("{}:1"), hashSlot("{}:1"))
+}
+
+func Test_groupKeysBySlot(t *testing.T) {
+	groups := groupKeysBySlot([]string{"{user}:1", "foo", "{user}:2", "bar"})
+	assert.Equal(t, [][]string{{"{user}:1", "{user}:2"}, {"foo"}, {"bar"}}, groups)
+}
@@ -0,0 +1,388 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrNotSupported the cache does not support tags and namespaces
+var ErrNotSupported = errors.New("cache: tags and namespaces are not supported")
+
+var (
+	_ TagCache = (*memoryCache)(nil)
+	_ TagCache = (*redisCache)(nil)
+	_ TagCache = (*multiLevelCache)(nil)
+	_ TagCache = (*instrumentedCache)(nil)
+)
+
+// TagCache cache that supports tag-based and namespace-based invalidation,
+// the memory, redis and multi-level caches implement it.
+//
+// Tags: SetWithTags records the key under each tag, InvalidateTags deletes all keys recorded under the tags,
+// e.g. tag "tenant:42" for all data of a tenant.
+//
+// Namespaces: NamespaceKey returns the key with the current version of the namespace,
+// BumpNamespace changes the version so that all keys under the namespace become stale at once,
+// the stale keys are no longer read and are removed when they expire, e.g. namespace "userExample:list" for all list pages.
+type TagCache interface {
+	Cache
+	SetWithTags(ctx context.Context, key string, val interface{}, expiration time.Duration, tags ...string) error
+	InvalidateTags(ctx context.Context, tags ...string) error
+	NamespaceKey(ctx context.Context, namespace string, key string) (string, error)
+	BumpNamespace(ctx context.Context, namespace string) error
+}
+
+// the key of the set of keys recorded under the tag
+func tagKey(tag string) string {
+	return "_tag:" + tag
+}
+
+// the key of the version of the namespace
+func namespaceVersionKey(namespace string) string {
+	return "_ns:" + namespace
+}
+
+func versionedKey(namespace string, version int64, key string) string {
+	return namespace + ":v" + strconv.FormatInt(version, 10) + ":" + key
+}
+
+// versions are based on the current time, so that the keys of old versions are not read again
+// even if the version is lost, e.g. the process restarts or the redis key is evicted
+func nextVersion(version int64) int64 {
+	now := time.Now().UnixNano()
+	if version < now {
+		return now
+	}
+	return version + 1
+}
+
+// ------------------------------------------------------------------------------------------
+
+// the hash of the cache key in ristretto, the evicted items are identified by it
+type keyHash struct {
+	key      uint64
+	conflict uint64
+}
+
+func hashKey(cacheKey string) keyHash {
+	key, conflict := z.KeyToHash(cacheKey)
+	return keyHash{key: key, conflict: conflict}
+}
+
+// the key and the tags it is recorded under
+type taggedKey struct {
+	key  string
+	tags map[string]struct{}
+}
+
+// SetWithTags set data and record the key under the tags, the key is removed from the tags
+// when it is deleted, evicted or expired
+func (m *memoryCache) SetWithTags(ctx context.Context, key string, val interface{}, expiration time.Duration, tags ...string) error {
+	cacheKey, err := BuildCacheKey(m.KeyPrefix, key)
+	if err != nil {
+		return fmt.Errorf("BuildCacheKey error: %v, key=%s", err, key)
+	}
+	if len(tags) > 0 {
+		// record the tags before setting, the item may be rejected or evicted as soon as it is set
+		h := hashKey(cacheKey)
+		m.mu.Lock()
+		tk, ok := m.keyTags[h]
+		if !ok {
+			tk = &taggedKey{key: key, tags: make(map[string]struct{})}
+			m.keyTags[h] = tk
+		}
+		for _, tag := range tags {
+			keys, ok := m.tags[tag]
+			if !ok {
+				keys = make(map[string]struct{})
+				m.tags[tag] = keys
+			}
+			keys[key] = struct{}{}
+			tk.tags[tag] = struct{}{}
+		}
+		m.mu.Unlock()
+	}
+
+	err = m.Set(ctx, key, val, expiration)
+	if err != nil {
+		m.untagKey(cacheKey)
+		return err
+	}
+	return nil
+}
+
+// InvalidateTags delete all keys recorded under the tags
+func (m *memoryCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	m.mu.Lock()
+	var keys []string
+	for _, tag := range tags {
+		for key := range m.tags[tag] {
+			keys = append(keys, key)
+		}
+		delete(m.tags, tag)
+	}
+	m.mu.Unlock()
+
+	if len(keys) == 0 {
+		return nil
+	}
+	// deleting the keys also removes them from their other tags
+	return m.Del(ctx, keys...)
+}
+
+func (m *memoryCache) untagKey(cacheKey string) {
+	m.mu.Lock()
+	m.untag(hashKey(cacheKey))
+	m.mu.Unlock()
+}
+
+// called by ristretto when the item is evicted, expired or rejected, must not call m.client
+func (m *memoryCache) untagItem(item *ristretto.Item) {
+	m.mu.Lock()
+	m.untag(keyHash{key: item.Key, conflict: item.Conflict})
+	m.mu.Unlock()
+}
+
+// remove the key from its tags and the empty tags, m.mu must be held
+func (m *memoryCache) untag(h keyHash) {
+	tk, ok := m.keyTags[h]
+	if !ok {
+		return
+	}
+	delete(m.keyTags, h)
+	for tag := range tk.tags {
+		keys := m.tags[tag]
+		delete(keys, tk.key)
+		if len(keys) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
+
+// NamespaceKey return the key with the current version of the namespace
+func (m *memoryCache) NamespaceKey(_ context.Context, namespace string, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	version, ok := m.versions[namespace]
+	if !ok {
+		version = nextVersion(0)
+		m.versions[namespace] = version
+	}
+	return versionedKey(namespace, version, key), nil
+}
+
+// BumpNamespace make all keys under the namespace stale
+func (m *memoryCache) BumpNamespace(_ context.Context, namespace string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.versions[namespace] = nextVersion(m.versions[namespace])
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------
+
+// add the key to the tag set, the tag set lives as long as the longest lived key in it
+var tagAddScript = redis.NewScript(`
+local exists = redis.call("EXISTS", KEYS[1])
+redis.call("SADD", KEYS[1], ARGV[2])
+local expiration = tonumber(ARGV[1])
+if expiration <= 0 then
+	redis.call("PERSIST", KEYS[1])
+	return 1
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if exists == 0 or (ttl >= 0 and ttl < expiration) then
+	redis.call("PEXPIRE", KEYS[1], expiration)
+end
+return 1
+`)
+
+// SetWithTags set data and record the key under the tags
+func (c *redisCache) SetWithTags(ctx context.Context, key string, val interface{}, expiration time.Duration, tags ...string) error {
+	err := c.Set(ctx, key, val, expiration)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		cacheKey, err := BuildCacheKey(c.KeyPrefix, tagKey(tag))
+		if err != nil {
+			return fmt.Errorf("BuildCacheKey error: %v, tag=%s", err, tag)
+		}
+		err = tagAddScript.Run(ctx, c.client, []string{cacheKey}, expiration.Milliseconds(), key).Err()
+		if err != nil {
+			return fmt.Errorf("tagAddScript.Run error: %v, cacheKey=%s", err, cacheKey)
+		}
+	}
+	return nil
+}
+
+// InvalidateTags delete all keys recorded under the tags
+func (c *redisCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	return c.invalidateTags(ctx, c.Del, tags...)
+}
+
+// get the keys recorded under the tags, delete them by del, and then delete the tag sets
+func (c *redisCache) invalidateTags(ctx context.Context, del func(ctx context.Context, keys ...string) error, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	var keys []string
+	tagKeys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		cacheKey, err := BuildCacheKey(c.KeyPrefix, tagKey(tag))
+		if err != nil {
+			return fmt.Errorf("BuildCacheKey error: %v, tag=%s", err, tag)
+		}
+		members, err := c.client.SMembers(ctx, cacheKey).Result()
+		if err != nil {
+			return fmt.Errorf("c.client.SMembers error: %v, cacheKey=%s", err, cacheKey)
+		}
+		keys = append(keys, members...)
+		tagKeys = append(tagKeys, tagKey(tag))
+	}
+
+	if len(keys) > 0 {
+		err := del(ctx, keys...)
+		if err != nil {
+			return err
+		}
+	}
+	return c.Del(ctx, tagKeys...)
+}
+
+// NamespaceKey return the key with the current version of the namespace
+func (c *redisCache) NamespaceKey(ctx context.Context, namespace string, key string) (string, error) {
+	cacheKey, err := BuildCacheKey(c.KeyPrefix, namespaceVersionKey(namespace))
+	if err != nil {
+		return "", fmt.Errorf("BuildCacheKey error: %v, namespace=%s", err, namespace)
+	}
+
+	version, err := c.client.Get(ctx, cacheKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		// initialize the version, another instance may have done it at the same time
+		_, err = c.client.SetNX(ctx, cacheKey, nextVersion(0), 0).Result()
+		if err != nil {
+			return "", fmt.Errorf("c.client.SetNX error: %v, cacheKey=%s", err, cacheKey)
+		}
+		version, err = c.client.Get(ctx, cacheKey).Int64()
+	}
+	if err != nil {
+		return "", fmt.Errorf("c.client.Get error: %v, cacheKey=%s", err, cacheKey)
+	}
+
+	return versionedKey(namespace, version, key), nil
+}
+
+// set the version to max(now, version+1) atomically like nextVersion, the versions are compared as strings of digits,
+// because the numbers of lua lose precision in nanoseconds, and INCR keeps the precision.
+var bumpNamespaceScript = redis.NewScript(`
+local version = redis.call("GET", KEYS[1])
+local now = ARGV[1]
+if not version or #version < #now or (#version == #now and version < now) then
+	redis.call("SET", KEYS[1], now)
+	return 1
+end
+redis.call("INCR", KEYS[1])
+return 1
+`)
+
+// BumpNamespace make all keys under the namespace stale, every bump gets a new version even if they are concurrent
+func (c *redisCache) BumpNamespace(ctx context.Context, namespace string) error {
+	cacheKey, err := BuildCacheKey(c.KeyPrefix, namespaceVersionKey(namespace))
+	if err != nil {
+		return fmt.Errorf("BuildCacheKey error: %v, namespace=%s", err, namespace)
+	}
+	now := strconv.FormatInt(nextVersion(0), 10)
+	err = bumpNamespaceScript.Run(ctx, c.client, []string{cacheKey}, now).Err()
+	if err != nil {
+		return fmt.Errorf("bumpNamespaceScript.Run error: %v, cacheKey=%s", err, cacheKey)
+	}
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------
+
+// SetWithTags set data to redis and local memory, the tags are recorded in redis
+func (c *multiLevelCache) SetWithTags(ctx context.Context, key string, val interface{}, expiration time.Duration, tags ...string) error {
+	err := c.remote.(TagCache).SetWithTags(ctx, key, val, expiration, tags...)
+	if err != nil {
+		return err
+	}
+	_ = c.publish(ctx, key)
+	return c.local.Set(ctx, key, val, c.getLocalExpiration(expiration))
+}
+
+// InvalidateTags delete all keys recorded under the tags from redis and local memory,
+// and notify other instances to delete local cache
+func (c *multiLevelCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	return c.remote.(*redisCache).invalidateTags(ctx, c.Del, tags...)
+}
+
+// NamespaceKey return the key with the current version of the namespace, the version is shared by all instances via redis
+func (c *multiLevelCache) NamespaceKey(ctx context.Context, namespace string, key string) (string, error) {
+	return c.remote.(TagCache).NamespaceKey(ctx, namespace, key)
+}
+
+// BumpNamespace make all keys under the namespace stale, in local memory the stale keys are removed when they expire
+func (c *multiLevelCache) BumpNamespace(ctx context.Context, namespace string) error {
+	return c.remote.(TagCache).BumpNamespace(ctx, namespace)
+}
+
+// ------------------------------------------------------------------------------------------
+
+// SetWithTags set data and record the key under the tags
+func (c *instrumentedCache) SetWithTags(ctx context.Context, key string, val interface{}, expiration time.Duration, tags ...string) error {
+	tc, ok := c.cache.(TagCache)
+	if !ok {
+		return ErrNotSupported
+	}
+	ctx, end := c.start(ctx, "SetWithTags", key)
+	err := tc.SetWithTags(ctx, key, val, expiration, tags...)
+	end(err)
+	return err
+}
+
+// InvalidateTags delete all keys recorded under the tags
+func (c *instrumentedCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	tc, ok := c.cache.(TagCache)
+	if !ok {
+		return ErrNotSupported
+	}
+	ctx, end := c.start(ctx, "InvalidateTags", tags...)
+	err := tc.InvalidateTags(ctx, tags...)
+	end(err)
+	return err
+}
+
+// NamespaceKey return the key with the current version of the namespace
+func (c *instrumentedCache) NamespaceKey(ctx context.Context, namespace string, key string) (string, error) {
+	tc, ok := c.cache.(TagCache)
+	if !ok {
+		return "", ErrNotSupported
+	}
+	ctx, end := c.start(ctx, "NamespaceKey", namespace+":")
+	nsKey, err := tc.NamespaceKey(ctx, namespace, key)
+	end(err)
+	return nsKey, err
+}
+
+// BumpNamespace make all keys under the namespace stale
+func (c *instrumentedCache) BumpNamespace(ctx context.Context, namespace string) error {
+	tc, ok := c.cache.(TagCache)
+	if !ok {
+		return ErrNotSupported
+	}
+	ctx, end := c.start(ctx, "BumpNamespace", namespace+":")
+	err := tc.BumpNamespace(ctx, namespace)
+	end(err)
+	return err
+}
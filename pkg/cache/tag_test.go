@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/gotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tagUser struct {
+	ID   uint64
+	Name string
+}
+
+func newTagUser() interface{} {
+	return &tagUser{}
+}
+
+func testTagCache(t *testing.T, c TagCache) {
+	ctx := context.Background()
+
+	err := c.SetWithTags(ctx, "tagUser:1", &tagUser{ID: 1}, time.Minute, "tenant:42", "tagUser:all")
+	assert.NoError(t, err)
+	err = c.SetWithTags(ctx, "tagUser:2", &tagUser{ID: 2}, time.Minute, "tenant:43", "tagUser:all")
+	assert.NoError(t, err)
+	err = c.SetWithTags(ctx, "tagUser:3", &tagUser{ID: 3}, 0, "tenant:42")
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10) // wait for memory cache to be written
+
+	err = c.InvalidateTags(ctx, "tenant:42")
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	val := &tagUser{}
+	assert.ErrorIs(t, c.Get(ctx, "tagUser:1", val), CacheNotFound)
+	assert.ErrorIs(t, c.Get(ctx, "tagUser:3", val), CacheNotFound)
+	assert.NoError(t, c.Get(ctx, "tagUser:2", val))
+	assert.Equal(t, uint64(2), val.ID)
+
+	err = c.InvalidateTags(ctx, "tagUser:all", "not-exist")
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	assert.ErrorIs(t, c.Get(ctx, "tagUser:2", val), CacheNotFound)
+	assert.NoError(t, c.InvalidateTags(ctx))
+
+	// namespace
+	key1, err := c.NamespaceKey(ctx, "tagUser:list", "page=1")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key1, "tagUser:list:v"))
+	assert.True(t, strings.HasSuffix(key1, ":page=1"))
+	key2, err := c.NamespaceKey(ctx, "tagUser:list", "page=1")
+	assert.NoError(t, err)
+	assert.Equal(t, key1, key2)
+
+	err = c.Set(ctx, key1, &tagUser{ID: 1}, time.Minute)
+	assert.NoError(t, err)
+	err = c.BumpNamespace(ctx, "tagUser:list")
+	assert.NoError(t, err)
+	key2, err = c.NamespaceKey(ctx, "tagUser:list", "page=1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, key1, key2)
+	assert.ErrorIs(t, c.Get(ctx, key2, val), CacheNotFound)
+
+	// a namespace that has never been read
+	assert.NoError(t, c.BumpNamespace(ctx, "tagUser:other"))
+}
+
+func TestMemoryCache_Tags(t *testing.T) {
+	c := NewMemoryCache("", encoding.JSONEncoding{}, newTagUser)
+	testTagCache(t, c.(TagCache))
+	m := c.(*memoryCache)
+	assert.Empty(t, m.tags)
+	assert.Empty(t, m.keyTags)
+
+	// the deleted keys are removed from the tags
+	ctx := context.Background()
+	assert.NoError(t, m.SetWithTags(ctx, "tagUser:1", &tagUser{ID: 1}, time.Minute, "tenant:42", "tagUser:all"))
+	assert.NoError(t, m.SetWithTags(ctx, "tagUser:2", &tagUser{ID: 2}, time.Minute, "tagUser:all"))
+	time.Sleep(time.Millisecond * 10)
+	assert.NoError(t, m.Del(ctx, "tagUser:1"))
+	assert.Equal(t, map[string]map[string]struct{}{"tagUser:all": {"tagUser:2": {}}}, m.tags)
+	assert.Equal(t, 1, len(m.keyTags))
+
+	// the evicted keys are removed from the tags
+	m.client.Clear()
+	assert.Empty(t, m.tags)
+	assert.Empty(t, m.keyTags)
+}
+
+func TestRedisCache_Tags(t *testing.T) {
+	rc := gotest.NewCache(nil)
+	defer rc.Close()
+	c := NewRedisCache(rc.RedisClient, "prefix", encoding.JSONEncoding{}, newTagUser)
+	testTagCache(t, c.(TagCache))
+
+	// the tag set expires with the longest lived key
+	err := c.(TagCache).SetWithTags(rc.Ctx, "tagUser:4", &tagUser{ID: 4}, time.Minute, "tenant:44")
+	assert.NoError(t, err)
+	err = c.(TagCache).SetWithTags(rc.Ctx, "tagUser:5", &tagUser{ID: 5}, time.Second, "tenant:44")
+	assert.NoError(t, err)
+	ttl := rc.RedisClient.TTL(rc.Ctx, "prefix:_tag:tenant:44").Val()
+	assert.Equal(t, time.Minute, ttl)
+
+	// the version is lost, the old keys are not read again
+	key1, _ := c.(TagCache).NamespaceKey(rc.Ctx, "tagUser:list", "page=1")
+	rc.RedisClient.Del(rc.Ctx, "prefix:_ns:tagUser:list")
+	key2, _ := c.(TagCache).NamespaceKey(rc.Ctx, "tagUser:list", "page=1")
+	assert.NotEqual(t, key1, key2)
+
+	// the concurrent bumps are atomic, every bump increases the version
+	future := time.Now().Add(time.Hour).UnixNano()
+	rc.RedisClient.Set(rc.Ctx, "prefix:_ns:tagUser:list", future, 0)
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.(TagCache).BumpNamespace(rc.Ctx, "tagUser:list"))
+		}()
+	}
+	wg.Wait()
+	version, err := rc.RedisClient.Get(rc.Ctx, "prefix:_ns:tagUser:list").Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, future+50, version)
+
+	// the version older than now is set to now
+	rc.RedisClient.Set(rc.Ctx, "prefix:_ns:tagUser:list", 100, 0)
+	now := time.Now().UnixNano()
+	assert.NoError(t, c.(TagCache).BumpNamespace(rc.Ctx, "tagUser:list"))
+	version, err = rc.RedisClient.Get(rc.Ctx, "prefix:_ns:tagUser:list").Int64()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, version, now)
+
+	// redis error
+	rc.Close()
+	assert.Error(t, c.(TagCache).SetWithTags(rc.Ctx, "tagUser:1", &tagUser{ID: 1}, time.Minute, "tenant:42"))
+	assert.Error(t, c.(TagCache).InvalidateTags(rc.Ctx, "tenant:42"))
+	_, err = c.(TagCache).NamespaceKey(rc.Ctx, "tagUser:list", "page=1")
+	assert.Error(t, err)
+	assert.Error(t, c.(TagCache).BumpNamespace(rc.Ctx, "tagUser:list"))
+}
+
+func TestMultiLevelCache_Tags(t *testing.T) {
+	rc := gotest.NewCache(nil)
+	defer rc.Close()
+	c1 := NewMultiLevelCache(rc.RedisClient, "", encoding.JSONEncoding{}, newTagUser)
+	c2 := NewMultiLevelCache(rc.RedisClient, "", encoding.JSONEncoding{}, newTagUser)
+	testTagCache(t, c1.(TagCache))
+
+	// the local cache of other instances is invalidated
+	err := c1.(TagCache).SetWithTags(rc.Ctx, "tagUser:1", &tagUser{ID: 1}, time.Minute, "tenant:42")
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	val := &tagUser{}
+	assert.NoError(t, c2.Get(rc.Ctx, "tagUser:1", val))
+	time.Sleep(time.Millisecond * 10)
+	err = c1.(TagCache).InvalidateTags(rc.Ctx, "tenant:42")
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 50)
+	assert.ErrorIs(t, c2.Get(rc.Ctx, "tagUser:1", val), CacheNotFound)
+
+	// the namespace version is shared
+	key1, err := c1.(TagCache).NamespaceKey(rc.Ctx, "tagUser:list", "page=1")
+	assert.NoError(t, err)
+	key2, err := c2.(TagCache).NamespaceKey(rc.Ctx, "tagUser:list", "page=1")
+	assert.NoError(t, err)
+	assert.Equal(t, key1, key2)
+}
+
+func TestInstrumentedCache_Tags(t *testing.T) {
+	c := NewInstrumentedCache(NewMemoryCache("", encoding.JSONEncoding{}, newTagUser), WithEnableTrace())
+	testTagCache(t, c.(TagCache))
+
+	// not supported
+	c = NewInstrumentedCache(&noTagCache{}, WithEnableTrace())
+	ctx := context.Background()
+	assert.ErrorIs(t, c.(TagCache).SetWithTags(ctx, "foo", "bar", time.Minute, "tag"), ErrNotSupported)
+	assert.ErrorIs(t, c.(TagCache).InvalidateTags(ctx, "tag"), ErrNotSupported)
+	_, err := c.(TagCache).NamespaceKey(ctx, "ns", "foo")
+	assert.ErrorIs(t, err, ErrNotSupported)
+	assert.ErrorIs(t, c.(TagCache).BumpNamespace(ctx, "ns"), ErrNotSupported)
+}
+
+type noTagCache struct {
+	Cache
+}
+
+func Test_nextVersion(t *testing.T) {
+	v := nextVersion(0)
+	assert.Greater(t, v, int64(0))
+	future := time.Now().Add(time.Hour).UnixNano()
+	assert.Equal(t, future+1, nextVersion(future))
+}
@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// multiGetIterator is implemented by the built-in caches, the values found are decoded
+// by the caller through the decode function, so that no reflection or factory is required,
+// fn is also called for the not found placeholders, and their decode function returns ErrPlaceholder.
+type multiGetIterator interface {
+	multiGetIter(ctx context.Context, keys []string, fn func(key string, decode func(val interface{}) error)) error
+}
+
+// Typed a type-safe cache on top of Cache, K is the type of business key, V is the type of cached value
+type Typed[K comparable, V any] struct {
+	cache Cache
+	keyFn func(K) string
+}
+
+// NewTyped create a type-safe cache, keyFn converts the business key to the cache key
+func NewTyped[K comparable, V any](c Cache, keyFn func(K) string) *Typed[K, V] {
+	if keyFn == nil {
+		keyFn = func(k K) string {
+			return fmt.Sprintf("%v", k)
+		}
+	}
+	return &Typed[K, V]{
+		cache: c,
+		keyFn: keyFn,
+	}
+}
+
+// Cache returns the underlying cache
+func (t *Typed[K, V]) Cache() Cache {
+	return t.cache
+}
+
+// Key returns the cache key of k
+func (t *Typed[K, V]) Key(k K) string {
+	return t.keyFn(k)
+}
+
+// Set data
+func (t *Typed[K, V]) Set(ctx context.Context, k K, val V, expiration time.Duration) error {
+	return t.cache.Set(ctx, t.keyFn(k), &val, expiration)
+}
+
+// Get data, if the key is a not found placeholder, ErrPlaceholder is returned
+func (t *Typed[K, V]) Get(ctx context.Context, k K) (V, error) {
+	var val V
+	err := t.cache.Get(ctx, t.keyFn(k), &val)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return val, nil
+}
+
+// MultiSet multiple set data
+func (t *Typed[K, V]) MultiSet(ctx context.Context, valMap map[K]V, expiration time.Duration) error {
+	if len(valMap) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(valMap))
+	for k, v := range valMap {
+		v := v
+		m[t.keyFn(k)] = &v
+	}
+	return t.cache.MultiSet(ctx, m, expiration)
+}
+
+// MultiGet multiple get data, keys that are not hit are not included in the returned map
+func (t *Typed[K, V]) MultiGet(ctx context.Context, ks []K) (map[K]V, error) {
+	retMap, _, err := t.MultiGetWithPlaceholder(ctx, ks)
+	return retMap, err
+}
+
+// MultiGetWithPlaceholder multiple get data, keys that are not hit are not included in the returned map,
+// and the keys of the not found placeholders are returned separately.
+func (t *Typed[K, V]) MultiGetWithPlaceholder(ctx context.Context, ks []K) (map[K]V, []K, error) {
+	retMap := make(map[K]V, len(ks))
+	var placeholderKeys []K
+	if len(ks) == 0 {
+		return retMap, placeholderKeys, nil
+	}
+
+	keyMap := make(map[string]K, len(ks))
+	keys := make([]string, 0, len(ks))
+	for _, k := range ks {
+		key := t.keyFn(k)
+		keyMap[key] = k
+		keys = append(keys, key)
+	}
+
+	iter, ok := t.cache.(multiGetIterator)
+	if !ok {
+		// fall back to getting one by one
+		for _, key := range keys {
+			var val V
+			if err := t.cache.Get(ctx, key, &val); err != nil {
+				if errors.Is(err, ErrPlaceholder) {
+					placeholderKeys = append(placeholderKeys, keyMap[key])
+				}
+				continue
+			}
+			retMap[keyMap[key]] = val
+		}
+		return retMap, placeholderKeys, nil
+	}
+
+	err := iter.multiGetIter(ctx, keys, func(key string, decode func(val interface{}) error) {
+		var val V
+		if err := decode(&val); err != nil {
+			if errors.Is(err, ErrPlaceholder) {
+				placeholderKeys = append(placeholderKeys, keyMap[key])
+			}
+			return
+		}
+		retMap[keyMap[key]] = val
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return retMap, placeholderKeys, nil
+}
+
+// Del delete data
+func (t *Typed[K, V]) Del(ctx context.Context, ks ...K) error {
+	if len(ks) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(ks))
+	for _, k := range ks {
+		keys = append(keys, t.keyFn(k))
+	}
+	return t.cache.Del(ctx, keys...)
+}
+
+// SetCacheWithNotFound set not found placeholder
+func (t *Typed[K, V]) SetCacheWithNotFound(ctx context.Context, k K) error {
+	return t.cache.SetCacheWithNotFound(ctx, t.keyFn(k))
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hankyu66/sponge/pkg/encoding"
+	"github.com/hankyu66/sponge/pkg/gotest"
+	"github.com/hankyu66/sponge/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typedUser struct {
+	ID   uint64
+	Name string
+}
+
+func testTyped(t *testing.T, tc *Typed[uint64, *typedUser], ctx *gotest.Cache) {
+	record1 := &typedUser{ID: 1, Name: "foo"}
+	record2 := &typedUser{ID: 2, Name: "bar"}
+
+	err := tc.Set(ctx.Ctx, record1.ID, record1, time.Minute)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+
+	got, err := tc.Get(ctx.Ctx, record1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, record1, got)
+
+	err = tc.MultiSet(ctx.Ctx, map[uint64]*typedUser{record1.ID: record1, record2.ID: record2}, time.Minute)
+	assert.NoError(t, err)
+	err = tc.SetCacheWithNotFound(ctx.Ctx, 3)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+
+	_, err = tc.Get(ctx.Ctx, 3)
+	assert.ErrorIs(t, err, ErrPlaceholder)
+
+	vals, err := tc.MultiGet(ctx.Ctx, []uint64{1, 2, 3, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(vals))
+	assert.Equal(t, record2, vals[2])
+	vals, placeholderIDs, err := tc.MultiGetWithPlaceholder(ctx.Ctx, []uint64{1, 2, 3, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(vals))
+	assert.Equal(t, []uint64{3}, placeholderIDs)
+
+	err = tc.Del(ctx.Ctx, 1, 2)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	vals, err = tc.MultiGet(ctx.Ctx, []uint64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(vals))
+
+	_ = tc.MultiSet(ctx.Ctx, nil, time.Minute)
+	_, _ = tc.MultiGet(ctx.Ctx, nil)
+	_ = tc.Del(ctx.Ctx)
+	assert.NotNil(t, tc.Cache())
+	assert.Equal(t, "user:1", tc.Key(1))
+}
+
+func TestTyped(t *testing.T) {
+	c := gotest.NewCache(nil)
+	defer c.Close()
+	keyFn := func(id uint64) string {
+		return "user:" + utils.Uint64ToStr(id)
+	}
+
+	caches := []Cache{
+		NewRedisCache(c.RedisClient, "", encoding.JSONEncoding{}, nil),
+		NewMemoryCache("", encoding.JSONEncoding{}, nil),
+		NewMultiLevelCache(c.RedisClient, "", encoding.JSONEncoding{}, nil),
+	}
+	for _, iCache := range caches {
+		testTyped(t, NewTyped[uint64, *typedUser](iCache, keyFn), c)
+	}
+}
+
+func TestTyped_default(t *testing.T) {
+	tc := NewTyped[string, string](NewMemoryCache("", encoding.JSONEncoding{}, nil), nil)
+	assert.Equal(t, "foo", tc.Key("foo"))
+
+	err := tc.Cache().MultiGet(context.Background(), []string{"foo"}, map[string]string{})
+	assert.ErrorIs(t, err, ErrNewObjectNil)
+}
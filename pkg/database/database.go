@@ -0,0 +1,103 @@
+// Package database is a driver-agnostic library for connecting mysql, postgres and sqlite,
+// the options are the same as pkg/mysql, including pooling, tracing, read-write separation and logging.
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hankyu66/sponge/pkg/mysql"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	// DriverMysql mysql driver
+	DriverMysql = "mysql"
+	// DriverPostgres postgres driver
+	DriverPostgres = "postgres"
+	// DriverSqlite sqlite driver, it is implemented in pure go and does not require cgo
+	DriverSqlite = "sqlite"
+)
+
+// Option set the database options, it is the same as mysql.Option
+type Option = mysql.Option
+
+// the options are the same as pkg/mysql
+var (
+	WithLogging             = mysql.WithLogging
+	WithSlowThreshold       = mysql.WithSlowThreshold
+	WithMaxIdleConns        = mysql.WithMaxIdleConns
+	WithMaxOpenConns        = mysql.WithMaxOpenConns
+	WithConnMaxLifetime     = mysql.WithConnMaxLifetime
+	WithEnableForeignKey    = mysql.WithEnableForeignKey
+	WithEnableTrace         = mysql.WithEnableTrace
+	WithEnableMetrics       = mysql.WithEnableMetrics
+	WithLogRequestIDKey     = mysql.WithLogRequestIDKey
+	WithRWSeparation        = mysql.WithRWSeparation
+	WithReplicaWeights      = mysql.WithReplicaWeights
+	WithReplicaHealthCheck  = mysql.WithReplicaHealthCheck
+	WithReplicaLagFunc      = mysql.WithReplicaLagFunc
+	WithReadYourWrites      = mysql.WithReadYourWrites
+	WithAudit               = mysql.WithAudit
+	WithAuditExcludeTables  = mysql.WithAuditExcludeTables
+	WithAuditExcludeColumns = mysql.WithAuditExcludeColumns
+	WithAuditContextKeys    = mysql.WithAuditContextKeys
+	WithGormPlugin          = mysql.WithGormPlugin
+)
+
+// Init connect a database by driver, driver is mysql, postgres or sqlite, if empty, it is mysql.
+//
+// dsn format:
+//
+//	mysql: root:123456@(127.0.0.1:3306)/test?charset=utf8mb4&parseTime=True&loc=Local
+//	postgres: host=127.0.0.1 port=5432 user=root password=123456 dbname=test sslmode=disable TimeZone=Asia/Shanghai
+//	sqlite: /path/to/test.db, or ":memory:" for in-memory database
+func Init(driver string, dsn string, opts ...Option) (*gorm.DB, error) {
+	switch strings.ToLower(driver) {
+	case "", DriverMysql:
+		return mysql.Init(dsn, opts...)
+	case DriverPostgres, "postgresql":
+		return mysql.InitWithDialector(PostgresDialector, dsn, opts...)
+	case DriverSqlite:
+		return initSqlite(dsn, opts...)
+	}
+	return nil, fmt.Errorf("unsupported database driver '%s', only mysql, postgres and sqlite are supported", driver)
+}
+
+// PostgresDialector the dialector of postgres
+func PostgresDialector(dsn string) gorm.Dialector {
+	return postgres.Open(dsn)
+}
+
+// SqliteDialector the dialector of sqlite
+func SqliteDialector(dsn string) gorm.Dialector {
+	return sqlite.Open(dsn)
+}
+
+func initSqlite(dsn string, opts ...Option) (*gorm.DB, error) {
+	db, err := mysql.InitWithDialector(SqliteDialector, dsn, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// each connection of an in-memory database is a separate database,
+	// so only one connection is used and it is never closed
+	if isSqliteMemory(dsn) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+	}
+
+	return db, nil
+}
+
+func isSqliteMemory(dsn string) bool {
+	return dsn == "" || strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory")
+}
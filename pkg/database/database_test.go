@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type userExample struct {
+	ID        uint64 `gorm:"column:id;primaryKey;autoIncrement"`
+	Name      string `gorm:"column:name;type:varchar(40);not null"`
+	CreatedAt time.Time
+}
+
+func TestInit_sqlite(t *testing.T) {
+	db, err := Init(DriverSqlite, ":memory:",
+		WithLogging(nil),
+		WithEnableTrace(),
+		WithMaxOpenConns(10),
+		WithGormPlugin(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "sqlite", db.Name())
+
+	// the in-memory database is kept in a single connection
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sqlDB.Stats().MaxOpenConnections)
+
+	err = db.AutoMigrate(&userExample{})
+	assert.NoError(t, err)
+	err = db.Create(&userExample{Name: "foo"}).Error
+	assert.NoError(t, err)
+	record := &userExample{}
+	err = db.Where("name = ?", "foo").First(record).Error
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), record.ID)
+	_ = sqlDB.Close()
+}
+
+func TestInit_sqliteRWSeparation(t *testing.T) {
+	dir := t.TempDir()
+	master := dir + "/master.db"
+	slave := dir + "/slave.db"
+
+	db, err := Init(DriverSqlite, master, WithRWSeparation([]string{slave}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, db.AutoMigrate(&userExample{}))
+	assert.NoError(t, db.Create(&userExample{Name: "foo"}).Error)
+
+	// read from the slave, which has no table
+	err = db.Where("name = ?", "foo").First(&userExample{}).Error
+	assert.Error(t, err)
+}
+
+func TestInit_postgres(t *testing.T) {
+	dsn := "host=192.168.3.37 port=5432 user=root password=123456 dbname=test sslmode=disable connect_timeout=1"
+	db, err := Init(DriverPostgres, dsn, WithEnableTrace())
+	if err != nil {
+		// ignore test error about not being able to connect to real postgres
+		t.Logf(fmt.Sprintf("connect to postgres failed, err=%v, dsn=%s", err, dsn))
+		return
+	}
+	t.Logf("%+v", db.Name())
+}
+
+func TestInit_error(t *testing.T) {
+	_, err := Init("oracle", "dsn")
+	assert.Error(t, err)
+
+	// mysql is the default driver
+	_, err = Init("", "root:123456@(127.0.0.1:1)/test?timeout=1s")
+	assert.Error(t, err)
+}
+
+func Test_Dialector(t *testing.T) {
+	var d gorm.Dialector = PostgresDialector("host=127.0.0.1")
+	assert.Equal(t, "postgres", d.Name())
+	d = SqliteDialector(":memory:")
+	assert.Equal(t, "sqlite", d.Name())
+	assert.True(t, isSqliteMemory("file::memory:?cache=shared"))
+	assert.False(t, isSqliteMemory("test.db"))
+}
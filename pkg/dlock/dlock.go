@@ -0,0 +1,283 @@
+// Package dlock is a distributed lock library based on redis, with automatic lease extension,
+// fencing tokens and a redlock mode for multiple independent redis nodes.
+package dlock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hankyu66/sponge/pkg/krand"
+
+	"github.com/go-redis/redis/v8"
+)
+
+var (
+	// ErrNotObtained the lock is held by others
+	ErrNotObtained = errors.New("dlock: lock not obtained")
+	// ErrNotHeld the lock is not held by the current holder, it may have expired or been unlocked
+	ErrNotHeld = errors.New("dlock: lock not held")
+)
+
+// set the lock if it does not exist, and return the current fencing token of the node, -1 if the lock is held by others,
+// the two keys use the same hash tag so that they are in the same slot in redis cluster
+var acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return tonumber(redis.call("GET", KEYS[2]) or "0")
+end
+return -1
+`)
+
+// set the fencing token of the node if the lock is still held and the token is greater than the current one
+var fenceScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(redis.call("GET", KEYS[2]) or "0") >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("SET", KEYS[2], ARGV[2])
+return 1
+`)
+
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Mutex distributed lock, a Mutex instance can be held by only one goroutine at a time
+type Mutex struct {
+	clients  []redis.UniversalClient
+	quorum   int
+	lockKey  string
+	tokenKey string
+	opts     *options
+
+	mu     sync.Mutex
+	value  string
+	token  int64
+	stop   chan struct{}
+	lost   chan struct{}
+	isHeld bool
+}
+
+// New create a distributed lock on a redis, the client can be a single, sentinel or cluster client
+func New(client redis.UniversalClient, key string, opts ...Option) *Mutex {
+	return NewRedlock([]redis.UniversalClient{client}, key, opts...)
+}
+
+// NewRedlock create a distributed lock on multiple independent redis nodes,
+// the lock is acquired when it is set on a majority of nodes within the lease time.
+// see: https://redis.io/docs/manual/patterns/distributed-locks/
+func NewRedlock(clients []redis.UniversalClient, key string, opts ...Option) *Mutex {
+	o := defaultOptions()
+	o.apply(opts...)
+	if o.renewInterval <= 0 || o.renewInterval >= o.expiration {
+		o.renewInterval = o.expiration / 3
+	}
+
+	return &Mutex{
+		clients:  clients,
+		quorum:   len(clients)/2 + 1,
+		lockKey:  o.keyPrefix + "{" + key + "}",
+		tokenKey: o.keyPrefix + "{" + key + "}:token",
+		opts:     o,
+	}
+}
+
+// TryLock try to acquire the lock once, return false if the lock is held by others
+func (m *Mutex) TryLock(ctx context.Context) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.isHeld {
+		return false, nil
+	}
+
+	value := krand.String(krand.R_All, 32)
+	token, err := m.acquire(ctx, value)
+	if err != nil {
+		if errors.Is(err, ErrNotObtained) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	m.value = value
+	m.token = token
+	m.isHeld = true
+	m.stop = make(chan struct{})
+	m.lost = make(chan struct{})
+	go m.renew(m.value, m.stop, m.lost)
+
+	return true, nil
+}
+
+// Lock acquire the lock, block until the lock is acquired or ctx is done
+func (m *Mutex) Lock(ctx context.Context) error {
+	ticker := time.NewTicker(m.opts.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := m.TryLock(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Unlock release the lock, return ErrNotHeld if the lock has expired or been held by others
+func (m *Mutex) Unlock(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.isHeld {
+		return ErrNotHeld
+	}
+	close(m.stop)
+	m.isHeld = false
+
+	n := 0
+	var lastErr error
+	for _, client := range m.clients {
+		res, err := releaseScript.Run(ctx, client, []string{m.lockKey}, m.value).Int64()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if res == 1 {
+			n++
+		}
+	}
+	if n < m.quorum {
+		if lastErr != nil {
+			return lastErr
+		}
+		return ErrNotHeld
+	}
+
+	return nil
+}
+
+// Token return the fencing token of the current holder, it increases monotonically each time the lock is acquired,
+// pass it to the storage so that writes with an older token can be rejected.
+func (m *Mutex) Token() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.token
+}
+
+// Lost return a channel that is closed when the lease could not be extended, the holder should stop working
+func (m *Mutex) Lost() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lost
+}
+
+// acquire the lock on a majority of nodes within the lease time, the fencing token is the maximum token of the
+// locked majority plus one, and it is written back to the majority before the lock is returned. The majority of
+// each holder intersects the majority written by the previous holder, so the token increases monotonically.
+func (m *Mutex) acquire(ctx context.Context, value string) (int64, error) {
+	start := time.Now()
+	expiration := m.opts.expiration.Milliseconds()
+	keys := []string{m.lockKey, m.tokenKey}
+
+	var locked []redis.UniversalClient
+	var maxToken int64
+	var lastErr error
+	for _, client := range m.clients {
+		token, err := acquireScript.Run(ctx, client, keys, value, expiration).Int64()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if token >= 0 {
+			locked = append(locked, client)
+			if token > maxToken {
+				maxToken = token
+			}
+		}
+	}
+
+	if len(locked) >= m.quorum {
+		token := maxToken + 1
+		n := 0
+		for _, client := range locked {
+			res, err := fenceScript.Run(ctx, client, keys, value, token).Int64()
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if res == 1 {
+				n++
+			}
+		}
+
+		drift := time.Duration(float64(m.opts.expiration)*m.opts.driftFactor) + 2*time.Millisecond
+		validity := m.opts.expiration - time.Since(start) - drift
+		if n >= m.quorum && validity > 0 {
+			return token, nil
+		}
+	}
+
+	// release the nodes that have been locked
+	for _, client := range m.clients {
+		_ = releaseScript.Run(context.Background(), client, []string{m.lockKey}, value).Err()
+	}
+	if len(locked) == 0 && lastErr != nil {
+		return 0, lastErr
+	}
+	return 0, ErrNotObtained
+}
+
+// extend the lease on a majority of nodes
+func (m *Mutex) extend(ctx context.Context, value string) bool {
+	n := 0
+	for _, client := range m.clients {
+		res, err := extendScript.Run(ctx, client, []string{m.lockKey}, value, m.opts.expiration.Milliseconds()).Int64()
+		if err == nil && res == 1 {
+			n++
+		}
+	}
+	return n >= m.quorum
+}
+
+// renew extend the lease periodically until the lock is released or the lease is lost
+func (m *Mutex) renew(value string, stop chan struct{}, lost chan struct{}) {
+	ticker := time.NewTicker(m.opts.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), m.opts.renewInterval)
+			ok := m.extend(ctx, value)
+			cancel()
+			if !ok {
+				close(lost)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package dlock
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, redis.NewClient(&redis.Options{Addr: s.Addr()})
+}
+
+func TestMutex_TryLock(t *testing.T) {
+	s, client := newRedis(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	m1 := New(client, "foo")
+	m2 := New(client, "foo")
+
+	ok, err := m1.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), m1.Token())
+
+	ok, err = m2.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	err = m1.Unlock(ctx)
+	assert.NoError(t, err)
+	err = m1.Unlock(ctx)
+	assert.ErrorIs(t, err, ErrNotHeld)
+
+	// fencing token increases
+	ok, err = m2.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), m2.Token())
+	assert.NoError(t, m2.Unlock(ctx))
+}
+
+func TestMutex_Lock(t *testing.T) {
+	s, client := newRedis(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	var count int32
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := New(client, "bar", WithRetryInterval(time.Millisecond*5))
+			if err := m.Lock(ctx); err != nil {
+				t.Error(err)
+				return
+			}
+			// only one holder at a time
+			assert.Equal(t, int32(1), atomic.AddInt32(&count, 1))
+			time.Sleep(time.Millisecond * 10)
+			atomic.AddInt32(&count, -1)
+			assert.NoError(t, m.Unlock(ctx))
+		}()
+	}
+	wg.Wait()
+
+	// context cancellation
+	m1 := New(client, "bar")
+	assert.NoError(t, m1.Lock(ctx))
+	ctx2, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+	err := New(client, "bar").Lock(ctx2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NoError(t, m1.Unlock(ctx))
+}
+
+func TestMutex_renew(t *testing.T) {
+	s, client := newRedis(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	m := New(client, "renew", WithExpiration(time.Millisecond*300), WithRenewInterval(time.Millisecond*50), WithKeyPrefix("test:"))
+	assert.NoError(t, m.Lock(ctx))
+
+	// the lease is extended, the lock does not expire
+	time.Sleep(time.Millisecond * 120)
+	s.FastForward(time.Millisecond * 200)
+	assert.True(t, s.Exists("test:{renew}"))
+
+	// the lease is lost
+	s.Del("test:{renew}")
+	select {
+	case <-m.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("lost is not notified")
+	}
+	assert.ErrorIs(t, m.Unlock(ctx), ErrNotHeld)
+}
+
+func TestRedlock(t *testing.T) {
+	var clients []redis.UniversalClient
+	var servers []*miniredis.Miniredis
+	for i := 0; i < 3; i++ {
+		s, client := newRedis(t)
+		defer s.Close()
+		servers = append(servers, s)
+		clients = append(clients, client)
+	}
+	ctx := context.Background()
+
+	m1 := NewRedlock(clients, "order")
+	m2 := NewRedlock(clients, "order")
+	ok, err := m1.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = m2.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, m1.Unlock(ctx))
+
+	// a minority of nodes is down
+	servers[0].Close()
+	ok, err = m2.TryLock(ctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), m2.Token())
+	assert.NoError(t, m2.Unlock(ctx))
+
+	// a majority of nodes is down
+	servers[1].Close()
+	ok, _ = m1.TryLock(ctx)
+	assert.False(t, ok)
+
+	// all nodes are down
+	servers[2].Close()
+	ok, err = m1.TryLock(ctx)
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
+
+func TestRedlock_token(t *testing.T) {
+	var clients []redis.UniversalClient
+	var servers []*miniredis.Miniredis
+	for i := 0; i < 3; i++ {
+		s, client := newRedis(t)
+		defer s.Close()
+		servers = append(servers, s)
+		clients = append(clients, client)
+	}
+	ctx := context.Background()
+
+	// each holder locks a different majority, e.g. A {1,2}, B {2,3}, C {1,3},
+	// the node locked by others is not in the majority of the holder
+	var lastToken int64
+	for _, blocked := range []int{2, 0, 1, 2, 0} {
+		assert.NoError(t, servers[blocked].Set("dlock:{order}", "others"))
+		m := NewRedlock(clients, "order")
+		ok, err := m.TryLock(ctx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Greater(t, m.Token(), lastToken)
+		lastToken = m.Token()
+		assert.NoError(t, m.Unlock(ctx))
+		servers[blocked].Del("dlock:{order}")
+	}
+	assert.Equal(t, int64(5), lastToken)
+}
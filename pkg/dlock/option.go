@@ -0,0 +1,65 @@
+package dlock
+
+import "time"
+
+// Option set the lock options.
+type Option func(*options)
+
+type options struct {
+	expiration    time.Duration
+	retryInterval time.Duration
+	renewInterval time.Duration
+	driftFactor   float64
+	keyPrefix     string
+}
+
+func (o *options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// default settings
+func defaultOptions() *options {
+	return &options{
+		expiration:    10 * time.Second,       // lease time of the lock
+		retryInterval: 100 * time.Millisecond, // interval of retrying to acquire the lock in Lock
+		renewInterval: 0,                      // interval of lease extension, if 0, it is one third of expiration
+		driftFactor:   0.01,                   // clock drift factor of redlock
+		keyPrefix:     "dlock:",               // prefix of redis keys
+	}
+}
+
+// WithExpiration set the lease time of the lock, the lease is extended automatically while the lock is held
+func WithExpiration(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.expiration = d
+		}
+	}
+}
+
+// WithRetryInterval set the interval of retrying to acquire the lock in Lock
+func WithRetryInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.retryInterval = d
+		}
+	}
+}
+
+// WithRenewInterval set the interval of lease extension, must be less than expiration
+func WithRenewInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.renewInterval = d
+		}
+	}
+}
+
+// WithKeyPrefix set the prefix of redis keys, default is "dlock:"
+func WithKeyPrefix(prefix string) Option {
+	return func(o *options) {
+		o.keyPrefix = prefix
+	}
+}
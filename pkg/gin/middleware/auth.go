@@ -13,6 +13,9 @@ import (
 const (
 	// HeaderAuthorizationKey http header authorization key
 	HeaderAuthorizationKey = "Authorization"
+
+	// ContextUIDKey the key of uid in gin.Context, it is put into the context by WrapCtx too
+	ContextUIDKey = "uid"
 )
 
 type jwtOptions struct {
@@ -93,7 +96,7 @@ func Auth(opts ...JwtOption) gin.HandlerFunc {
 				return
 			}
 		} else {
-			c.Set("uid", claims.UID)
+			c.Set(ContextUIDKey, claims.UID)
 			c.Set("role", claims.Role)
 		}
 
@@ -133,10 +133,13 @@ func HeaderRequestIDField(c *gin.Context) zap.Field {
 // RequestHeaderKey request header key
 var RequestHeaderKey = "request_header_key"
 
-// WrapCtx wrap context, put the Keys and Header of gin.Context into context
+// WrapCtx wrap context, put the request id, uid and Header of gin.Context into context
 func WrapCtx(c *gin.Context) context.Context {
 	ctx := context.WithValue(c.Request.Context(), ContextRequestIDKey, c.GetString(ContextRequestIDKey)) //nolint
-	return context.WithValue(ctx, RequestHeaderKey, c.Request.Header)                                    //nolint
+	if uid := c.GetString(ContextUIDKey); uid != "" {
+		ctx = context.WithValue(ctx, ContextUIDKey, uid) //nolint
+	}
+	return context.WithValue(ctx, RequestHeaderKey, c.Request.Header) //nolint
 }
 
 // GetFromCtx get value from context
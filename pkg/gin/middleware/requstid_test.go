@@ -55,9 +55,11 @@ func TestFieldRequestIDFromContext(t *testing.T) {
 		t.Log(field)
 
 		c.Set("foo", "bar")
+		c.Set(ContextUIDKey, "100")
 
 		ctx := WrapCtx(c)
 		t.Log(ctx.Value(ContextRequestIDKey))
+		assert.Equal(t, "100", ctx.Value(ContextUIDKey))
 		t.Log(GetFromCtx(ctx, "foo"))
 		t.Log(CtxRequestIDField(ctx))
 		t.Log(GetFromCtx(ctx, "not-exist"))
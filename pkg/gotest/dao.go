@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql/driver"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
@@ -24,8 +27,31 @@ type Dao struct {
 	closeFns []func()
 }
 
-// NewDao instantiated dao
-func NewDao(c *Cache, testData interface{}) *Dao {
+// DaoOption set the dao options.
+type DaoOption func(*daoOptions)
+
+type daoOptions struct {
+	driver string
+}
+
+func (o *daoOptions) apply(opts ...DaoOption) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// WithDriver set the sql dialect of the mock database, mysql or postgres, default is mysql
+func WithDriver(driver string) DaoOption {
+	return func(o *daoOptions) {
+		o.driver = driver
+	}
+}
+
+// NewDao instantiated dao, the database is mocked by sqlmock
+func NewDao(c *Cache, testData interface{}, opts ...DaoOption) *Dao {
+	o := &daoOptions{driver: "mysql"}
+	o.apply(opts...)
+
 	var closeFns []func()
 
 	if c != nil {
@@ -39,11 +65,18 @@ func NewDao(c *Cache, testData interface{}) *Dao {
 	if err != nil {
 		panic(err)
 	}
-	mockDB, err := gorm.Open(
-		mysql.New(mysql.Config{
+	var dialector gorm.Dialector
+	switch strings.ToLower(o.driver) {
+	case "postgres":
+		dialector = postgres.New(postgres.Config{Conn: sqlDB})
+	default:
+		dialector = mysql.New(mysql.Config{
 			Conn:                      sqlDB,
 			SkipInitializeWithVersion: true,
-		}),
+		})
+	}
+	mockDB, err := gorm.Open(
+		dialector,
 		&gorm.Config{
 			NamingStrategy: schema.NamingStrategy{SingularTable: true},
 		})
@@ -66,6 +99,53 @@ func NewDao(c *Cache, testData interface{}) *Dao {
 	}
 }
 
+// NewSqliteDao instantiated dao, the database is a real in-memory sqlite database instead of sqlmock,
+// the tables of testData and models are created automatically, SQLMock is nil.
+func NewSqliteDao(c *Cache, testData interface{}, models ...interface{}) *Dao {
+	var closeFns []func()
+
+	if c != nil {
+		closeFns = append(closeFns, func() {
+			c.redisServer.Close()
+		})
+	}
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+	})
+	if err != nil {
+		panic(err)
+	}
+	// each connection of an in-memory database is a separate database
+	sqlDB, err := db.DB()
+	if err != nil {
+		panic(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	if testData != nil {
+		models = append([]interface{}{testData}, models...)
+	}
+	if len(models) > 0 {
+		err = db.AutoMigrate(models...)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	closeFns = append(closeFns, func() {
+		_ = sqlDB.Close()
+	})
+
+	return &Dao{
+		Ctx:      context.Background(),
+		TestData: testData,
+		Cache:    c,
+		DB:       db,
+		AnyTime:  &anyTime{},
+		closeFns: closeFns,
+	}
+}
+
 // Close dao
 func (d *Dao) Close() {
 	for _, fn := range d.closeFns {
@@ -9,6 +9,7 @@ import (
 	"github.com/hankyu66/sponge/pkg/mysql/query"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
 
@@ -26,6 +27,47 @@ func TestNewDao(t *testing.T) {
 	defer d.Close()
 }
 
+func TestNewDao_postgres(t *testing.T) {
+	testData := &User{ID: 1, Name: "foo"}
+
+	d := NewDao(nil, testData, WithDriver("postgres"))
+	d.IDao = newUserDao(d.DB)
+	defer d.Close()
+	assert.Equal(t, "postgres", d.DB.Name())
+
+	d.SQLMock.ExpectQuery(`SELECT \* FROM "user" WHERE id = \$1`).
+		WithArgs(testData.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(testData.ID, testData.Name))
+	record, err := d.IDao.(*userDao).GetByID(d.Ctx, testData.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, testData.Name, record.Name)
+}
+
+func TestNewSqliteDao(t *testing.T) {
+	now := time.Now()
+	testData := &User{
+		Name:      "foo",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	d := NewSqliteDao(NewCache(nil), testData)
+	d.IDao = newUserDao(d.DB)
+	defer d.Close()
+	assert.Nil(t, d.SQLMock)
+
+	err := d.IDao.(*userDao).Create(d.Ctx, testData)
+	assert.NoError(t, err)
+	record, err := d.IDao.(*userDao).GetByID(d.Ctx, testData.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", record.Name)
+
+	records, total, err := d.IDao.(*userDao).GetByColumns(d.Ctx, &query.Params{Size: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, 1, len(records))
+}
+
 func TestDao_GetAnyArgs(t *testing.T) {
 	now := time.Now()
 	testData := &User{
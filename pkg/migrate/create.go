@@ -0,0 +1,65 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+const embedFileContent = `// Package migrations the sql migration files of the service, the file name format is
+// <version>_<name>.up.sql and <version>_<name>.down.sql, create them by the command 'sponge migrate create'.
+package migrations
+
+import "embed"
+
+// FS sql migration files
+//
+//go:embed *.sql
+var FS embed.FS
+`
+
+var migrationNameRegexp = regexp.MustCompile(`^\w+$`)
+
+// Create create the up and down sql files of a new migration in dir, the version is the current time,
+// and an embed.go file is created if it does not exist, so the files can be compiled into the service.
+// return the paths of the created files.
+func Create(dir string, name string) ([]string, error) {
+	if !migrationNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name '%s', only letters, digits and underscores are allowed", name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	version := time.Now().Format("20060102150405")
+	files := []struct {
+		path    string
+		content string
+	}{
+		{filepath.Join(dir, version+"_"+name+upSuffix), fmt.Sprintf("-- migration %s_%s up\n\n", version, name)},
+		{filepath.Join(dir, version+"_"+name+downSuffix), fmt.Sprintf("-- migration %s_%s down\n\n", version, name)},
+	}
+
+	embedFile := filepath.Join(dir, "embed.go")
+	if _, err := os.Stat(embedFile); os.IsNotExist(err) {
+		files = append(files, struct {
+			path    string
+			content string
+		}{embedFile, embedFileContent})
+	}
+
+	paths := []string{}
+	for _, file := range files {
+		if _, err := os.Stat(file.path); err == nil {
+			return paths, fmt.Errorf("file %s already exists", file.path)
+		}
+		if err := os.WriteFile(file.path, []byte(file.content), 0666); err != nil {
+			return paths, err
+		}
+		paths = append(paths, file.path)
+	}
+
+	return paths, nil
+}
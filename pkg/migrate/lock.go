@@ -0,0 +1,220 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrLocked the lock is held by another migrator
+var ErrLocked = errors.New("migration lock is held by another process")
+
+// Locker lock to stop concurrent runs, *dlock.Mutex of pkg/dlock implements it
+type Locker interface {
+	Lock(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
+
+// lock of the database, the lock is bound to a session (connection) in mysql and postgres,
+// a row of the lock table is used in other databases, e.g. sqlite, the row has a lease which is renewed
+// while the lock is held, so the lock of a crashed process is acquired by others after the lease expires.
+type dbLocker struct {
+	db            *gorm.DB
+	name          string
+	timeout       time.Duration
+	retryInterval time.Duration
+	lease         time.Duration
+
+	conn      *sql.Conn
+	stopRenew chan struct{}
+	renewDone chan struct{}
+}
+
+func newDBLocker(db *gorm.DB, name string, timeout time.Duration, retryInterval time.Duration, lease time.Duration) *dbLocker {
+	return &dbLocker{
+		db:            db,
+		name:          name,
+		timeout:       timeout,
+		retryInterval: retryInterval,
+		lease:         lease,
+	}
+}
+
+// Lock acquire the lock, return ErrLocked if it is not acquired within the timeout
+func (l *dbLocker) Lock(ctx context.Context) error {
+	switch l.db.Dialector.Name() {
+	case "mysql":
+		return l.lockSession(ctx, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+			var result sql.NullInt64
+			err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", l.name, int(l.timeout.Seconds())).Scan(&result)
+			return result.Valid && result.Int64 == 1, err
+		})
+	case "postgres":
+		return l.lockSession(ctx, func(ctx context.Context, conn *sql.Conn) (bool, error) {
+			return l.retry(ctx, func() (bool, error) {
+				var ok bool
+				err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key()).Scan(&ok)
+				return ok, err
+			})
+		})
+	default:
+		return l.lockTable(ctx)
+	}
+}
+
+// Unlock release the lock
+func (l *dbLocker) Unlock(ctx context.Context) error {
+	switch l.db.Dialector.Name() {
+	case "mysql":
+		return l.unlockSession(ctx, "SELECT RELEASE_LOCK(?)", l.name)
+	case "postgres":
+		return l.unlockSession(ctx, "SELECT pg_advisory_unlock($1)", l.key())
+	default:
+		l.stopRenewing()
+		return l.db.WithContext(ctx).Table(l.lockTableName()).Where("name = ?", l.name).Delete(&lockRecord{}).Error
+	}
+}
+
+// the session lock must be released by the same connection, so hold the connection until unlocking
+func (l *dbLocker) lockSession(ctx context.Context, acquire func(ctx context.Context, conn *sql.Conn) (bool, error)) error {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	ok, err := acquire(ctx, conn)
+	if err != nil || !ok {
+		_ = conn.Close()
+		if err != nil {
+			return err
+		}
+		return ErrLocked
+	}
+
+	l.conn = conn
+	return nil
+}
+
+func (l *dbLocker) unlockSession(ctx context.Context, query string, args ...interface{}) error {
+	if l.conn == nil {
+		return nil
+	}
+	defer func() {
+		_ = l.conn.Close()
+		l.conn = nil
+	}()
+	_, err := l.conn.ExecContext(ctx, query, args...)
+	return err
+}
+
+type lockRecord struct {
+	Name      string `gorm:"column:name;type:varchar(255);primaryKey"`
+	LockedAt  time.Time
+	ExpiresAt time.Time
+}
+
+func (l *dbLocker) lockTableName() string {
+	return l.name + "_lock"
+}
+
+// the row can be inserted only once because of the primary key, the expired row is deleted before inserting
+func (l *dbLocker) lockTable(ctx context.Context) error {
+	db := l.db.WithContext(ctx).Table(l.lockTableName())
+	if err := db.AutoMigrate(&lockRecord{}); err != nil {
+		return err
+	}
+
+	ok, err := l.retry(ctx, func() (bool, error) {
+		now := time.Now()
+		err := l.db.WithContext(ctx).Table(l.lockTableName()).Where("name = ? AND expires_at < ?", l.name, now).Delete(&lockRecord{}).Error
+		if err != nil {
+			return false, err
+		}
+		err = l.db.WithContext(ctx).Table(l.lockTableName()).Create(&lockRecord{Name: l.name, LockedAt: now, ExpiresAt: now.Add(l.lease)}).Error
+		if err != nil {
+			if l.isDuplicateKey(ctx, err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLocked
+	}
+
+	l.stopRenew = make(chan struct{})
+	l.renewDone = make(chan struct{})
+	go l.renew(l.stopRenew, l.renewDone)
+	return nil
+}
+
+// the row is held by others if the error is a duplicate key error, the error is translated by the dialector if it supports,
+// otherwise check whether the row exists.
+func (l *dbLocker) isDuplicateKey(ctx context.Context, err error) bool {
+	if translator, ok := l.db.Dialector.(gorm.ErrorTranslator); ok {
+		return errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey)
+	}
+	var count int64
+	e := l.db.WithContext(ctx).Table(l.lockTableName()).Where("name = ?", l.name).Count(&count).Error
+	return e == nil && count > 0
+}
+
+// renew the lease of the row until unlocking
+func (l *dbLocker) renew(stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(l.lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			_ = l.db.Table(l.lockTableName()).Where("name = ?", l.name).Update("expires_at", time.Now().Add(l.lease)).Error
+		}
+	}
+}
+
+func (l *dbLocker) stopRenewing() {
+	if l.stopRenew == nil {
+		return
+	}
+	close(l.stopRenew)
+	<-l.renewDone
+	l.stopRenew, l.renewDone = nil, nil
+}
+
+func (l *dbLocker) retry(ctx context.Context, acquire func() (bool, error)) (bool, error) {
+	deadline := time.Now().Add(l.timeout)
+	for {
+		ok, err := acquire()
+		if err != nil || ok {
+			return ok, err
+		}
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(l.retryInterval):
+		}
+	}
+}
+
+// key of the postgres advisory lock
+func (l *dbLocker) key() int64 {
+	return int64(crc32.ChecksumIEEE([]byte(fmt.Sprintf("sponge_migrate:%s", l.name))))
+}
@@ -0,0 +1,301 @@
+// Package migrate is a library of versioned schema migrations, it runs ordered up/down sql or go migrations,
+// tracks the applied migrations in a table, and uses a lock to stop concurrent runs.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Migration a versioned migration, use the sql or the go functions, if both are set, the go functions are used.
+// the migration is executed in a transaction, note that the DDL statements of mysql are committed implicitly.
+type Migration struct {
+	Version int64
+	Name    string
+
+	UpSQL   string
+	DownSQL string
+
+	Up   func(ctx context.Context, tx *gorm.DB) error
+	Down func(ctx context.Context, tx *gorm.DB) error
+}
+
+// Status status of a migration
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	IsApplied bool       `json:"isApplied"`
+	AppliedAt *time.Time `json:"appliedAt"`
+}
+
+// record of an applied migration
+type schemaMigration struct {
+	Version   int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name;type:varchar(255)"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+// Migrator execute migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration // sorted by version
+	tableName  string
+	locker     Locker
+	allowEmpty bool
+	log        *zap.Logger
+}
+
+// New create a migrator, the migrations are loaded from WithDir or WithFS and WithMigrations
+func New(db *gorm.DB, opts ...Option) (*Migrator, error) {
+	o := defaultOptions()
+	o.apply(opts...)
+
+	var migrations []*Migration
+	if o.fsys != nil {
+		list, err := loadSQLMigrations(o.fsys)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, list...)
+	}
+	migrations = append(migrations, o.migrations...)
+
+	versions := map[int64]string{}
+	for _, m := range migrations {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("invalid version %d of migration '%s'", m.Version, m.Name)
+		}
+		if m.Up == nil && m.UpSQL == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up sql or function", m.Version, m.Name)
+		}
+		if name, ok := versions[m.Version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d, name '%s' and '%s'", m.Version, name, m.Name)
+		}
+		versions[m.Version] = m.Name
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	locker := o.locker
+	if locker == nil {
+		locker = newDBLocker(db, o.tableName, o.lockTimeout, o.retryInterval, o.lockLease)
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		tableName:  o.tableName,
+		locker:     locker,
+		allowEmpty: o.allowEmpty,
+		log:        o.log,
+	}, nil
+}
+
+// Up apply all pending migrations, return the number of applied migrations
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.UpTo(ctx, 0)
+}
+
+// UpTo apply the pending migrations whose version is less than or equal to version,
+// if version is 0, apply all pending migrations. return the number of applied migrations.
+func (m *Migrator) UpTo(ctx context.Context, version int64) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(applied map[int64]*schemaMigration) error {
+		for _, migration := range m.migrations {
+			if version > 0 && migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.checkEmpty(migration, true); err != nil {
+				return err
+			}
+			if err := m.run(ctx, migration, true); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down roll back the last steps applied migrations, return the number of rolled back migrations
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, nil
+	}
+
+	count := 0
+	err := m.withLock(ctx, func(applied map[int64]*schemaMigration) error {
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil && migration.DownSQL == "" {
+				return fmt.Errorf("migration %d_%s has no down sql or function", migration.Version, migration.Name)
+			}
+			if err := m.checkEmpty(migration, false); err != nil {
+				return err
+			}
+			if err := m.run(ctx, migration, false); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status get the status of all migrations, the applied migrations not found in the source are included too
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Status{}
+	for _, migration := range m.migrations {
+		s := &Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			s.IsApplied = true
+			s.AppliedAt = &appliedAt
+			delete(applied, migration.Version)
+		}
+		list = append(list, s)
+	}
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		list = append(list, &Status{Version: record.Version, Name: record.Name, IsApplied: true, AppliedAt: &appliedAt})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	return list, nil
+}
+
+// Version get the latest applied version, return 0 if no migration is applied
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	if err := m.createTable(ctx); err != nil {
+		return 0, err
+	}
+	record := &schemaMigration{}
+	err := m.db.WithContext(ctx).Table(m.tableName).Order("version DESC").Limit(1).Find(record).Error
+	return record.Version, err
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(applied map[int64]*schemaMigration) error) (err error) {
+	if err = m.createTable(ctx); err != nil {
+		return err
+	}
+
+	if err = m.locker.Lock(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		if e := m.locker.Unlock(context.Background()); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	// get the applied migrations after locking, they may be changed by others before locking
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	return fn(applied)
+}
+
+func (m *Migrator) createTable(ctx context.Context) error {
+	return m.db.WithContext(ctx).Table(m.tableName).AutoMigrate(&schemaMigration{})
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]*schemaMigration, error) {
+	if err := m.createTable(ctx); err != nil {
+		return nil, err
+	}
+	records := []*schemaMigration{}
+	err := m.db.WithContext(ctx).Table(m.tableName).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]*schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// the sql without statements (e.g. only comments) is rejected unless allowEmpty is set,
+// otherwise the migration would be marked as applied or rolled back without changing anything
+func (m *Migrator) checkEmpty(migration *Migration, isUp bool) error {
+	direction, fn, sql, marked := "up", migration.Up, migration.UpSQL, "applied"
+	if !isUp {
+		direction, fn, sql, marked = "down", migration.Down, migration.DownSQL, "rolled back"
+	}
+	if fn != nil || m.allowEmpty || len(splitStatements(sql)) > 0 {
+		return nil
+	}
+	return fmt.Errorf("the %s sql of migration %d_%s has no statements, it would be marked as %s "+
+		"without changing anything, write the %s sql or allow empty sql", direction, migration.Version, migration.Name, marked, direction)
+}
+
+// execute a migration and record it in the same transaction
+func (m *Migrator) run(ctx context.Context, migration *Migration, isUp bool) error {
+	direction, fn, sql := "up", migration.Up, migration.UpSQL
+	if !isUp {
+		direction, fn, sql = "down", migration.Down, migration.DownSQL
+	}
+	start := time.Now()
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if fn != nil {
+			if err := fn(ctx, tx); err != nil {
+				return err
+			}
+		} else {
+			for _, statement := range splitStatements(sql) {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if isUp {
+			return tx.Table(m.tableName).Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		}
+		result := tx.Table(m.tableName).Where("version = ?", migration.Version).Delete(&schemaMigration{})
+		if result.Error == nil && result.RowsAffected == 0 {
+			return errors.New("record of the migration not found")
+		}
+		return result.Error
+	})
+	if err != nil {
+		return fmt.Errorf("migrate %s %d_%s error: %v", direction, migration.Version, migration.Name, err)
+	}
+
+	m.log.Info("migrate "+direction,
+		zap.Int64("version", migration.Version),
+		zap.String("name", migration.Name),
+		zap.String("cost", time.Since(start).String()),
+	)
+	return nil
+}
@@ -0,0 +1,341 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testFS = fstest.MapFS{
+	"20230101000000_create_user.up.sql": {Data: []byte(`-- create table user
+CREATE TABLE user (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(40) NOT NULL
+);
+INSERT INTO user (id, name) VALUES (1, 'foo');
+`)},
+	"20230101000000_create_user.down.sql": {Data: []byte("DROP TABLE user;\n")},
+	"20230102000000_add_age.up.sql":       {Data: []byte("ALTER TABLE user ADD COLUMN age INTEGER NOT NULL DEFAULT 0;\n")},
+	"20230102000000_add_age.down.sql":     {Data: []byte("ALTER TABLE user DROP COLUMN age;\n")},
+	"README.md":                           {Data: []byte("ignored")},
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1) // every connection of memory database is a new database
+	return db
+}
+
+func TestMigrator(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	goMigration := &Migration{
+		Version: 20230103000000,
+		Name:    "insert_bar",
+		Up: func(ctx context.Context, tx *gorm.DB) error {
+			return tx.Exec("INSERT INTO user (id, name, age) VALUES (2, 'bar', 20)").Error
+		},
+		Down: func(ctx context.Context, tx *gorm.DB) error {
+			return tx.Exec("DELETE FROM user WHERE id = 2").Error
+		},
+	}
+	m, err := New(db, WithFS(testFS), WithMigrations(goMigration))
+	assert.NoError(t, err)
+
+	n, err := m.UpTo(ctx, 20230102000000)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	version, err := m.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20230102000000), version)
+
+	n, err = m.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	var count int64
+	assert.NoError(t, db.Table("user").Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	// nothing to apply
+	n, err = m.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	status, err := m.Status(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(status))
+	for _, s := range status {
+		assert.True(t, s.IsApplied)
+		assert.NotNil(t, s.AppliedAt)
+	}
+
+	n, err = m.Down(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	version, err = m.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20230101000000), version)
+	assert.False(t, db.Migrator().HasColumn("user", "age"))
+
+	n, err = m.Down(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.False(t, db.Migrator().HasTable("user"))
+
+	status, err = m.Status(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(status))
+	assert.False(t, status[0].IsApplied)
+}
+
+func TestMigrator_error(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	m, err := New(db, WithMigrations(
+		&Migration{Version: 1, Name: "create_foo", UpSQL: "CREATE TABLE foo (id INTEGER);"},
+		&Migration{Version: 2, Name: "bad_sql", UpSQL: "CREATE TABLE foo (id INTEGER);\nINSERT INTO bar VALUES (1);"},
+	))
+	assert.NoError(t, err)
+
+	// the second migration is rolled back
+	n, err := m.Up(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+	version, _ := m.Version(ctx)
+	assert.Equal(t, int64(1), version)
+
+	// no down sql
+	_, err = m.Down(ctx, 1)
+	assert.Error(t, err)
+
+	// the applied migration is not found in the source
+	m, err = New(db)
+	assert.NoError(t, err)
+	status, err := m.Status(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(status))
+	assert.True(t, status[0].IsApplied)
+}
+
+func TestNew_error(t *testing.T) {
+	db := newTestDB(t)
+
+	_, err := New(db, WithFS(testFS), WithMigrations(&Migration{Version: 20230101000000, Name: "foo", UpSQL: "SELECT 1;"}))
+	assert.Error(t, err)
+	_, err = New(db, WithMigrations(&Migration{Version: 1, Name: "foo"}))
+	assert.Error(t, err)
+	_, err = New(db, WithMigrations(&Migration{Version: 0, Name: "foo", UpSQL: "SELECT 1;"}))
+	assert.Error(t, err)
+	_, err = New(db, WithFS(fstest.MapFS{"foo.sql": {Data: []byte("SELECT 1;")}}))
+	assert.Error(t, err)
+	_, err = New(db, WithFS(fstest.MapFS{"1_foo.down.sql": {Data: []byte("SELECT 1;")}}))
+	assert.Error(t, err)
+	_, err = New(db, WithDir("/not/exist/dir"))
+	assert.Error(t, err)
+}
+
+func TestMigrator_locked(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	locker := newDBLocker(db, "schema_migrations", time.Minute, time.Millisecond, time.Minute)
+	assert.NoError(t, locker.Lock(ctx))
+
+	m, err := New(db, WithFS(testFS), WithLockTimeout(time.Millisecond*10))
+	assert.NoError(t, err)
+	_, err = m.Up(ctx)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	assert.NoError(t, locker.Unlock(ctx))
+	n, err := m.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestMigrator_lockLease(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	// the lease is renewed while the lock is held
+	locker := newDBLocker(db, "schema_migrations", time.Millisecond*10, time.Millisecond, time.Millisecond*150)
+	assert.NoError(t, locker.Lock(ctx))
+	time.Sleep(time.Millisecond * 300)
+	other := newDBLocker(db, "schema_migrations", time.Millisecond*10, time.Millisecond, time.Millisecond*150)
+	assert.ErrorIs(t, other.Lock(ctx), ErrLocked)
+	assert.NoError(t, locker.Unlock(ctx))
+
+	// the lock of a crashed process is acquired after the lease expires
+	crashed := newDBLocker(db, "schema_migrations", time.Millisecond*10, time.Millisecond, time.Millisecond*150)
+	assert.NoError(t, crashed.Lock(ctx))
+	close(crashed.stopRenew)
+	<-crashed.renewDone
+	assert.ErrorIs(t, other.Lock(ctx), ErrLocked)
+	time.Sleep(time.Millisecond * 200)
+	assert.NoError(t, other.Lock(ctx))
+	assert.NoError(t, other.Unlock(ctx))
+}
+
+type mockLocker struct {
+	err error
+}
+
+func (l *mockLocker) Lock(ctx context.Context) error {
+	return l.err
+}
+
+func (l *mockLocker) Unlock(ctx context.Context) error {
+	return nil
+}
+
+func TestMigrator_lockError(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	// the error of inserting the row is returned, not ErrLocked
+	err := db.Callback().Create().Before("gorm:create").Register("test:create_error", func(db *gorm.DB) {
+		_ = db.AddError(errors.New("mock create error"))
+	})
+	assert.NoError(t, err)
+	locker := newDBLocker(db, "schema_migrations", time.Second, time.Millisecond, time.Minute)
+	err = locker.Lock(ctx)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrLocked)
+	assert.Contains(t, err.Error(), "mock create error")
+}
+
+func TestWithLocker(t *testing.T) {
+	db := newTestDB(t)
+	m, err := New(db, WithFS(testFS), WithLocker(&mockLocker{err: errors.New("mock error")}), WithTableName("migrations"))
+	assert.NoError(t, err)
+	_, err = m.Up(context.Background())
+	assert.Error(t, err)
+	_, err = m.Down(context.Background(), 1)
+	assert.Error(t, err)
+}
+
+func Test_splitStatements(t *testing.T) {
+	sql := `-- comment
+CREATE TABLE foo (
+    id INTEGER
+);
+
+INSERT INTO foo VALUES (1);
+UPDATE foo SET id = 3;
+
+-- comment only;
+
+DELETE FROM foo`
+	statements := splitStatements(sql)
+	assert.Equal(t, []string{
+		"-- comment\nCREATE TABLE foo (\n    id INTEGER\n);",
+		"INSERT INTO foo VALUES (1);",
+		"UPDATE foo SET id = 3;",
+		"-- comment only;\n\nDELETE FROM foo",
+	}, statements)
+
+	// the semicolons in the quoted strings, dollar-quoted strings and comments
+	sql = `CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := now(); -- end;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+CREATE FUNCTION foo() RETURNS text AS $body$ SELECT 'a;$$;b'; $body$ LANGUAGE sql;
+INSERT INTO foo VALUES ('a;b', 'it''s;', 'c\';d', "e;f", ` + "`g;h`" + `); /* block;
+comment; */ SELECT $1; SELECT 1`
+	statements = splitStatements(sql)
+	assert.Equal(t, []string{
+		"CREATE FUNCTION set_updated_at() RETURNS trigger AS $$\nBEGIN\n    NEW.updated_at := now(); -- end;\n    RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;",
+		"CREATE FUNCTION foo() RETURNS text AS $body$ SELECT 'a;$$;b'; $body$ LANGUAGE sql;",
+		"INSERT INTO foo VALUES ('a;b', 'it''s;', 'c\\';d', \"e;f\", `g;h`);",
+		"/* block;\ncomment; */ SELECT $1;",
+		"SELECT 1",
+	}, statements)
+	assert.Empty(t, splitStatements("-- migration 1_foo down\n\n/* nothing; */\n"))
+}
+
+func TestCreate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	paths, err := Create(dir, "create_user")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(paths))
+	info, err := os.Stat(dir)
+	assert.NoError(t, err)
+	assert.True(t, info.Mode().Perm()&0100 != 0) // the directory is accessible
+
+	m, err := New(newTestDB(t), WithDir(dir))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(m.migrations))
+	assert.Equal(t, "create_user", m.migrations[0].Name)
+
+	// embed.go exists
+	time.Sleep(time.Second)
+	paths, err = Create(dir, "add_age")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(paths))
+	_, err = os.Stat(filepath.Join(dir, "embed.go"))
+	assert.NoError(t, err)
+
+	_, err = Create(dir, "bad name")
+	assert.Error(t, err)
+}
+
+func TestMigrator_emptyDown(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	migration := &Migration{Version: 1, Name: "create_foo", UpSQL: "CREATE TABLE foo (id INTEGER);", DownSQL: "-- migration 1_create_foo down\n\n"}
+
+	m, err := New(db, WithMigrations(migration))
+	assert.NoError(t, err)
+	n, err := m.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// the empty down sql is rejected, the migration is still applied
+	_, err = m.Down(ctx, 1)
+	assert.Error(t, err)
+	version, err := m.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	m, err = New(db, WithMigrations(migration), WithAllowEmpty())
+	assert.NoError(t, err)
+	n, err = m.Down(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestMigrator_emptyUp(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	migration := &Migration{Version: 1, Name: "create_foo", UpSQL: "-- migration 1_create_foo up\n\n", DownSQL: "-- migration 1_create_foo down\n\n"}
+
+	// the empty up sql is rejected, the migration is not applied
+	m, err := New(db, WithMigrations(migration))
+	assert.NoError(t, err)
+	_, err = m.Up(ctx)
+	assert.Error(t, err)
+	version, err := m.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+
+	m, err = New(db, WithMigrations(migration), WithAllowEmpty())
+	assert.NoError(t, err)
+	n, err := m.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
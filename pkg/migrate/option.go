@@ -0,0 +1,115 @@
+package migrate
+
+import (
+	"io/fs"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Option set the migrator options.
+type Option func(*options)
+
+type options struct {
+	fsys          fs.FS
+	migrations    []*Migration
+	tableName     string
+	locker        Locker
+	lockTimeout   time.Duration
+	lockLease     time.Duration
+	retryInterval time.Duration
+	allowEmpty    bool
+	log           *zap.Logger
+}
+
+func (o *options) apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// default settings
+func defaultOptions() *options {
+	return &options{
+		tableName:     "schema_migrations",    // table of the applied migrations
+		lockTimeout:   time.Minute,            // max time of waiting for the lock held by others
+		lockLease:     time.Minute,            // lease of the lock row in the databases without session lock, it is renewed while running
+		retryInterval: 500 * time.Millisecond, // interval of retrying to acquire the lock
+		log:           zap.NewNop(),
+	}
+}
+
+// WithDir set the directory of the sql migration files
+func WithDir(dir string) Option {
+	return func(o *options) {
+		o.fsys = os.DirFS(dir)
+	}
+}
+
+// WithFS set the file system of the sql migration files, e.g. embed.FS, the files are in the root directory
+func WithFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.fsys = fsys
+	}
+}
+
+// WithMigrations add go migrations, they can be mixed with sql migrations, but the versions must be unique
+func WithMigrations(migrations ...*Migration) Option {
+	return func(o *options) {
+		o.migrations = append(o.migrations, migrations...)
+	}
+}
+
+// WithTableName set the table name of the applied migrations, default is schema_migrations
+func WithTableName(name string) Option {
+	return func(o *options) {
+		if name != "" {
+			o.tableName = name
+		}
+	}
+}
+
+// WithLocker set a custom lock to stop concurrent runs, e.g. a redis lock of pkg/dlock,
+// default is the lock of the database.
+func WithLocker(l Locker) Option {
+	return func(o *options) {
+		o.locker = l
+	}
+}
+
+// WithLockTimeout set the max time of waiting for the lock held by others, default is 1 minute
+func WithLockTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.lockTimeout = d
+		}
+	}
+}
+
+// WithLockLease set the lease of the lock row used in the databases without session lock, e.g. sqlite,
+// the lease is renewed while running, the lock of a crashed process is released after the lease expires, default is 1 minute
+func WithLockLease(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.lockLease = d
+		}
+	}
+}
+
+// WithAllowEmpty allow applying or rolling back the migration whose up or down sql has no statements, e.g. only comments,
+// by default it is rejected, because the migration would be marked as applied or rolled back without changing anything.
+func WithAllowEmpty() Option {
+	return func(o *options) {
+		o.allowEmpty = true
+	}
+}
+
+// WithLogger set logger
+func WithLogger(l *zap.Logger) Option {
+	return func(o *options) {
+		if l != nil {
+			o.log = l
+		}
+	}
+}
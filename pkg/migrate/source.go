@@ -0,0 +1,153 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+// file name format: <version>_<name>.up.sql, <version>_<name>.down.sql
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// load sql migrations from the root directory of fsys
+func loadSQLMigrations(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migration files error: %v", err)
+	}
+
+	migrations := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		matches := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if len(matches) != 4 {
+			return nil, fmt.Errorf("invalid migration file name '%s', the format is <version>_<name>.up.sql or <version>_<name>.down.sql", entry.Name())
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version of migration file '%s': %v", entry.Name(), err)
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := migrations[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			migrations[version] = m
+		} else if m.Name != matches[2] {
+			return nil, fmt.Errorf("duplicate migration version %d, name '%s' and '%s'", version, m.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			m.UpSQL = string(data)
+		} else {
+			m.DownSQL = string(data)
+		}
+	}
+
+	list := make([]*Migration, 0, len(migrations))
+	for _, m := range migrations {
+		if m.UpSQL == "" {
+			return nil, fmt.Errorf("missing file %d_%s%s", m.Version, m.Name, upSuffix)
+		}
+		list = append(list, m)
+	}
+
+	return list, nil
+}
+
+// split sql into statements, a statement ends with ';' outside of the quoted strings and identifiers,
+// the dollar-quoted strings of postgres (e.g. $$...$$, $body$...$body$) and the comments,
+// the statements only containing comments are ignored.
+// note that the backslash escapes the next character in the quoted strings, as mysql does.
+func splitStatements(sql string) []string {
+	var (
+		statements []string
+		start      int
+		hasCode    bool
+	)
+	addStatement := func(end int) {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(sql[start:end]))
+		}
+		start, hasCode = end, false
+	}
+
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case strings.HasPrefix(sql[i:], "--"):
+			i = indexFrom(sql, i+2, "\n")
+		case strings.HasPrefix(sql[i:], "/*"):
+			i = indexFrom(sql, i+2, "*/") + 1
+		case c == '\'' || c == '"' || c == '`':
+			i = endOfQuoted(sql, i)
+			hasCode = true
+		case c == '$' && !isIdentChar(sql, i-1) && dollarTagRegexp.MatchString(sql[i:]):
+			tag := dollarTagRegexp.FindString(sql[i:])
+			i = indexFrom(sql, i+len(tag), tag) + len(tag) - 1
+			hasCode = true
+		case c == ';':
+			addStatement(i + 1)
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			hasCode = true
+		}
+	}
+	addStatement(len(sql))
+
+	return statements
+}
+
+// the opening tag of the dollar-quoted string, e.g. $$, $body$
+var dollarTagRegexp = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// index of substr in s from the position, return len(s) if not found
+func indexFrom(s string, from int, substr string) int {
+	if from > len(s) {
+		return len(s)
+	}
+	i := strings.Index(s[from:], substr)
+	if i < 0 {
+		return len(s)
+	}
+	return from + i
+}
+
+// index of the closing quote of the quoted string or identifier starting at the position,
+// the quote is escaped by doubling it or by a backslash except in the backtick-quoted identifier.
+func endOfQuoted(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isIdentChar(s string, i int) bool {
+	if i < 0 {
+		return false
+	}
+	c := s[i]
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
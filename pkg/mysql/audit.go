@@ -0,0 +1,541 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	auditRowsKey = "sponge:audit_rows"
+	auditSkipKey = "sponge:audit_skip"
+
+	// AuditCreate operation of creating a row
+	AuditCreate = "create"
+	// AuditUpdate operation of updating a row
+	AuditUpdate = "update"
+	// AuditDelete operation of deleting a row, including soft delete
+	AuditDelete = "delete"
+)
+
+// AuditChange old and new value of a column, the old value is nil when creating, the new value is nil when deleting
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditRecord who changed which row and how
+type AuditRecord struct {
+	Table      string                 // table name
+	Operation  string                 // create, update or delete
+	PrimaryKey string                 // value of the primary key, the values of composite primary key are joined by comma
+	Changes    map[string]AuditChange // changed columns
+	Operator   string                 // the acting user, e.g. uid of jwt
+	RequestID  string                 // request id
+	CreatedAt  time.Time
+}
+
+// AuditSink write the audit records, db is a new session of the statement, if the statement is executed in
+// a transaction, the records written by db are in the same transaction. if an error is returned, the statement
+// is rolled back.
+type AuditSink interface {
+	Write(ctx context.Context, db *gorm.DB, records []*AuditRecord) error
+}
+
+// AuditSinkFunc function of AuditSink, e.g. send the records to log or message queue
+type AuditSinkFunc func(ctx context.Context, db *gorm.DB, records []*AuditRecord) error
+
+// Write audit records
+func (f AuditSinkFunc) Write(ctx context.Context, db *gorm.DB, records []*AuditRecord) error {
+	return f(ctx, db, records)
+}
+
+// AuditLog audit table model, create the table by db.Table(tableName).AutoMigrate(&mysql.AuditLog{})
+type AuditLog struct {
+	ID         uint64    `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"`
+	Table      string    `gorm:"column:table_name;type:varchar(64);NOT NULL;index" json:"tableName"`
+	Operation  string    `gorm:"column:operation;type:varchar(10);NOT NULL" json:"operation"`
+	PrimaryKey string    `gorm:"column:primary_key;type:varchar(255);NOT NULL" json:"primaryKey"`
+	Changes    string    `gorm:"column:changes;type:text" json:"changes"` // json of changed columns, e.g. {"name":{"old":"foo","new":"bar"}}
+	Operator   string    `gorm:"column:operator;type:varchar(64);NOT NULL" json:"operator"`
+	RequestID  string    `gorm:"column:request_id;type:varchar(64);NOT NULL" json:"requestID"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"createdAt"`
+}
+
+type auditTableSink struct {
+	table string
+}
+
+// NewAuditTableSink write the audit records to the table in the same database, default table is audit_log
+func NewAuditTableSink(table ...string) AuditSink {
+	s := &auditTableSink{table: "audit_log"}
+	if len(table) > 0 && table[0] != "" {
+		s.table = table[0]
+	}
+	return s
+}
+
+// Write audit records to the table
+func (s *auditTableSink) Write(ctx context.Context, db *gorm.DB, records []*AuditRecord) error {
+	logs := make([]*AuditLog, 0, len(records))
+	for _, r := range records {
+		changes, err := json.Marshal(r.Changes)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, &AuditLog{
+			Table:      r.Table,
+			Operation:  r.Operation,
+			PrimaryKey: r.PrimaryKey,
+			Changes:    string(changes),
+			Operator:   r.Operator,
+			RequestID:  r.RequestID,
+			CreatedAt:  r.CreatedAt,
+		})
+	}
+	return db.WithContext(ctx).Table(s.table).Create(&logs).Error
+}
+
+// ------------------------------------------------------------------------------------------
+
+// auditPlugin record the rows changed by create, update and delete, the old values are queried before
+// updating and deleting, the new values are queried after updating, in the same transaction of the statement.
+// the raw sql of db.Exec is not audited.
+type auditPlugin struct {
+	sink           AuditSink
+	excludeTables  map[string]struct{}
+	excludeColumns map[string]struct{} // column or table.column
+	operatorKey    string
+	requestIDKey   string
+}
+
+func newAuditPlugin(o *options) *auditPlugin {
+	p := &auditPlugin{
+		sink:           o.auditSink,
+		excludeTables:  map[string]struct{}{},
+		excludeColumns: map[string]struct{}{},
+		operatorKey:    o.auditOperatorKey,
+		requestIDKey:   o.auditRequestIDKey,
+	}
+	if p.sink == nil {
+		p.sink = NewAuditTableSink()
+	}
+	if s, ok := p.sink.(*auditTableSink); ok {
+		p.excludeTables[s.table] = struct{}{}
+	}
+	for _, table := range o.auditExcludeTables {
+		p.excludeTables[table] = struct{}{}
+	}
+	for _, column := range o.auditExcludeColumns {
+		p.excludeColumns[column] = struct{}{}
+	}
+	return p
+}
+
+// Name plugin name
+func (p *auditPlugin) Name() string {
+	return "sponge:audit"
+}
+
+// Initialize register callbacks, the old values are queried after the table name is rewritten by sharding
+func (p *auditPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	err := cb.Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").
+		Register("sponge:audit", p.afterCreate)
+	if err != nil {
+		return err
+	}
+
+	err = cb.Update().Before("gorm:update").After("sponge:sharding").Register("sponge:audit_before", p.before)
+	if err != nil {
+		return err
+	}
+	err = cb.Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").
+		Register("sponge:audit", p.afterUpdate)
+	if err != nil {
+		return err
+	}
+
+	err = cb.Delete().Before("gorm:delete").After("sponge:sharding").Register("sponge:audit_before", p.before)
+	if err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").
+		Register("sponge:audit", p.afterDelete)
+}
+
+func (p *auditPlugin) skip(db *gorm.DB) bool {
+	if db.Error != nil || db.Statement.Table == "" {
+		return true
+	}
+	if _, ok := db.Statement.Settings.Load(auditSkipKey); ok {
+		return true
+	}
+	_, ok := p.excludeTables[db.Statement.Table]
+	return ok
+}
+
+func (p *auditPlugin) isExcludedColumn(table string, column string) bool {
+	if _, ok := p.excludeColumns[column]; ok {
+		return true
+	}
+	_, ok := p.excludeColumns[table+"."+column]
+	return ok
+}
+
+// query the rows to be updated or deleted
+func (p *auditPlugin) before(db *gorm.DB) {
+	if p.skip(db) {
+		return
+	}
+	stmt := db.Statement
+
+	var conds []clause.Expression
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			conds = append(conds, where.Exprs...)
+		}
+	}
+	conds = append(conds, primaryKeyConds(stmt)...)
+	if len(conds) == 0 && !db.AllowGlobalUpdate {
+		return // rejected by gorm
+	}
+
+	rows, err := p.queryRows(db, conds)
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("audit: query the rows before writing, %v", err))
+		return
+	}
+	db.InstanceSet(auditRowsKey, rows)
+}
+
+func (p *auditPlugin) afterCreate(db *gorm.DB) {
+	if p.skip(db) || db.RowsAffected == 0 {
+		return
+	}
+	stmt := db.Statement
+
+	var records []*AuditRecord
+	for _, row := range createdRows(stmt) {
+		changes := map[string]AuditChange{}
+		for column, value := range row {
+			if !p.isExcludedColumn(stmt.Table, column) {
+				changes[column] = AuditChange{New: value}
+			}
+		}
+		records = append(records, p.newRecord(stmt, AuditCreate, primaryKey(row, primaryKeyNames(stmt)), changes))
+	}
+	p.write(db, records)
+}
+
+func (p *auditPlugin) afterUpdate(db *gorm.DB) {
+	oldRows, ok := p.beforeRows(db)
+	if !ok {
+		return
+	}
+	stmt := db.Statement
+	pkNames := primaryKeyNames(stmt)
+
+	newRows := map[string]map[string]interface{}{}
+	if values := primaryKeyValues(oldRows, pkNames); len(values) > 0 {
+		column, queryValues := schema.ToQueryValues(stmt.Table, pkNames, values)
+		rows, err := p.queryRows(db, []clause.Expression{clause.IN{Column: column, Values: queryValues}})
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("audit: query the rows after update, %v", err))
+			return
+		}
+		for _, row := range rows {
+			newRows[primaryKey(row, pkNames)] = row
+		}
+	}
+
+	var records []*AuditRecord
+	for _, oldRow := range oldRows {
+		pk := primaryKey(oldRow, pkNames)
+		newRow, ok := newRows[pk]
+		if !ok {
+			newRow = assignedValues(stmt, oldRow) // the table has no primary key, use the assigned values
+		}
+		changes := map[string]AuditChange{}
+		for column, newValue := range newRow {
+			oldValue := oldRow[column]
+			if p.isExcludedColumn(stmt.Table, column) || isEqualValue(oldValue, newValue) {
+				continue
+			}
+			changes[column] = AuditChange{Old: oldValue, New: newValue}
+		}
+		if len(changes) > 0 {
+			records = append(records, p.newRecord(stmt, AuditUpdate, pk, changes))
+		}
+	}
+	p.write(db, records)
+}
+
+func (p *auditPlugin) afterDelete(db *gorm.DB) {
+	oldRows, ok := p.beforeRows(db)
+	if !ok {
+		return
+	}
+	stmt := db.Statement
+	pkNames := primaryKeyNames(stmt)
+
+	var records []*AuditRecord
+	for _, oldRow := range oldRows {
+		changes := map[string]AuditChange{}
+		for column, value := range oldRow {
+			if !p.isExcludedColumn(stmt.Table, column) {
+				changes[column] = AuditChange{Old: value}
+			}
+		}
+		records = append(records, p.newRecord(stmt, AuditDelete, primaryKey(oldRow, pkNames), changes))
+	}
+	p.write(db, records)
+}
+
+func (p *auditPlugin) beforeRows(db *gorm.DB) ([]map[string]interface{}, bool) {
+	if p.skip(db) || db.RowsAffected == 0 {
+		return nil, false
+	}
+	v, ok := db.InstanceGet(auditRowsKey)
+	if !ok {
+		return nil, false
+	}
+	rows, ok := v.([]map[string]interface{})
+	return rows, ok && len(rows) > 0
+}
+
+// query the rows of the statement table by the conditions in a new session
+func (p *auditPlugin) queryRows(db *gorm.DB, conds []clause.Expression) ([]map[string]interface{}, error) {
+	stmt := db.Statement
+	tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Set(auditSkipKey, true)
+	if stmt.Model != nil && stmt.Schema != nil {
+		tx = tx.Model(stmt.Model) // soft deleted rows are excluded
+	}
+	if stmt.Unscoped {
+		tx = tx.Unscoped()
+	}
+	tx.Statement.Table = stmt.Table
+	tx.Statement.TableExpr = stmt.TableExpr
+	if len(conds) > 0 {
+		tx = tx.Clauses(clause.Where{Exprs: conds})
+	}
+
+	var rows []map[string]interface{}
+	err := tx.Find(&rows).Error
+	for _, row := range rows {
+		for column, value := range row {
+			row[column] = normalizeValue(value)
+		}
+	}
+	return rows, err
+}
+
+func (p *auditPlugin) newRecord(stmt *gorm.Statement, operation string, pk string, changes map[string]AuditChange) *AuditRecord {
+	return &AuditRecord{
+		Table:      stmt.Table,
+		Operation:  operation,
+		PrimaryKey: pk,
+		Changes:    changes,
+		Operator:   contextString(stmt.Context, p.operatorKey),
+		RequestID:  contextString(stmt.Context, p.requestIDKey),
+		CreatedAt:  time.Now(),
+	}
+}
+
+func (p *auditPlugin) write(db *gorm.DB, records []*AuditRecord) {
+	if len(records) == 0 {
+		return
+	}
+	tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Set(auditSkipKey, true)
+	if err := p.sink.Write(db.Statement.Context, tx, records); err != nil {
+		_ = db.AddError(fmt.Errorf("audit: write records, %v", err))
+	}
+}
+
+// ------------------------------------------------------------------------------------------
+
+// the primary key conditions added by gorm from the model or the records
+func primaryKeyConds(stmt *gorm.Statement) []clause.Expression {
+	if stmt.Schema == nil || len(stmt.Schema.PrimaryFields) == 0 || !stmt.ReflectValue.IsValid() {
+		return nil
+	}
+	var conds []clause.Expression
+	addConds := func(rv reflect.Value) {
+		_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, rv, stmt.Schema.PrimaryFields)
+		column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+		if len(values) > 0 {
+			conds = append(conds, clause.IN{Column: column, Values: values})
+		}
+	}
+	addConds(stmt.ReflectValue)
+	if stmt.Model != nil && stmt.Dest != stmt.Model {
+		addConds(reflect.ValueOf(stmt.Model))
+	}
+	return conds
+}
+
+func primaryKeyNames(stmt *gorm.Statement) []string {
+	if stmt.Schema != nil && len(stmt.Schema.PrimaryFieldDBNames) > 0 {
+		return stmt.Schema.PrimaryFieldDBNames
+	}
+	return []string{"id"}
+}
+
+func primaryKey(row map[string]interface{}, pkNames []string) string {
+	values := make([]string, 0, len(pkNames))
+	for _, name := range pkNames {
+		if v, ok := row[name]; ok && v != nil {
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+func primaryKeyValues(rows []map[string]interface{}, pkNames []string) [][]interface{} {
+	var values [][]interface{}
+	for _, row := range rows {
+		value := make([]interface{}, 0, len(pkNames))
+		for _, name := range pkNames {
+			v, ok := row[name]
+			if !ok || v == nil {
+				return nil
+			}
+			value = append(value, v)
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// the rows created by the statement, from the model or the map values
+func createdRows(stmt *gorm.Statement) []map[string]interface{} {
+	var rows []map[string]interface{}
+	addRow := func(rv reflect.Value) {
+		rv = reflect.Indirect(rv)
+		switch rv.Kind() { //nolint
+		case reflect.Struct:
+			if stmt.Schema == nil {
+				return
+			}
+			row := map[string]interface{}{}
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName == "" || !field.Readable {
+					continue
+				}
+				v, _ := field.ValueOf(stmt.Context, rv)
+				row[field.DBName] = normalizeValue(v)
+			}
+			rows = append(rows, row)
+		case reflect.Map:
+			if m, ok := rv.Interface().(map[string]interface{}); ok {
+				row := map[string]interface{}{}
+				for column, v := range m {
+					row[column] = normalizeValue(v)
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	rv := reflect.Indirect(stmt.ReflectValue)
+	switch rv.Kind() { //nolint
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			addRow(rv.Index(i))
+		}
+	default:
+		addRow(rv)
+	}
+	return rows
+}
+
+// the values assigned by the SET clause, the expressions are ignored
+func assignedValues(stmt *gorm.Statement, oldRow map[string]interface{}) map[string]interface{} {
+	row := map[string]interface{}{}
+	c, ok := stmt.Clauses["SET"]
+	if !ok {
+		return row
+	}
+	set, ok := c.Expression.(clause.Set)
+	if !ok {
+		return row
+	}
+	for _, a := range set {
+		if _, ok := oldRow[a.Column.Name]; !ok {
+			continue
+		}
+		if _, isExpr := a.Value.(clause.Expression); isExpr {
+			continue
+		}
+		row[a.Column.Name] = normalizeValue(a.Value)
+	}
+	return row
+}
+
+// the value of driver.Valuer, e.g. sql.NullString, and []byte are converted to the values in json
+func normalizeValue(v interface{}) interface{} {
+	v = indirectValue(v)
+	if valuer, ok := v.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil {
+			v = dv
+		}
+	}
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
+
+func indirectValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+func isEqualValue(a interface{}, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	// the same value of different types, e.g. int64 and int
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func contextString(ctx context.Context, key string) string {
+	if ctx == nil || key == "" {
+		return ""
+	}
+	switch v := ctx.Value(key).(type) { //nolint
+	case string:
+		return v
+	case nil:
+		return ""
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
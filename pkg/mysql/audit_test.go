@@ -0,0 +1,169 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type auditUser struct {
+	Model `gorm:"embedded"`
+
+	Name     string
+	Age      int
+	Password string
+}
+
+func newAuditTestDB(t *testing.T, opts ...Option) *gorm.DB {
+	dsn := filepath.Join(t.TempDir(), "audit.db")
+	db, err := InitWithDialector(sqliteDialector, dsn, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.AutoMigrate(&auditUser{}, &txUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Table("audit_log").AutoMigrate(&AuditLog{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func getAuditLogs(t *testing.T, db *gorm.DB) []*AuditLog {
+	var logs []*AuditLog
+	if err := db.Table("audit_log").Order("id").Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	return logs
+}
+
+func decodeChanges(t *testing.T, s string) map[string]AuditChange {
+	changes := map[string]AuditChange{}
+	if err := json.Unmarshal([]byte(s), &changes); err != nil {
+		t.Fatal(err)
+	}
+	return changes
+}
+
+func TestWithAudit(t *testing.T) {
+	db := newAuditTestDB(t, WithAudit(), WithAuditExcludeColumns("password", "audit_user.updated_at"))
+	ctx := context.WithValue(context.Background(), "uid", "100") //nolint
+	ctx = context.WithValue(ctx, "request_id", "req-1")          //nolint
+
+	user := &auditUser{Name: "foo", Age: 10, Password: "123456"}
+	err := db.WithContext(ctx).Create(user).Error
+	assert.NoError(t, err)
+	logs := getAuditLogs(t, db)
+	if assert.Len(t, logs, 1) {
+		assert.Equal(t, "audit_user", logs[0].Table)
+		assert.Equal(t, AuditCreate, logs[0].Operation)
+		assert.Equal(t, "1", logs[0].PrimaryKey)
+		assert.Equal(t, "100", logs[0].Operator)
+		assert.Equal(t, "req-1", logs[0].RequestID)
+		changes := decodeChanges(t, logs[0].Changes)
+		assert.Equal(t, AuditChange{New: "foo"}, changes["name"])
+		assert.NotContains(t, changes, "password")
+		assert.NotContains(t, changes, "updated_at")
+	}
+
+	// only the changed columns are recorded
+	err = db.WithContext(ctx).Model(user).Updates(map[string]interface{}{"name": "bar", "age": 10, "password": "654321"}).Error
+	assert.NoError(t, err)
+	logs = getAuditLogs(t, db)
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, AuditUpdate, logs[1].Operation)
+		assert.Equal(t, "1", logs[1].PrimaryKey)
+		changes := decodeChanges(t, logs[1].Changes)
+		assert.Equal(t, map[string]AuditChange{"name": {Old: "foo", New: "bar"}}, changes)
+	}
+
+	// expression and batch update
+	err = db.Create(&auditUser{Name: "baz", Age: 20}).Error
+	assert.NoError(t, err)
+	err = db.Model(&auditUser{}).Where("age >= ?", 10).Update("age", gorm.Expr("age + 1")).Error
+	assert.NoError(t, err)
+	logs = getAuditLogs(t, db)
+	if assert.Len(t, logs, 5) {
+		assert.Equal(t, "1", logs[3].PrimaryKey)
+		assert.Equal(t, map[string]AuditChange{"age": {Old: 10.0, New: 11.0}}, decodeChanges(t, logs[3].Changes))
+		assert.Equal(t, "2", logs[4].PrimaryKey)
+		assert.Equal(t, "", logs[4].Operator)
+	}
+
+	// no rows are changed
+	err = db.Model(&auditUser{}).Where("id = ?", 100).Update("age", 1).Error
+	assert.NoError(t, err)
+	assert.Len(t, getAuditLogs(t, db), 5)
+
+	// soft delete
+	err = db.WithContext(ctx).Delete(user).Error
+	assert.NoError(t, err)
+	logs = getAuditLogs(t, db)
+	if assert.Len(t, logs, 6) {
+		assert.Equal(t, AuditDelete, logs[5].Operation)
+		assert.Equal(t, "1", logs[5].PrimaryKey)
+		changes := decodeChanges(t, logs[5].Changes)
+		assert.Equal(t, AuditChange{Old: "bar"}, changes["name"])
+	}
+	// the soft deleted row is not deleted again
+	err = db.Where("id = ?", 1).Delete(&auditUser{}).Error
+	assert.NoError(t, err)
+	assert.Len(t, getAuditLogs(t, db), 6)
+
+	// table without model
+	err = db.Table("tx_user").Create(map[string]interface{}{"id": 1, "name": "foo"}).Error
+	assert.NoError(t, err)
+	err = db.Table("tx_user").Where("id = ?", 1).Update("name", "bar").Error
+	assert.NoError(t, err)
+	logs = getAuditLogs(t, db)
+	if assert.Len(t, logs, 8) {
+		assert.Equal(t, "tx_user", logs[6].Table)
+		assert.Equal(t, "1", logs[6].PrimaryKey)
+		assert.Equal(t, map[string]AuditChange{"name": {Old: "foo", New: "bar"}}, decodeChanges(t, logs[7].Changes))
+	}
+}
+
+func TestWithAudit_sink(t *testing.T) {
+	var records []*AuditRecord
+	sink := AuditSinkFunc(func(ctx context.Context, db *gorm.DB, rs []*AuditRecord) error {
+		for _, r := range rs {
+			if r.Table == "tx_user" && r.Operation == AuditDelete {
+				return errors.New("sink error")
+			}
+		}
+		records = append(records, rs...)
+		return nil
+	})
+	db := newAuditTestDB(t,
+		WithAudit(sink),
+		WithAuditExcludeTables("audit_user"),
+		WithAuditContextKeys("operator", ""),
+	)
+	ctx := context.WithValue(context.Background(), "operator", 1) //nolint
+
+	err := db.WithContext(ctx).Create(&auditUser{Name: "foo"}).Error
+	assert.NoError(t, err)
+	users := []*txUser{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}
+	err = db.WithContext(ctx).Create(&users).Error
+	assert.NoError(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "1", records[0].Operator)
+		assert.Equal(t, "2", records[1].PrimaryKey)
+		assert.Equal(t, AuditChange{New: "bar"}, records[1].Changes["name"])
+	}
+	assert.Empty(t, getAuditLogs(t, db))
+
+	// the write is rolled back if the sink returns an error
+	err = db.Delete(&txUser{}, 1).Error
+	assert.Error(t, err)
+	var count int64
+	db.Model(&txUser{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"errors"
 	"reflect"
 	"time"
 
@@ -26,6 +27,15 @@ type Model2 struct {
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index" json:"-"`
 }
 
+// ErrVersionConflict the record has been modified by others since it was read, that is, the version column does not match,
+// it is used for optimistic locking, add `version` column to the table, and the update condition is `WHERE version = ?`.
+var ErrVersionConflict = errors.New("version conflict, the record has been modified by others")
+
+// Deleted scope of the soft deleted records, e.g. db.Scopes(mysql.Deleted).Find(&records)
+func Deleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
+
 // KV map type
 type KV = map[string]interface{}
 
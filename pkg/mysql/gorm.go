@@ -15,6 +15,15 @@ import (
 	"gorm.io/plugin/dbresolver"
 )
 
+// Dialector create a gorm dialector from dsn, it is used to connect other databases with the same options,
+// see pkg/database for postgres and sqlite.
+type Dialector func(dsn string) gorm.Dialector
+
+// MysqlDialector the dialector of mysql
+func MysqlDialector(dsn string) gorm.Dialector {
+	return mysqlDriver.New(mysqlDriver.Config{DSN: dsn})
+}
+
 // Init mysql
 func Init(dns string, opts ...Option) (*gorm.DB, error) {
 	o := defaultOptions()
@@ -34,31 +43,106 @@ func Init(dns string, opts ...Option) (*gorm.DB, error) {
 	}
 	db.Set("gorm:table_options", "CHARSET=utf8mb4") // automatic appending of table suffixes when creating tables
 
+	return db, usePlugins(db, MysqlDialector, o)
+}
+
+// InitWithDialector connect a database with the dialector, the options are the same as Init
+func InitWithDialector(dialector Dialector, dsn string, opts ...Option) (*gorm.DB, error) {
+	o := defaultOptions()
+	o.apply(opts...)
+
+	db, err := gorm.Open(dialector(dsn), gormConfig(o))
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxIdleConns(o.maxIdleConns)       // set the maximum number of connections in the idle connection pool
+	sqlDB.SetMaxOpenConns(o.maxOpenConns)       // set the maximum number of open database connections
+	sqlDB.SetConnMaxLifetime(o.connMaxLifetime) // set the maximum time a connection can be reused
+
+	return db, usePlugins(db, dialector, o)
+}
+
+// CloseDB close the connection pools of db, including the sources and replicas of the read-write separation,
+// and remove the stats of the pools from the metrics
+func CloseDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	pools := []*sql.DB{sqlDB}
+	if resolver, ok := db.Config.Plugins[(&dbresolver.DBResolver{}).Name()].(*dbresolver.DBResolver); ok {
+		_ = resolver.Call(func(connPool gorm.ConnPool) error {
+			if pool, ok := getSQLDB(connPool); ok && pool != sqlDB {
+				pools = append(pools, pool)
+			}
+			return nil
+		})
+	}
+	statsCollector.remove(pools...)
+
+	for _, pool := range pools {
+		if e := pool.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// register trace, read-write separation, metrics, audit and custom plugins
+func usePlugins(db *gorm.DB, dialector Dialector, o *options) error {
 	// register trace plugin
 	if o.enableTrace {
-		err = db.Use(otelgorm.NewPlugin())
+		err := db.Use(otelgorm.NewPlugin())
 		if err != nil {
-			return nil, fmt.Errorf("using gorm opentelemetry, err: %v", err)
+			return fmt.Errorf("using gorm opentelemetry, err: %v", err)
 		}
 	}
 
 	// register read-write separation plugin
+	var resolver *dbresolver.DBResolver
 	if len(o.slavesDsn) > 0 {
-		err = db.Use(rwSeparationPlugin(o))
+		pool := newReplicaPool(o)
+		resolver = rwSeparationPlugin(dialector, o, pool)
+		err := db.Use(resolver)
+		if err != nil {
+			return err
+		}
+		err = useReplicaPool(db, resolver, pool, o)
 		if err != nil {
-			return nil, err
+			return err
+		}
+	}
+
+	// register metrics plugin
+	if o.enableMetrics {
+		err := useMetrics(db, resolver, o)
+		if err != nil {
+			return fmt.Errorf("using gorm metrics, err: %v", err)
+		}
+	}
+
+	// register audit plugin
+	if o.enableAudit {
+		err := db.Use(newAuditPlugin(o))
+		if err != nil {
+			return fmt.Errorf("using gorm audit, err: %v", err)
 		}
 	}
 
 	// register plugins
 	for _, plugin := range o.plugins {
-		err = db.Use(plugin)
+		err := db.Use(plugin)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return db, nil
+	return nil
 }
 
 // gorm setting
@@ -96,24 +180,56 @@ func gormConfig(o *options) *gorm.Config {
 	return config
 }
 
-func rwSeparationPlugin(o *options) gorm.Plugin {
+func rwSeparationPlugin(dialector Dialector, o *options, policy dbresolver.Policy) *dbresolver.DBResolver {
 	slaves := []gorm.Dialector{}
 	for _, dsn := range o.slavesDsn {
-		slaves = append(slaves, mysqlDriver.New(mysqlDriver.Config{
-			DSN: dsn,
-		}))
+		slaves = append(slaves, dialector(dsn))
 	}
 
 	masters := []gorm.Dialector{}
 	for _, dsn := range o.mastersDsn {
-		masters = append(masters, mysqlDriver.New(mysqlDriver.Config{
-			DSN: dsn,
-		}))
+		masters = append(masters, dialector(dsn))
 	}
 
 	return dbresolver.Register(dbresolver.Config{
 		Sources:  masters,
 		Replicas: slaves,
-		Policy:   dbresolver.RandomPolicy{},
+		Policy:   policy,
+	})
+}
+
+// add the replica connection pools to the pool, and start health checks
+func useReplicaPool(db *gorm.DB, resolver *dbresolver.DBResolver, pool *replicaPool, o *options) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	// the connection pools are called in order of sources and replicas,
+	// if the masters dsn is empty, the only source is the connection pool of db.
+	sourceNum := len(o.mastersDsn)
+	if sourceNum == 0 {
+		sourceNum = 1
+	}
+	i := 0
+	err = resolver.Call(func(connPool gorm.ConnPool) error {
+		defer func() { i++ }()
+		if i >= sourceNum {
+			if replicaDB, ok := getSQLDB(connPool); ok {
+				pool.add(i-sourceNum, replicaDB)
+			}
+		}
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	err = db.Use(pool)
+	if err != nil {
+		return err
+	}
+	go pool.runHealthCheck(sqlDB, db.Dialector.Name())
+
+	return nil
 }
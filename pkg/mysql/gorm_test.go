@@ -29,6 +29,7 @@ func Test_gormConfig(t *testing.T) {
 		WithLogging(nil, 4),
 		WithSlowThreshold(time.Millisecond*100),
 		WithEnableTrace(),
+		WithEnableMetrics(),
 		WithMaxIdleConns(5),
 		WithMaxOpenConns(50),
 		WithConnMaxLifetime(time.Minute*3),
@@ -44,7 +45,7 @@ func Test_gormConfig(t *testing.T) {
 	c := gormConfig(o)
 	assert.NotNil(t, c)
 
-	err := rwSeparationPlugin(o)
+	err := rwSeparationPlugin(MysqlDialector, o, newReplicaPool(o))
 	assert.NotNil(t, err)
 }
 
@@ -58,3 +59,39 @@ func TestGetTableName(t *testing.T) {
 	name = GetTableName("table")
 	assert.Empty(t, name)
 }
+
+func TestDeleted(t *testing.T) {
+	type softUser struct {
+		Model `gorm:"embedded"`
+		Name  string
+	}
+
+	db := newTxDB(t)
+	err := db.AutoMigrate(&softUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := []*softUser{{Name: "foo"}, {Name: "bar"}}
+	err = db.Create(&users).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Delete(&softUser{}, users[0].ID).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var records []*softUser
+	err = db.Scopes(Deleted).Find(&records).Error
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, "foo", records[0].Name)
+
+	// restore
+	result := db.Scopes(Deleted).Model(&softUser{}).Where("id = ?", users[0].ID).Update("deleted_at", nil)
+	assert.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	var count int64
+	db.Model(&softUser{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
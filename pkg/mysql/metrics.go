@@ -0,0 +1,282 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	metricsNamespace = "gorm"
+	metricsStartKey  = "sponge:metrics_start_time"
+)
+
+var (
+	queryLabels = []string{"db", "table", "operation"}
+
+	queryCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "query_total",
+			Help:      "Total number of sql queries.",
+		}, queryLabels,
+	)
+
+	queryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "query_duration_seconds",
+			Help:      "SQL query latencies in seconds.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, queryLabels,
+	)
+
+	queryErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "query_errors_total",
+			Help:      "Total number of failed sql queries, record not found is not an error.",
+		}, queryLabels,
+	)
+
+	rowsAffected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rows_affected_total",
+			Help:      "Total number of rows affected or returned by sql queries.",
+		}, queryLabels,
+	)
+
+	statsCollector = &dbStatsCollector{}
+
+	registerOnce sync.Once
+)
+
+// register the metrics to the prometheus default registry, the metrics of all databases are distinguished by the label db
+func registerMetrics() {
+	registerOnce.Do(func() {
+		prometheus.MustRegister(queryCount, queryDuration, queryErrors, rowsAffected, statsCollector)
+	})
+}
+
+// ------------------------------------------------------------------------------------------
+
+// metricsPlugin gorm plugin to record the count, latency, errors and rows affected of sql queries
+type metricsPlugin struct {
+	dbName string
+}
+
+// Name plugin name
+func (p *metricsPlugin) Name() string {
+	return "sponge:metrics"
+}
+
+// Initialize register callbacks
+func (p *metricsPlugin) Initialize(db *gorm.DB) error {
+	type register func(name string, fn func(*gorm.DB)) error
+	cb := db.Callback()
+	callbacks := []struct {
+		operation string
+		before    register
+		after     register
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, c := range callbacks {
+		err := c.before("sponge:metrics_before_"+c.operation, p.before)
+		if err != nil {
+			return err
+		}
+		err = c.after("sponge:metrics_after_"+c.operation, p.after(c.operation))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *metricsPlugin) before(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func (p *metricsPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		lvs := []string{p.dbName, table, operation}
+
+		queryCount.WithLabelValues(lvs...).Inc()
+		queryDuration.WithLabelValues(lvs...).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			queryErrors.WithLabelValues(lvs...).Inc()
+		}
+		if db.Statement.RowsAffected > 0 {
+			rowsAffected.WithLabelValues(lvs...).Add(float64(db.Statement.RowsAffected))
+		}
+	}
+}
+
+// ------------------------------------------------------------------------------------------
+
+var (
+	statsLabels = []string{"db", "role", "instance"}
+
+	maxOpenDesc = prometheus.NewDesc(metricsNamespace+"_dbstats_max_open_connections",
+		"Maximum number of open connections to the database.", statsLabels, nil)
+	openDesc = prometheus.NewDesc(metricsNamespace+"_dbstats_open_connections",
+		"The number of established connections both in use and idle.", statsLabels, nil)
+	inUseDesc = prometheus.NewDesc(metricsNamespace+"_dbstats_in_use_connections",
+		"The number of connections currently in use.", statsLabels, nil)
+	idleDesc = prometheus.NewDesc(metricsNamespace+"_dbstats_idle_connections",
+		"The number of idle connections.", statsLabels, nil)
+	waitCountDesc = prometheus.NewDesc(metricsNamespace+"_dbstats_wait_count_total",
+		"The total number of connections waited for.", statsLabels, nil)
+	waitDurationDesc = prometheus.NewDesc(metricsNamespace+"_dbstats_wait_duration_seconds_total",
+		"The total time blocked waiting for a new connection.", statsLabels, nil)
+)
+
+// the labels of the connection pool, the pool of the same labels is replaced, e.g. db is initialized again
+type dbStatsKey struct {
+	dbName   string
+	role     string // master or replica
+	instance string // index of the role
+}
+
+// dbStatsCollector collect sql.DBStats of the connection pools when prometheus scrapes
+type dbStatsCollector struct {
+	mu    sync.RWMutex
+	stats map[dbStatsKey]*sql.DB
+}
+
+func (c *dbStatsCollector) add(dbName string, role string, instance int, db *sql.DB) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stats == nil {
+		c.stats = make(map[dbStatsKey]*sql.DB)
+	}
+	c.stats[dbStatsKey{dbName: dbName, role: role, instance: strconv.Itoa(instance)}] = db
+}
+
+// remove the connection pools, e.g. they are closed
+func (c *dbStatsCollector) remove(dbs ...*sql.DB) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, db := range c.stats {
+		for _, v := range dbs {
+			if db == v {
+				delete(c.stats, key)
+				break
+			}
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- maxOpenDesc
+	ch <- openDesc
+	ch <- inUseDesc
+	ch <- idleDesc
+	ch <- waitCountDesc
+	ch <- waitDurationDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for key, db := range c.stats {
+		stats := db.Stats()
+		lvs := []string{key.dbName, key.role, key.instance}
+		ch <- prometheus.MustNewConstMetric(maxOpenDesc, prometheus.GaugeValue, float64(stats.MaxOpenConnections), lvs...)
+		ch <- prometheus.MustNewConstMetric(openDesc, prometheus.GaugeValue, float64(stats.OpenConnections), lvs...)
+		ch <- prometheus.MustNewConstMetric(inUseDesc, prometheus.GaugeValue, float64(stats.InUse), lvs...)
+		ch <- prometheus.MustNewConstMetric(idleDesc, prometheus.GaugeValue, float64(stats.Idle), lvs...)
+		ch <- prometheus.MustNewConstMetric(waitCountDesc, prometheus.CounterValue, float64(stats.WaitCount), lvs...)
+		ch <- prometheus.MustNewConstMetric(waitDurationDesc, prometheus.CounterValue, stats.WaitDuration.Seconds(), lvs...)
+	}
+}
+
+// register the metrics plugin and the connection pools of master and replicas, the resolver may be nil
+func useMetrics(db *gorm.DB, resolver *dbresolver.DBResolver, o *options) error {
+	registerMetrics()
+
+	dbName := o.metricsDBName
+	if dbName == "" {
+		dbName = db.Migrator().CurrentDatabase()
+		if dbName == "" {
+			dbName = db.Dialector.Name()
+		}
+	}
+
+	err := db.Use(&metricsPlugin{dbName: dbName})
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	statsCollector.add(dbName, "master", 0, sqlDB)
+
+	if resolver == nil {
+		return nil
+	}
+	// the connection pools are called in order of sources and replicas,
+	// if the masters dsn is empty, the only source is the connection pool of db.
+	sourceNum := len(o.mastersDsn)
+	if sourceNum == 0 {
+		sourceNum = 1
+	}
+	i := 0
+	return resolver.Call(func(connPool gorm.ConnPool) error {
+		defer func() { i++ }()
+		pool, ok := getSQLDB(connPool)
+		if !ok || pool == sqlDB {
+			return nil
+		}
+		if i < sourceNum {
+			statsCollector.add(dbName, "master", i+1, pool)
+		} else {
+			statsCollector.add(dbName, "replica", i-sourceNum, pool)
+		}
+		return nil
+	})
+}
+
+func getSQLDB(connPool gorm.ConnPool) (*sql.DB, bool) {
+	switch pool := connPool.(type) {
+	case *sql.DB:
+		return pool, true
+	case gorm.GetDBConnector:
+		db, err := pool.GetDBConn()
+		return db, err == nil
+	}
+	return nil, false
+}
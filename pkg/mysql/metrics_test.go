@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func sqliteDialector(dsn string) gorm.Dialector {
+	return sqlite.Open(dsn)
+}
+
+func TestWithEnableMetrics(t *testing.T) {
+	db, err := InitWithDialector(sqliteDialector, "file::memory:",
+		WithMaxOpenConns(1),
+		WithEnableMetrics("metrics_test"),
+		WithRWSeparation([]string{"file::memory:", "file::memory:"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.AutoMigrate(&txUser{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users := []*txUser{{Name: "foo"}, {Name: "bar"}}
+	err = db.Create(&users).Error
+	assert.NoError(t, err)
+	err = db.Model(&txUser{}).Where("id = ?", users[0].ID).Update("name", "baz").Error
+	assert.NoError(t, err)
+	err = db.Exec("SELECT * FROM not_exist").Error
+	assert.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(queryCount.WithLabelValues("metrics_test", "tx_user", "create")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(rowsAffected.WithLabelValues("metrics_test", "tx_user", "create")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(queryCount.WithLabelValues("metrics_test", "tx_user", "update")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(queryErrors.WithLabelValues("metrics_test", "unknown", "raw")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(queryErrors.WithLabelValues("metrics_test", "tx_user", "create")))
+
+	// connection pools of master and 2 replicas
+	expected := `
+# HELP gorm_dbstats_max_open_connections Maximum number of open connections to the database.
+# TYPE gorm_dbstats_max_open_connections gauge
+gorm_dbstats_max_open_connections{db="metrics_test",instance="0",role="master"} 1
+gorm_dbstats_max_open_connections{db="metrics_test",instance="0",role="replica"} 0
+gorm_dbstats_max_open_connections{db="metrics_test",instance="1",role="replica"} 0
+`
+	err = testutil.CollectAndCompare(statsCollector, strings.NewReader(expected), "gorm_dbstats_max_open_connections")
+	assert.NoError(t, err)
+}
+
+func TestCloseDB_metrics(t *testing.T) {
+	initDB := func() *gorm.DB {
+		db, err := InitWithDialector(sqliteDialector, "file::memory:", WithMaxOpenConns(2),
+			WithEnableMetrics("metrics_close_test"), WithRWSeparation([]string{"file::memory:"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return db
+	}
+
+	// the pools of the same labels are replaced, not duplicated
+	db1 := initDB()
+	db2 := initDB()
+	count := func() int {
+		statsCollector.mu.RLock()
+		defer statsCollector.mu.RUnlock()
+		n := 0
+		for key := range statsCollector.stats {
+			if key.dbName == "metrics_close_test" {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, 2, count())
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(statsCollector)
+	_, err := reg.Gather()
+	assert.NoError(t, err)
+
+	// the closed pools are removed
+	assert.NoError(t, CloseDB(db1))
+	assert.NoError(t, CloseDB(db2))
+	assert.Error(t, db2.Exec("SELECT 1").Error)
+	assert.Equal(t, 0, count())
+}
@@ -21,6 +21,8 @@ type options struct {
 
 	disableForeignKey bool
 	enableTrace       bool
+	enableMetrics     bool
+	metricsDBName     string
 
 	requestIDKey string
 	gLog         *zap.Logger
@@ -29,6 +31,19 @@ type options struct {
 	slavesDsn  []string
 	mastersDsn []string
 
+	replicaWeights       []int
+	replicaCheckInterval time.Duration
+	replicaMaxLag        time.Duration
+	replicaLagFunc       LagFunc
+	rywWindow            time.Duration
+
+	enableAudit         bool
+	auditSink           AuditSink
+	auditExcludeTables  []string
+	auditExcludeColumns []string
+	auditOperatorKey    string
+	auditRequestIDKey   string
+
 	plugins []gorm.Plugin
 }
 
@@ -50,10 +65,14 @@ func defaultOptions() *options {
 
 		disableForeignKey: true,  // disables the use of foreign keys, true is recommended for production environments, enabled by default
 		enableTrace:       false, // whether to enable link tracing, default is off
+		enableMetrics:     false, // whether to enable prometheus metrics, default is off
 
 		requestIDKey: "",          // request id key
 		gLog:         nil,         // custom logger
 		logLevel:     logger.Info, // default logLevel
+
+		auditOperatorKey:  "uid",        // the key of operator in context, set by middleware.Auth
+		auditRequestIDKey: "request_id", // the key of request id in context, set by middleware.RequestID
 	}
 }
 
@@ -119,6 +138,17 @@ func WithEnableTrace() Option {
 	}
 }
 
+// WithEnableMetrics use prometheus metrics of sql queries and connection pools,
+// dbName is the value of label db, default is the current database name
+func WithEnableMetrics(dbName ...string) Option {
+	return func(o *options) {
+		o.enableMetrics = true
+		if len(dbName) > 0 {
+			o.metricsDBName = dbName[0]
+		}
+	}
+}
+
 // WithLogRequestIDKey log request id
 func WithLogRequestIDKey(key string) Option {
 	return func(o *options) {
@@ -137,6 +167,72 @@ func WithRWSeparation(slavesDsn []string, mastersDsn ...string) Option {
 	}
 }
 
+// WithReplicaWeights set the weights of the slaves for weighted round-robin, in order of slaves dsn, default is 1
+func WithReplicaWeights(weights ...int) Option {
+	return func(o *options) {
+		o.replicaWeights = weights
+	}
+}
+
+// WithReplicaHealthCheck ping the slaves every interval, the unhealthy slaves are taken out of rotation,
+// if maxLag is greater than 0, the slaves whose replication lag exceeds maxLag are taken out of rotation too.
+func WithReplicaHealthCheck(interval time.Duration, maxLag time.Duration) Option {
+	return func(o *options) {
+		o.replicaCheckInterval = interval
+		o.replicaMaxLag = maxLag
+	}
+}
+
+// WithReplicaLagFunc set a custom function to get the replication lag of a slave,
+// default is SHOW SLAVE STATUS for mysql and pg_last_xact_replay_timestamp for postgres
+func WithReplicaLagFunc(fn LagFunc) Option {
+	return func(o *options) {
+		o.replicaLagFunc = fn
+	}
+}
+
+// WithReadYourWrites route the reads to the master within window after a write in the same context,
+// the context must be created by ReadYourWrites
+func WithReadYourWrites(window time.Duration) Option {
+	return func(o *options) {
+		o.rywWindow = window
+	}
+}
+
+// WithAudit record the rows changed by create, update and delete, including the primary key, the old and new
+// values of the changed columns, and the operator and request id from context, the records are written to sink,
+// default is the table audit_log in the same database.
+func WithAudit(sink ...AuditSink) Option {
+	return func(o *options) {
+		o.enableAudit = true
+		if len(sink) > 0 {
+			o.auditSink = sink[0]
+		}
+	}
+}
+
+// WithAuditExcludeTables the changes of the tables are not audited
+func WithAuditExcludeTables(tables ...string) Option {
+	return func(o *options) {
+		o.auditExcludeTables = append(o.auditExcludeTables, tables...)
+	}
+}
+
+// WithAuditExcludeColumns the columns are not audited, the format is column or table.column, e.g. password, user.token
+func WithAuditExcludeColumns(columns ...string) Option {
+	return func(o *options) {
+		o.auditExcludeColumns = append(o.auditExcludeColumns, columns...)
+	}
+}
+
+// WithAuditContextKeys set the keys of operator and request id in context, default is uid and request_id
+func WithAuditContextKeys(operatorKey string, requestIDKey string) Option {
+	return func(o *options) {
+		o.auditOperatorKey = operatorKey
+		o.auditRequestIDKey = requestIDKey
+	}
+}
+
 // WithGormPlugin setting gorm plugin
 func WithGormPlugin(plugins ...gorm.Plugin) Option {
 	return func(o *options) {
@@ -0,0 +1,287 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType type of the column values, it determines the allowed expressions and how the values are converted
+type ColumnType int
+
+const (
+	// TypeString string column, e.g. char, varchar, text, decimal, json
+	TypeString ColumnType = iota + 1
+	// TypeInt signed integer column
+	TypeInt
+	// TypeUint unsigned integer column
+	TypeUint
+	// TypeFloat floating point column
+	TypeFloat
+	// TypeBool bool column
+	TypeBool
+	// TypeTime date and time column
+	TypeTime
+)
+
+var columnTypeNames = map[ColumnType]string{
+	TypeString: "string",
+	TypeInt:    "int",
+	TypeUint:   "uint",
+	TypeFloat:  "float",
+	TypeBool:   "bool",
+	TypeTime:   "time",
+}
+
+// String name of the column type
+func (t ColumnType) String() string {
+	if name, ok := columnTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// layouts of the time values in query conditions
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// ColumnRule usage of a column in query parameters
+type ColumnRule struct {
+	Name   string     // column name
+	Type   ColumnType // column type
+	Filter bool       // whether the column is allowed in query conditions
+	Sort   bool       // whether the column is allowed in sort fields
+}
+
+// ColumnSchema allow-list of the columns of a table, column names and sort fields are concatenated into sql,
+// so only the registered columns are allowed in query parameters.
+type ColumnSchema struct {
+	rules map[string]ColumnRule
+}
+
+// NewColumnSchema create an allow-list of the columns of a table
+func NewColumnSchema(rules ...ColumnRule) *ColumnSchema {
+	s := &ColumnSchema{rules: make(map[string]ColumnRule, len(rules))}
+	for _, rule := range rules {
+		s.rules[rule.Name] = rule
+	}
+	return s
+}
+
+// CheckColumns check the sort fields, and the column names, expressions and values of the query conditions by the allow-list,
+// the values are converted to the column types, e.g. "20" of an int column is converted to int64(20).
+func (p *Params) CheckColumns(s *ColumnSchema) error {
+	if err := s.checkSort(p.Sort); err != nil {
+		return err
+	}
+	return s.checkColumns(p.Columns)
+}
+
+// CheckColumns check the column names, expressions and values of the query conditions by the allow-list,
+// the values are converted to the column types.
+func (c *Conditions) CheckColumns(s *ColumnSchema) error {
+	return s.checkColumns(c.Columns)
+}
+
+func (s *ColumnSchema) checkSort(sort string) error {
+	sort = strings.Replace(sort, " ", "", -1)
+	if sort == "" {
+		return nil
+	}
+	for _, name := range strings.Split(sort, ",") {
+		name = strings.TrimPrefix(name, "-")
+		if name == "" {
+			continue
+		}
+		rule, ok := s.rules[name]
+		if !ok || !rule.Sort {
+			return fmt.Errorf("column '%s' is not allowed in sort", name)
+		}
+	}
+	return nil
+}
+
+func (s *ColumnSchema) checkColumns(columns []Column) error {
+	for i := range columns {
+		column := &columns[i]
+		if column.isGroup() {
+			if err := s.checkColumns(column.Group); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rule, ok := s.rules[column.Name]
+		if !ok || !rule.Filter {
+			return fmt.Errorf("column '%s' is not allowed in conditions", column.Name)
+		}
+		if err := rule.checkColumn(column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check the expression and convert the value of the column
+func (r ColumnRule) checkColumn(column *Column) error {
+	exp := strings.ToLower(column.Exp)
+	if exp == "" {
+		exp = Eq
+	}
+	sqlExp, ok := expMap[exp]
+	if !ok {
+		return fmt.Errorf("unknown exp type '%s'", column.Exp)
+	}
+
+	switch sqlExp {
+	case " IS NULL", " IS NOT NULL":
+		return nil
+
+	case " LIKE ", " NOT LIKE ":
+		if r.Type != TypeString {
+			return fmt.Errorf("exp type '%s' is not allowed for column '%s' of type %s", column.Exp, r.Name, r.Type)
+		}
+
+	case " > ", " >= ", " < ", " <= ", " BETWEEN ":
+		if r.Type == TypeBool {
+			return fmt.Errorf("exp type '%s' is not allowed for column '%s' of type %s", column.Exp, r.Name, r.Type)
+		}
+	}
+
+	if sqlExp == " IN " || sqlExp == " NOT IN " || sqlExp == " BETWEEN " {
+		values, err := getValues(column.Value)
+		if err != nil {
+			return fmt.Errorf("column '%s': %v", r.Name, err)
+		}
+		newValues := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			v, err := convertValue(r.Type, value)
+			if err != nil {
+				return fmt.Errorf("column '%s': %v", r.Name, err)
+			}
+			newValues = append(newValues, v)
+		}
+		column.Value = newValues
+		return nil
+	}
+
+	v, err := convertValue(r.Type, column.Value)
+	if err != nil {
+		return fmt.Errorf("column '%s': %v", r.Name, err)
+	}
+	column.Value = v
+	return nil
+}
+
+// convert the value to the column type, the values in json request bodies are string, float64, bool or json.Number
+func convertValue(t ColumnType, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, fmt.Errorf("value cannot be nil")
+	}
+	if n, ok := value.(json.Number); ok {
+		value = string(n)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch t {
+	case TypeString:
+		switch rv.Kind() {
+		case reflect.String:
+			return rv.String(), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.Bool:
+			return fmt.Sprintf("%v", value), nil
+		}
+
+	case TypeInt:
+		switch rv.Kind() {
+		case reflect.String:
+			if v, err := strconv.ParseInt(strings.TrimSpace(rv.String()), 10, 64); err == nil {
+				return v, nil
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int(), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rv.Uint() <= math.MaxInt64 {
+				return int64(rv.Uint()), nil
+			}
+		case reflect.Float32, reflect.Float64:
+			if f := rv.Float(); f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+				return int64(f), nil
+			}
+		}
+
+	case TypeUint:
+		switch rv.Kind() {
+		case reflect.String:
+			if v, err := strconv.ParseUint(strings.TrimSpace(rv.String()), 10, 64); err == nil {
+				return v, nil
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.Int() >= 0 {
+				return uint64(rv.Int()), nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return rv.Uint(), nil
+		case reflect.Float32, reflect.Float64:
+			if f := rv.Float(); f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 {
+				return uint64(f), nil
+			}
+		}
+
+	case TypeFloat:
+		switch rv.Kind() {
+		case reflect.String:
+			if v, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64); err == nil {
+				return v, nil
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(rv.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return float64(rv.Uint()), nil
+		case reflect.Float32, reflect.Float64:
+			return rv.Float(), nil
+		}
+
+	case TypeBool:
+		switch rv.Kind() {
+		case reflect.Bool:
+			return rv.Bool(), nil
+		case reflect.String:
+			if v, err := strconv.ParseBool(strings.TrimSpace(rv.String())); err == nil {
+				return v, nil
+			}
+		case reflect.Float64:
+			if f := rv.Float(); f == 0 || f == 1 {
+				return f == 1, nil
+			}
+		}
+
+	case TypeTime:
+		if v, ok := value.(time.Time); ok {
+			return v, nil
+		}
+		if rv.Kind() == reflect.String {
+			str := strings.TrimSpace(rv.String())
+			for _, layout := range timeLayouts {
+				if v, err := time.ParseInLocation(layout, str, time.Local); err == nil {
+					return v, nil
+				}
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown column type %d", t)
+	}
+
+	return nil, fmt.Errorf("invalid value '%v', it cannot be converted to %s", value, t)
+}
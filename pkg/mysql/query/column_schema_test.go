@@ -0,0 +1,159 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testColumns = NewColumnSchema(
+	ColumnRule{Name: "id", Type: TypeUint, Filter: true, Sort: true},
+	ColumnRule{Name: "name", Type: TypeString, Filter: true, Sort: true},
+	ColumnRule{Name: "age", Type: TypeInt, Filter: true, Sort: true},
+	ColumnRule{Name: "score", Type: TypeFloat, Filter: true},
+	ColumnRule{Name: "is_admin", Type: TypeBool, Filter: true},
+	ColumnRule{Name: "created_at", Type: TypeTime, Filter: true, Sort: true},
+	ColumnRule{Name: "password", Type: TypeString},
+)
+
+func TestParams_CheckColumns(t *testing.T) {
+	body := `{"page":0,"size":10,"sort":"-age, name","columns":[
+		{"group":[{"name":"name","exp":"prefixlike","value":"Zhang","logic":"or"},{"name":"is_admin","value":"true"}]},
+		{"name":"age","exp":"between","value":"20,30"},
+		{"name":"id","exp":"in","value":[1,2]},
+		{"name":"score","exp":">=","value":"60.5"},
+		{"name":"created_at","exp":"<","value":"2023-01-02 03:04:05"},
+		{"name":"name","exp":"isnotnull"}
+	]}`
+	p := &Params{}
+	err := json.Unmarshal([]byte(body), p)
+	assert.NoError(t, err)
+
+	err = p.CheckColumns(testColumns)
+	assert.NoError(t, err)
+	assert.Equal(t, "Zhang", p.Columns[0].Group[0].Value)
+	assert.Equal(t, true, p.Columns[0].Group[1].Value)
+	assert.Equal(t, []interface{}{int64(20), int64(30)}, p.Columns[1].Value)
+	assert.Equal(t, []interface{}{uint64(1), uint64(2)}, p.Columns[2].Value)
+	assert.Equal(t, 60.5, p.Columns[3].Value)
+	assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.Local), p.Columns[4].Value)
+
+	queryStr, args, err := p.ConvertToGormConditions()
+	assert.NoError(t, err)
+	assert.Equal(t, "(name LIKE ? OR is_admin = ?) AND age BETWEEN ? AND ? AND id IN (?) AND score >= ? AND created_at < ? AND name IS NOT NULL", queryStr)
+	assert.Equal(t, 7, len(args))
+}
+
+func TestParams_CheckColumnsError(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *Params
+	}{
+		{
+			name:   "unknown sort column",
+			params: &Params{Sort: "-age,name;DROP TABLE user"},
+		},
+		{
+			name:   "column is not sortable",
+			params: &Params{Sort: "score"},
+		},
+		{
+			name:   "unknown column",
+			params: &Params{Columns: []Column{{Name: "1=1 OR name", Value: "foo"}}},
+		},
+		{
+			name:   "column is not filterable",
+			params: &Params{Columns: []Column{{Name: "password", Value: "foo"}}},
+		},
+		{
+			name:   "unknown column in group",
+			params: &Params{Columns: []Column{{Group: []Column{{Name: "foo", Value: "bar"}}}}},
+		},
+		{
+			name:   "like int column",
+			params: &Params{Columns: []Column{{Name: "age", Exp: Like, Value: "2"}}},
+		},
+		{
+			name:   "gt bool column",
+			params: &Params{Columns: []Column{{Name: "is_admin", Exp: Gt, Value: true}}},
+		},
+		{
+			name:   "unknown exp",
+			params: &Params{Columns: []Column{{Name: "age", Exp: "xxx", Value: 1}}},
+		},
+		{
+			name:   "invalid int value",
+			params: &Params{Columns: []Column{{Name: "age", Value: "20 OR 1=1"}}},
+		},
+		{
+			name:   "invalid uint value",
+			params: &Params{Columns: []Column{{Name: "id", Exp: In, Value: []interface{}{1.0, -2.0}}}},
+		},
+		{
+			name:   "invalid float value",
+			params: &Params{Columns: []Column{{Name: "score", Value: "abc"}}},
+		},
+		{
+			name:   "invalid bool value",
+			params: &Params{Columns: []Column{{Name: "is_admin", Value: "yes"}}},
+		},
+		{
+			name:   "invalid time value",
+			params: &Params{Columns: []Column{{Name: "created_at", Value: "yesterday"}}},
+		},
+		{
+			name:   "invalid string value",
+			params: &Params{Columns: []Column{{Name: "name", Value: []interface{}{"foo"}}}},
+		},
+		{
+			name:   "nil value",
+			params: &Params{Columns: []Column{{Name: "name"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.CheckColumns(testColumns)
+			assert.Error(t, err)
+			t.Log(err)
+		})
+	}
+}
+
+func TestConditions_CheckColumns(t *testing.T) {
+	c := &Conditions{Columns: []Column{{Name: "age", Value: 20.0}}}
+	err := c.CheckColumns(testColumns)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), c.Columns[0].Value)
+
+	c = &Conditions{Columns: []Column{{Name: "age", Value: 20.5}}}
+	err = c.CheckColumns(testColumns)
+	assert.Error(t, err)
+}
+
+func Test_convertValue(t *testing.T) {
+	v, err := convertValue(TypeString, 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", v)
+	v, err = convertValue(TypeInt, json.Number("-3"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-3), v)
+	v, err = convertValue(TypeUint, uint8(3))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), v)
+	v, err = convertValue(TypeFloat, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, v)
+	v, err = convertValue(TypeBool, 0.0)
+	assert.NoError(t, err)
+	assert.Equal(t, false, v)
+	now := time.Now()
+	v, err = convertValue(TypeTime, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now, v)
+
+	_, err = convertValue(ColumnType(0), "foo")
+	assert.Error(t, err)
+	assert.Equal(t, "unknown", ColumnType(0).String())
+}
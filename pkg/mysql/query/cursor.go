@@ -0,0 +1,240 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidCursor the cursor is malformed or does not match the sort fields
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cache of parsed model schemas, used to get the values of the sort columns from records
+var schemaCache = &sync.Map{}
+
+// cursor content, it is encoded as base64 json, the client treats it as an opaque string
+type cursor struct {
+	Sort   string        `json:"s"` // normalized sort of the query, a cursor is only valid for the same sort
+	Values []cursorValue `json:"v"` // values of the sort columns of the last record
+}
+
+// time values are kept apart so that they are decoded as time.Time rather than string
+type cursorValue struct {
+	Time  *time.Time  `json:"t,omitempty"`
+	Value interface{} `json:"v,omitempty"`
+}
+
+type sortColumn struct {
+	name string
+	desc bool
+}
+
+// parse the sort fields, the id column is appended if missing, so that the order of records is unique,
+// it has the same direction as the last sort column.
+func getSortColumns(columnNames string) []sortColumn {
+	columnNames = strings.Replace(columnNames, " ", "", -1)
+	if columnNames == "" {
+		return []sortColumn{{name: "id", desc: true}}
+	}
+
+	names := strings.Split(columnNames, ",")
+	columns := make([]sortColumn, 0, len(names)+1)
+	hasID := false
+	for _, name := range names {
+		if name == "" || name == "-" {
+			continue
+		}
+		column := sortColumn{name: name}
+		if name[0] == '-' {
+			column = sortColumn{name: name[1:], desc: true}
+		}
+		if column.name == "id" {
+			hasID = true
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return []sortColumn{{name: "id", desc: true}}
+	}
+	if !hasID {
+		columns = append(columns, sortColumn{name: "id", desc: columns[len(columns)-1].desc})
+	}
+
+	return columns
+}
+
+func getCursorOrder(columns []sortColumn) string {
+	strs := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column.desc {
+			strs = append(strs, column.name+" DESC")
+		} else {
+			strs = append(strs, column.name+" ASC")
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
+// ConvertToCursor converted to conform to gorm rules based on the cursor sort parameter for keyset (cursor) pagination,
+// the order always ends with the id column so that the records have a unique order,
+// queryStr and args are the conditions of the records after the cursor, they are empty if the cursor is empty (the first page).
+//
+// example: sort="-age", the cursor of the last record is (age=20, id=100)
+//
+//	order = "age DESC, id DESC"
+//	queryStr = "(age < ? OR (age = ? AND id < ?))"
+//	args = [20, 20, 100]
+func (p *Params) ConvertToCursor() (order string, queryStr string, args []interface{}, err error) {
+	columns := getSortColumns(p.Sort)
+	order = getCursorOrder(columns)
+	if p.Cursor == "" {
+		return order, "", nil, nil
+	}
+
+	values, err := decodeCursor(p.Cursor, order)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if len(values) != len(columns) {
+		return "", "", nil, ErrInvalidCursor
+	}
+
+	// (c1 > ? OR (c1 = ? AND c2 > ?) OR (c1 = ? AND c2 = ? AND c3 > ?))
+	ors := make([]string, 0, len(columns))
+	for i, column := range columns {
+		ands := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, columns[j].name+" = ?")
+			args = append(args, values[j])
+		}
+		if column.desc {
+			ands = append(ands, column.name+" < ?")
+		} else {
+			ands = append(ands, column.name+" > ?")
+		}
+		args = append(args, values[i])
+
+		if len(ands) == 1 {
+			ors = append(ors, ands[0])
+		} else {
+			ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+		}
+	}
+	queryStr = "(" + strings.Join(ors, " OR ") + ")"
+
+	return order, queryStr, args, nil
+}
+
+// NextCursor get the cursor of the next page from the records of the current page, records is a slice of model structs or pointers,
+// it returns an empty string if there are no more records, that is, the number of records is less than the page size.
+// the cursor can be used in both paging modes, e.g. get the first page by page number and the following pages by cursor.
+func (p *Params) NextCursor(records interface{}) (string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(records))
+	if rv.Kind() != reflect.Slice {
+		return "", fmt.Errorf("records must be a slice, got %T", records)
+	}
+	page := NewPage(p.Page, p.Size, p.Sort)
+	if rv.Len() == 0 || rv.Len() < page.size {
+		return "", nil
+	}
+
+	last := reflect.Indirect(rv.Index(rv.Len() - 1))
+	if last.Kind() != reflect.Struct {
+		return "", fmt.Errorf("records must be a slice of structs, got %T", records)
+	}
+	s, err := schema.Parse(last.Addr().Interface(), schemaCache, schema.NamingStrategy{SingularTable: true})
+	if err != nil {
+		return "", err
+	}
+
+	columns := getSortColumns(p.Sort)
+	c := cursor{
+		Sort:   getCursorOrder(columns),
+		Values: make([]cursorValue, 0, len(columns)),
+	}
+	for _, column := range columns {
+		field := s.LookUpField(column.name)
+		if field == nil {
+			return "", fmt.Errorf("unknown sort column '%s'", column.name)
+		}
+		value, _ := field.ValueOf(context.Background(), last)
+		c.Values = append(c.Values, newCursorValue(value))
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func newCursorValue(value interface{}) cursorValue {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err == nil {
+			value = v
+		}
+	}
+	switch v := value.(type) {
+	case time.Time:
+		return cursorValue{Time: &v}
+	case *time.Time:
+		return cursorValue{Time: v}
+	}
+	return cursorValue{Value: value}
+}
+
+func decodeCursor(str string, order string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	c := cursor{}
+	if err = decoder.Decode(&c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != order {
+		return nil, fmt.Errorf("%w, it does not match the sort '%s'", ErrInvalidCursor, order)
+	}
+
+	values := make([]interface{}, 0, len(c.Values))
+	for _, v := range c.Values {
+		if v.Time != nil {
+			values = append(values, *v.Time)
+			continue
+		}
+		values = append(values, convertNumber(v.Value))
+	}
+	return values, nil
+}
+
+// keep the precision of large integers such as uint64 ids
+func convertNumber(value interface{}) interface{} {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return string(n)
+}
@@ -0,0 +1,94 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cursorUser struct {
+	ID        uint64 `gorm:"column:id;primary_key"`
+	Name      string
+	Age       int
+	CreatedAt time.Time
+}
+
+func Test_getSortColumns(t *testing.T) {
+	assert.Equal(t, "id DESC", getCursorOrder(getSortColumns("")))
+	assert.Equal(t, "age ASC, id ASC", getCursorOrder(getSortColumns("age")))
+	assert.Equal(t, "age ASC, name DESC, id DESC", getCursorOrder(getSortColumns("age, -name")))
+	assert.Equal(t, "id ASC, name DESC", getCursorOrder(getSortColumns("id,-name")))
+	assert.Equal(t, "id DESC", getCursorOrder(getSortColumns(",-")))
+}
+
+func TestParams_ConvertToCursor(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 6000, time.UTC)
+	records := []*cursorUser{
+		{ID: 1, Name: "foo", Age: 21, CreatedAt: now},
+		{ID: 18446744073709551615, Name: "bar", Age: 20, CreatedAt: now},
+	}
+
+	// first page
+	p := &Params{Size: 2, Sort: "-age"}
+	order, queryStr, args, err := p.ConvertToCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, "age DESC, id DESC", order)
+	assert.Equal(t, "", queryStr)
+	assert.Nil(t, args)
+
+	p.Cursor, err = p.NextCursor(records)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, p.Cursor)
+	order, queryStr, args, err = p.ConvertToCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, "age DESC, id DESC", order)
+	assert.Equal(t, "(age < ? OR (age = ? AND id < ?))", queryStr)
+	assert.Equal(t, []interface{}{int64(20), int64(20), uint64(18446744073709551615)}, args)
+
+	// time column
+	p = &Params{Size: 2, Sort: "created_at"}
+	p.Cursor, err = p.NextCursor(records)
+	assert.NoError(t, err)
+	_, queryStr, args, err = p.ConvertToCursor()
+	assert.NoError(t, err)
+	assert.Equal(t, "(created_at > ? OR (created_at = ? AND id > ?))", queryStr)
+	assert.Equal(t, []interface{}{now, now, uint64(18446744073709551615)}, args)
+
+	// the cursor does not match the sort
+	p.Sort = "-created_at"
+	_, _, _, err = p.ConvertToCursor()
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// malformed cursor
+	p.Cursor = "foo"
+	_, _, _, err = p.ConvertToCursor()
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestParams_NextCursor(t *testing.T) {
+	records := []cursorUser{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}
+
+	// no more records
+	p := &Params{Size: 3}
+	c, err := p.NextCursor(records)
+	assert.NoError(t, err)
+	assert.Equal(t, "", c)
+	c, err = p.NextCursor([]cursorUser{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", c)
+
+	p.Size = 2
+	c, err = p.NextCursor(&records)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, c)
+
+	// error
+	p.Sort = "unknown"
+	_, err = p.NextCursor(records)
+	assert.Error(t, err)
+	_, err = p.NextCursor(records[0])
+	assert.Error(t, err)
+	_, err = p.NextCursor([]int{1, 2})
+	assert.Error(t, err)
+}
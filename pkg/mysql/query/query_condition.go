@@ -2,7 +2,11 @@
 package query
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -23,6 +27,20 @@ const (
 	Like = "like"
 	// In include
 	In = "in"
+	// NotIn not include
+	NotIn = "notin"
+	// Between in the range of two values, including the two values
+	Between = "between"
+	// IsNull the value is null, no value is required
+	IsNull = "isnull"
+	// IsNotNull the value is not null, no value is required
+	IsNotNull = "isnotnull"
+	// NotLike fuzzy lookup of values that do not match
+	NotLike = "notlike"
+	// PrefixLike lookup of values that start with the value
+	PrefixLike = "prefixlike"
+	// SuffixLike lookup of values that end with the value
+	SuffixLike = "suffixlike"
 
 	// AND logic and
 	AND string = "and"
@@ -31,21 +49,32 @@ const (
 )
 
 var expMap = map[string]string{
-	Eq:   " = ",
-	Neq:  " <> ",
-	Gt:   " > ",
-	Gte:  " >= ",
-	Lt:   " < ",
-	Lte:  " <= ",
-	Like: " LIKE ",
-	In:   " IN ",
-
-	"=":  " = ",
-	"!=": " <> ",
-	">":  " > ",
-	">=": " >= ",
-	"<":  " < ",
-	"<=": " <= ",
+	Eq:         " = ",
+	Neq:        " <> ",
+	Gt:         " > ",
+	Gte:        " >= ",
+	Lt:         " < ",
+	Lte:        " <= ",
+	Like:       " LIKE ",
+	In:         " IN ",
+	NotIn:      " NOT IN ",
+	Between:    " BETWEEN ",
+	IsNull:     " IS NULL",
+	IsNotNull:  " IS NOT NULL",
+	NotLike:    " NOT LIKE ",
+	PrefixLike: " LIKE ",
+	SuffixLike: " LIKE ",
+
+	"=":           " = ",
+	"!=":          " <> ",
+	">":           " > ",
+	">=":          " >= ",
+	"<":           " < ",
+	"<=":          " <= ",
+	"not in":      " NOT IN ",
+	"is null":     " IS NULL",
+	"is not null": " IS NOT NULL",
+	"not like":    " NOT LIKE ",
 }
 
 var logicMap = map[string]string{
@@ -65,23 +94,43 @@ type Params struct {
 	Page int    `json:"page" form:"page" binding:"gte=0"`
 	Size int    `json:"size" form:"size" binding:"gt=0"`
 	Sort string `json:"sort,omitempty" form:"sort" binding:""`
+	// cursor of keyset pagination, it is the nextCursor returned by the previous page, page is ignored if it is not empty
+	Cursor string `json:"cursor,omitempty" form:"cursor" binding:""`
 
 	Columns []Column `json:"columns,omitempty" form:"columns"` // not required
 }
 
-// Column query info
+// Column query info, if Group is not empty, the column is a group of sub-conditions enclosed in parentheses,
+// and Name, Exp and Value are ignored, example: (a = 1 OR b = 2) AND c > 3
+//
+//	[]Column{
+//		{Group: []Column{{Name: "a", Value: 1, Logic: "or"}, {Name: "b", Value: 2}}},
+//		{Name: "c", Exp: "gt", Value: 3},
+//	}
 type Column struct {
 	Name  string      `json:"name" form:"columns"`  // column name
-	Exp   string      `json:"exp" form:"columns"`   // expressions, which default to = when the value is null, have =, !=, >, >=, <, <=, like, in
-	Value interface{} `json:"value" form:"columns"` // column value
+	Exp   string      `json:"exp" form:"columns"`   // expressions, which default to = when the value is null, have =, !=, >, >=, <, <=, like, in, notin, between, isnull, isnotnull, notlike, prefixlike, suffixlike
+	Value interface{} `json:"value" form:"columns"` // column value, multiple values of in, notin and between are an array or separated by commas
 	Logic string      `json:"logic" form:"columns"` // logical type, defaults to and when the value is null, with &(and), ||(or)
+
+	Group []Column `json:"group,omitempty" form:"columns"` // sub-conditions, not required
+}
+
+func (c *Column) isGroup() bool {
+	return len(c.Group) > 0
 }
 
 func (c *Column) checkValid() error {
+	if c.isGroup() {
+		return nil
+	}
 	if c.Name == "" {
 		return fmt.Errorf("field 'name' cannot be empty")
 	}
 	if c.Value == nil {
+		if exp := expMap[strings.ToLower(c.Exp)]; exp == " IS NULL" || exp == " IS NOT NULL" {
+			return nil
+		}
 		return fmt.Errorf("field 'value' cannot be nil")
 	}
 	return nil
@@ -89,28 +138,46 @@ func (c *Column) checkValid() error {
 
 // converting ExpType to sql expressions and LogicType to sql using characters
 func (c *Column) convert() error {
-	if c.Exp == "" {
-		c.Exp = Eq
-	}
-	if v, ok := expMap[strings.ToLower(c.Exp)]; ok { //nolint
+	if !c.isGroup() {
+		if c.Exp == "" {
+			c.Exp = Eq
+		}
+		exp := strings.ToLower(c.Exp)
+		v, ok := expMap[exp]
+		if !ok {
+			return fmt.Errorf("unknown exp type '%s'", c.Exp)
+		}
 		c.Exp = v
-		if c.Exp == " LIKE " {
-			c.Value = fmt.Sprintf("%%%v%%", c.Value)
+
+		switch exp {
+		case PrefixLike:
+			c.Value = fmt.Sprintf("%v%%", c.Value)
+		case SuffixLike:
+			c.Value = fmt.Sprintf("%%%v", c.Value)
 		}
-		if c.Exp == " IN " {
-			val, ok := c.Value.(string)
-			if !ok {
-				return fmt.Errorf("invalid value type '%s'", c.Value)
+		switch c.Exp {
+		case " LIKE ", " NOT LIKE ":
+			if exp != PrefixLike && exp != SuffixLike {
+				c.Value = fmt.Sprintf("%%%v%%", c.Value)
 			}
-			iVal := []interface{}{}
-			ss := strings.Split(val, ",")
-			for _, s := range ss {
-				iVal = append(iVal, s)
+		case " IN ", " NOT IN ":
+			values, err := getValues(c.Value)
+			if err != nil {
+				return err
 			}
-			c.Value = iVal
+			c.Value = values
+		case " BETWEEN ":
+			values, err := getValues(c.Value)
+			if err != nil {
+				return err
+			}
+			if len(values) != 2 {
+				return fmt.Errorf("exp type 'between' requires 2 values, got %d", len(values))
+			}
+			c.Value = values
+		case " IS NULL", " IS NOT NULL":
+			c.Value = nil
 		}
-	} else {
-		return fmt.Errorf("unknown exp type '%s'", c.Exp)
 	}
 
 	if c.Logic == "" {
@@ -125,6 +192,39 @@ func (c *Column) convert() error {
 	return nil
 }
 
+// get multiple values from an array or a string separated by commas
+func getValues(value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		iVal := []interface{}{}
+		ss := strings.Split(v, ",")
+		for _, s := range ss {
+			iVal = append(iVal, s)
+		}
+		return iVal, nil
+	case []interface{}:
+		if len(v) == 0 {
+			return nil, fmt.Errorf("field 'value' cannot be empty")
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("invalid value type '%s'", value)
+}
+
+// sql expression of the column
+func (c *Column) expression() (string, []interface{}) {
+	switch c.Exp {
+	case " IN ", " NOT IN ":
+		return c.Name + c.Exp + "(?)", []interface{}{c.Value}
+	case " BETWEEN ":
+		values := c.Value.([]interface{})
+		return c.Name + " BETWEEN ? AND ?", []interface{}{values[0], values[1]}
+	case " IS NULL", " IS NOT NULL":
+		return c.Name + c.Exp, nil
+	}
+	return c.Name + c.Exp + "?", []interface{}{c.Value}
+}
+
 // ConvertToPage converted to conform to gorm rules based on the page size sort parameter
 func (p *Params) ConvertToPage() (order string, limit int, offset int) { //nolint
 	page := NewPage(p.Page, p.Size, p.Sort)
@@ -135,22 +235,42 @@ func (p *Params) ConvertToPage() (order string, limit int, offset int) { //nolin
 }
 
 // ConvertToGormConditions conversion to gorm-compliant parameters based on the Columns parameter
-// ignore the logical type of the last column, whether it is a one-column or multi-column query
+// ignore the logical type of the last column, whether it is a one-column or multi-column query,
+// the same applies to the columns in a group.
 func (p *Params) ConvertToGormConditions() (string, []interface{}, error) {
-	str := ""
-	args := []interface{}{}
 	l := len(p.Columns)
 	if l == 0 {
 		return "", nil, nil
 	}
 
-	isUseIN := true
-	if l == 1 {
-		isUseIN = false
+	str, args, err := convertColumns(p.Columns)
+	if err != nil {
+		return "", nil, err
 	}
+
+	// when multiple columns are the same, determine whether the use of IN
+	isUseIN := l > 1
 	field := p.Columns[0].Name
+	for _, column := range p.Columns {
+		if column.isGroup() || field != column.Name || !(column.Exp == "" || strings.ToLower(column.Exp) == Eq || column.Exp == "=") {
+			isUseIN = false
+			break
+		}
+	}
+	if isUseIN {
+		str = field + " IN (?)"
+		args = []interface{}{args}
+	}
+
+	return str, args, nil
+}
+
+func convertColumns(columns []Column) (string, []interface{}, error) {
+	str := ""
+	args := []interface{}{}
+	l := len(columns)
 
-	for i, column := range p.Columns {
+	for i, column := range columns {
 		if err := column.checkValid(); err != nil {
 			return "", nil, err
 		}
@@ -160,35 +280,120 @@ func (p *Params) ConvertToGormConditions() (string, []interface{}, error) {
 			return "", nil, err
 		}
 
-		symbol := "?"
-		if column.Exp == " IN " {
-			symbol = "(?)"
+		var expr string
+		var values []interface{}
+		if column.isGroup() {
+			expr, values, err = convertColumns(column.Group)
+			if err != nil {
+				return "", nil, err
+			}
+			expr = "(" + expr + ")"
+		} else {
+			expr, values = column.expression()
 		}
+
 		if i == l-1 { // ignore the logical type of the last column
-			str += column.Name + column.Exp + symbol
+			str += expr
 		} else {
-			str += column.Name + column.Exp + symbol + column.Logic
+			str += expr + column.Logic
 		}
-		args = append(args, column.Value)
+		args = append(args, values...)
+	}
+
+	return str, args, nil
+}
 
-		// when multiple columns are the same, determine whether the use of IN
-		if isUseIN {
-			if field != column.Name {
-				isUseIN = false
-				continue
+// Hash a normalized hash of the parameters, parameters that result in the same query have the same hash,
+// e.g. the default values of exp and logic, case of exp and logic, spaces in sort, it is often used as cache key.
+// The normalized parameters are encoded in json, so a value containing the separators can't be confused with
+// the other columns.
+func (p *Params) Hash() string {
+	page := NewPage(p.Page, p.Size, p.Sort)
+	data, _ := json.Marshal(hashParams{
+		Page:    page.page,
+		Size:    page.size,
+		Sort:    page.sort,
+		Cursor:  p.Cursor,
+		Columns: normalizeColumns(p.Columns),
+	})
+
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashParams the normalized parameters used in the hash
+type hashParams struct {
+	Page    int          `json:"page"`
+	Size    int          `json:"size"`
+	Sort    string       `json:"sort"`
+	Cursor  string       `json:"cursor"`
+	Columns []hashColumn `json:"columns"`
+}
+
+type hashColumn struct {
+	Name  string       `json:"name,omitempty"`
+	Exp   string       `json:"exp,omitempty"`
+	Value interface{}  `json:"value,omitempty"`
+	Logic string       `json:"logic,omitempty"`
+	Group []hashColumn `json:"group,omitempty"`
+}
+
+func normalizeColumns(columns []Column) []hashColumn {
+	l := len(columns)
+	hashColumns := make([]hashColumn, 0, l)
+	for i, column := range columns {
+		logic := ""
+		if i < l-1 { // the logical type of the last column is ignored
+			logic = column.Logic
+			if logic == "" {
+				logic = AND
 			}
-			if column.Exp != expMap[Eq] {
-				isUseIN = false
+			if v, ok := logicMap[strings.ToLower(logic)]; ok {
+				logic = v
 			}
 		}
-	}
 
-	if isUseIN {
-		str = field + " IN (?)"
-		args = []interface{}{args}
+		if column.isGroup() {
+			hashColumns = append(hashColumns, hashColumn{Logic: logic, Group: normalizeColumns(column.Group)})
+			continue
+		}
+
+		exp := column.Exp
+		if exp == "" {
+			exp = Eq
+		}
+		if v, ok := expMap[strings.ToLower(exp)]; ok {
+			// prefix and suffix like have the same sql expression as like
+			if strings.ToLower(exp) == PrefixLike || strings.ToLower(exp) == SuffixLike {
+				v = strings.ToLower(exp)
+			}
+			exp = v
+		}
+		hashColumns = append(hashColumns, hashColumn{
+			Name:  strings.TrimSpace(column.Name),
+			Exp:   exp,
+			Value: normalizeValue(column.Value),
+			Logic: logic,
+		})
 	}
+	return hashColumns
+}
 
-	return str, args, nil
+// the scalar value is formatted as a string, e.g. 20 and "20" are the same value in the sql,
+// the elements of the slice value are kept as a json array, so that ["a b"] is not the same as ["a", "b"]
+func normalizeValue(value interface{}) interface{} {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprintf("%v", value)
+	}
+	values := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values = append(values, normalizeValue(v.Index(i).Interface()))
+	}
+	return values
 }
 
 func getExpsAndLogics(keyLen int, paramSrc string) ([]string, []string) { //nolint
@@ -250,18 +455,26 @@ func (c *Conditions) CheckValid() error {
 		return fmt.Errorf("field 'columns' cannot be empty")
 	}
 
-	for _, column := range c.Columns {
+	return checkColumns(c.Columns)
+}
+
+func checkColumns(columns []Column) error {
+	for _, column := range columns {
 		err := column.checkValid()
 		if err != nil {
 			return err
 		}
-		if column.Exp != "" {
-			if _, ok := expMap[column.Exp]; !ok {
+		if column.isGroup() {
+			if err = checkColumns(column.Group); err != nil {
+				return err
+			}
+		} else if column.Exp != "" {
+			if _, ok := expMap[strings.ToLower(column.Exp)]; !ok {
 				return fmt.Errorf("unknown exp type '%s'", column.Exp)
 			}
 		}
 		if column.Logic != "" {
-			if _, ok := logicMap[column.Logic]; !ok {
+			if _, ok := logicMap[strings.ToLower(column.Logic)]; !ok {
 				return fmt.Errorf("unknown logic type '%s'", column.Logic)
 			}
 		}
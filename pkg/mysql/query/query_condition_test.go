@@ -1,6 +1,7 @@
 package query
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"strings"
@@ -395,7 +396,154 @@ func TestParams_ConvertToGormConditions(t *testing.T) {
 			wantErr: false,
 		},
 
+		// ------------------------------- operators ------------------------------------------
+		{
+			name: "not in",
+			args: args{
+				columns: []Column{
+					{Name: "name", Exp: "not in", Value: "ZhangSan,LiSi"},
+				},
+			},
+			want:    "name NOT IN (?)",
+			want1:   []interface{}{[]interface{}{"ZhangSan", "LiSi"}},
+			wantErr: false,
+		},
+		{
+			name: "in array",
+			args: args{
+				columns: []Column{
+					{Name: "age", Exp: In, Value: []interface{}{20.0, 21.0}},
+				},
+			},
+			want:    "age IN (?)",
+			want1:   []interface{}{[]interface{}{20.0, 21.0}},
+			wantErr: false,
+		},
+		{
+			name: "between",
+			args: args{
+				columns: []Column{
+					{Name: "age", Exp: Between, Value: "20,30"},
+					{Name: "created_at", Exp: "BETWEEN", Value: []interface{}{"2023-01-01", "2023-02-01"}},
+				},
+			},
+			want:    "age BETWEEN ? AND ? AND created_at BETWEEN ? AND ?",
+			want1:   []interface{}{"20", "30", "2023-01-01", "2023-02-01"},
+			wantErr: false,
+		},
+		{
+			name: "is null and is not null",
+			args: args{
+				columns: []Column{
+					{Name: "deleted_at", Exp: IsNull},
+					{Name: "email", Exp: "is not null", Value: ""},
+				},
+			},
+			want:    "deleted_at IS NULL AND email IS NOT NULL",
+			want1:   []interface{}{},
+			wantErr: false,
+		},
+		{
+			name: "not like, prefix like and suffix like",
+			args: args{
+				columns: []Column{
+					{Name: "name", Exp: NotLike, Value: "Li"},
+					{Name: "name", Exp: PrefixLike, Value: "Zhang"},
+					{Name: "email", Exp: SuffixLike, Value: "@example.com"},
+				},
+			},
+			want:    "name NOT LIKE ? AND name LIKE ? AND email LIKE ?",
+			want1:   []interface{}{"%Li%", "Zhang%", "%@example.com"},
+			wantErr: false,
+		},
+
+		// -------------------------------- groups ------------------------------------------
+		{
+			name: "group and column",
+			args: args{
+				columns: []Column{
+					{
+						Group: []Column{
+							{Name: "a", Value: 1, Logic: "or"},
+							{Name: "b", Value: 2},
+						},
+					},
+					{Name: "c", Exp: Gt, Value: 3},
+				},
+			},
+			want:    "(a = ? OR b = ?) AND c > ?",
+			want1:   []interface{}{1, 2, 3},
+			wantErr: false,
+		},
+		{
+			name: "nested groups",
+			args: args{
+				columns: []Column{
+					{Name: "c", Exp: Gt, Value: 3, Logic: "||"},
+					{
+						Group: []Column{
+							{Name: "a", Value: 1},
+							{
+								Group: []Column{
+									{Name: "b", Exp: IsNull, Logic: "or"},
+									{Name: "b", Exp: Lt, Value: 0},
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    "c > ? OR (a = ? AND (b IS NULL OR b < ?))",
+			want1:   []interface{}{3, 1, 0},
+			wantErr: false,
+		},
+		{
+			name: "same columns in group",
+			args: args{
+				columns: []Column{
+					{Group: []Column{{Name: "a", Value: 1, Logic: "or"}, {Name: "a", Value: 2}}},
+					{Group: []Column{{Name: "a", Value: 3}}},
+				},
+			},
+			want:    "(a = ? OR a = ?) AND (a = ?)",
+			want1:   []interface{}{1, 2, 3},
+			wantErr: false,
+		},
+
 		// ---------------------------- error ----------------------------------------------
+		{
+			name: "between value err",
+			args: args{
+				columns: []Column{
+					{Name: "age", Exp: Between, Value: "20"},
+				},
+			},
+			want:    "",
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "not in value type err",
+			args: args{
+				columns: []Column{
+					{Name: "age", Exp: NotIn, Value: 20},
+				},
+			},
+			want:    "",
+			want1:   nil,
+			wantErr: true,
+		},
+		{
+			name: "group err",
+			args: args{
+				columns: []Column{
+					{Group: []Column{{Name: "a", Exp: "xxxxxx", Value: 1}}},
+				},
+			},
+			want:    "",
+			want1:   nil,
+			wantErr: true,
+		},
 		{
 			name: "exp type err",
 			args: args{
@@ -459,6 +607,67 @@ func TestParams_ConvertToGormConditions(t *testing.T) {
 	}
 }
 
+func TestParams_Hash(t *testing.T) {
+	p1 := &Params{
+		Page: 0,
+		Size: 20,
+		Sort: "-id, name",
+		Columns: []Column{
+			{Name: "age", Exp: ">", Value: 20, Logic: "||"},
+			{Name: "gender", Value: "male", Logic: "and"},
+		},
+	}
+	p2 := &Params{
+		Page: 0,
+		Size: 20,
+		Sort: "-id,name",
+		Columns: []Column{
+			{Name: "age", Exp: "gt", Value: "20", Logic: "OR"},
+			{Name: "gender", Exp: "eq", Value: "male"},
+		},
+	}
+	assert.Equal(t, p1.Hash(), p2.Hash())
+	assert.Equal(t, 32, len(p1.Hash()))
+
+	p2.Page = 1
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+	p2.Page = 0
+	p2.Columns[0].Logic = "and"
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+	p2.Columns[0].Logic = "or"
+	p2.Columns[1].Value = "female"
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+
+	// groups
+	p1.Columns = []Column{{Group: []Column{{Name: "a", Value: 1, Logic: "or"}, {Name: "b", Value: 2}}}, {Name: "c", Value: 3}}
+	p2.Columns = []Column{{Group: []Column{{Name: "a", Value: 1, Logic: "||"}, {Name: "b", Value: 2}}}, {Name: "c", Value: 3}}
+	assert.Equal(t, p1.Hash(), p2.Hash())
+	p2.Columns = []Column{{Group: []Column{{Name: "a", Value: 1, Logic: "or"}}}, {Name: "b", Value: 2}, {Name: "c", Value: 3}}
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+	p1.Columns = []Column{{Name: "a", Exp: Like, Value: "foo"}}
+	p2.Columns = []Column{{Name: "a", Exp: PrefixLike, Value: "foo"}}
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+
+	// the value containing the separators is not the same as the other columns
+	p1.Columns = []Column{{Name: "a", Value: "x AND &b = y"}}
+	p2.Columns = []Column{{Name: "a", Value: "x", Logic: "and"}, {Name: "b", Value: "y"}}
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+	p1.Columns = []Column{{Name: "a", Value: "x\" and &b\" = \"y"}}
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+	p1.Columns = []Column{{Name: "a", Exp: In, Value: []string{"a b"}}}
+	p2.Columns = []Column{{Name: "a", Exp: In, Value: []string{"a", "b"}}}
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+	p1.Columns = []Column{{Name: "a", Exp: In, Value: "[a b]"}}
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+	p1.Columns = []Column{{Name: "a", Exp: In, Value: []interface{}{"a", "b"}}}
+	assert.Equal(t, p1.Hash(), p2.Hash())
+	p1.Columns = nil
+	p1.Sort, p1.Cursor = "id&cursor=abc", ""
+	p2.Columns = nil
+	p2.Sort, p2.Cursor = "id", "abc"
+	assert.NotEqual(t, p1.Hash(), p2.Hash())
+}
+
 func Test_getExpsAndLogics(t *testing.T) {
 	type args struct {
 		keyLen   int
@@ -636,3 +845,27 @@ func TestConditions_checkValid(t *testing.T) {
 	err = c.CheckValid()
 	assert.NoError(t, err)
 }
+
+func TestConditions_group(t *testing.T) {
+	body := `{"columns":[
+		{"group":[{"name":"name","exp":"prefixlike","value":"Zhang","logic":"or"},{"name":"email","exp":"isnull"}]},
+		{"name":"age","exp":"between","value":[20,30]}
+	]}`
+	c := Conditions{}
+	err := json.Unmarshal([]byte(body), &c)
+	assert.NoError(t, err)
+	err = c.CheckValid()
+	assert.NoError(t, err)
+	str, values, err := c.ConvertToGorm()
+	assert.NoError(t, err)
+	assert.Equal(t, "(name LIKE ? OR email IS NULL) AND age BETWEEN ? AND ?", str)
+	assert.Equal(t, []interface{}{"Zhang%", 20.0, 30.0}, values)
+
+	// error in group
+	c.Columns[0].Group[1].Exp = "unknown-exp"
+	err = c.CheckValid()
+	assert.Error(t, err)
+	c.Columns[0].Group[1] = Column{Name: "email"}
+	err = c.CheckValid()
+	assert.Error(t, err)
+}
@@ -0,0 +1,320 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// LagFunc get the replication lag of a replica
+type LagFunc func(ctx context.Context, db *sql.DB) (time.Duration, error)
+
+type replica struct {
+	index         int // index of slaves dsn
+	db            *sql.DB
+	weight        int
+	currentWeight int
+	healthy       int32
+}
+
+func (r *replica) isHealthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1
+}
+
+// replicaPool route reads to the healthy replicas by weighted round-robin, it is the policy of dbresolver,
+// the unhealthy or lagging replicas are taken out of rotation by health checks, if no replica is healthy,
+// reads are routed to the master.
+type replicaPool struct {
+	mu       sync.Mutex
+	replicas []*replica
+	byPool   map[gorm.ConnPool]*replica
+
+	weights       []int
+	checkInterval time.Duration
+	maxLag        time.Duration
+	lagFunc       LagFunc
+	rywWindow     time.Duration
+	log           *zap.Logger
+}
+
+func newReplicaPool(o *options) *replicaPool {
+	return &replicaPool{
+		byPool:        map[gorm.ConnPool]*replica{},
+		weights:       o.replicaWeights,
+		checkInterval: o.replicaCheckInterval,
+		maxLag:        o.replicaMaxLag,
+		lagFunc:       o.replicaLagFunc,
+		rywWindow:     o.rywWindow,
+		log:           o.gLog,
+	}
+}
+
+// Resolve implements dbresolver.Policy, smooth weighted round-robin among the healthy replicas
+func (p *replicaPool) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var (
+		best  *replica
+		total int
+	)
+	for _, pool := range connPools {
+		r, ok := p.byPool[pool]
+		if !ok || !r.isHealthy() {
+			continue
+		}
+		r.currentWeight += r.weight
+		total += r.weight
+		if best == nil || r.currentWeight > best.currentWeight {
+			best = r
+		}
+	}
+	if best == nil {
+		// the sources, or no healthy replica, the statement is routed to the master by the callback
+		return connPools[rand.Intn(len(connPools))] //nolint
+	}
+	best.currentWeight -= total
+
+	return best.db
+}
+
+// Name plugin name
+func (p *replicaPool) Name() string {
+	return "sponge:replica_pool"
+}
+
+// Initialize register callbacks to route reads to the master, and record writes for reading your own writes
+func (p *replicaPool) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	err := cb.Query().After("gorm:db_resolver").Before("gorm:query").Register("sponge:replica_route", p.route)
+	if err != nil {
+		return err
+	}
+	err = cb.Row().After("gorm:db_resolver").Before("gorm:row").Register("sponge:replica_route", p.route)
+	if err != nil {
+		return err
+	}
+	err = cb.Raw().After("gorm:db_resolver").Before("gorm:raw").Register("sponge:replica_route", p.route)
+	if err != nil {
+		return err
+	}
+
+	if p.rywWindow <= 0 {
+		return nil
+	}
+	err = cb.Create().After("gorm:create").Register("sponge:record_write", p.recordWrite)
+	if err != nil {
+		return err
+	}
+	err = cb.Update().After("gorm:update").Register("sponge:record_write", p.recordWrite)
+	if err != nil {
+		return err
+	}
+	err = cb.Delete().After("gorm:delete").Register("sponge:record_write", p.recordWrite)
+	if err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register("sponge:record_write", p.recordWrite)
+}
+
+// add the replica connection pools after dbresolver is registered, they are in order of slaves dsn
+func (p *replicaPool) add(index int, db *sql.DB) {
+	weight := 1
+	if index < len(p.weights) && p.weights[index] > 0 {
+		weight = p.weights[index]
+	}
+	r := &replica{index: index, db: db, weight: weight, healthy: 1}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.replicas = append(p.replicas, r)
+	p.byPool[db] = r
+}
+
+func (p *replicaPool) getReplica(connPool gorm.ConnPool) (*replica, bool) {
+	if stmtDB, ok := connPool.(*gorm.PreparedStmtDB); ok {
+		connPool = stmtDB.ConnPool
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.byPool[connPool]
+	return r, ok
+}
+
+// route the read to the master if the replica is unhealthy or the context has written recently
+func (p *replicaPool) route(db *gorm.DB) {
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return
+	}
+	r, ok := p.getReplica(db.Statement.ConnPool)
+	if !ok {
+		return
+	}
+	if !r.isHealthy() || p.isPinned(db.Statement.Context) {
+		dbresolver.Write.ModifyStatement(db.Statement)
+	}
+}
+
+func (p *replicaPool) recordWrite(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Context == nil {
+		return
+	}
+	state, ok := db.Statement.Context.Value(rywCtxKey{}).(*rywState)
+	if !ok {
+		return
+	}
+	if rawSQL := strings.TrimSpace(db.Statement.SQL.String()); len(rawSQL) >= 6 && strings.EqualFold(rawSQL[:6], "select") {
+		return
+	}
+	atomic.StoreInt64(&state.lastWrite, time.Now().UnixNano())
+}
+
+func (p *replicaPool) isPinned(ctx context.Context) bool {
+	if p.rywWindow <= 0 || ctx == nil {
+		return false
+	}
+	state, ok := ctx.Value(rywCtxKey{}).(*rywState)
+	if !ok {
+		return false
+	}
+	lastWrite := atomic.LoadInt64(&state.lastWrite)
+	return lastWrite > 0 && time.Since(time.Unix(0, lastWrite)) < p.rywWindow
+}
+
+// check the replicas periodically until the master is closed, then the replicas are closed too
+func (p *replicaPool) runHealthCheck(master *sql.DB, dialector string) {
+	if p.checkInterval <= 0 || len(p.replicas) == 0 {
+		return
+	}
+	if p.lagFunc == nil && p.maxLag > 0 {
+		p.lagFunc = defaultLagFunc(dialector)
+	}
+
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := master.Ping(); err != nil && strings.Contains(err.Error(), "database is closed") {
+			for _, r := range p.replicas {
+				_ = r.db.Close()
+			}
+			return
+		}
+		for _, r := range p.replicas {
+			p.check(r)
+		}
+	}
+}
+
+func (p *replicaPool) check(r *replica) {
+	timeout := 3 * time.Second
+	if p.checkInterval < timeout {
+		timeout = p.checkInterval
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := r.db.PingContext(ctx)
+	if err == nil && p.maxLag > 0 && p.lagFunc != nil {
+		var lag time.Duration
+		lag, err = p.lagFunc(ctx, r.db)
+		if err == nil && lag > p.maxLag {
+			err = fmt.Errorf("replication lag %s exceeds %s", lag, p.maxLag)
+		}
+	}
+
+	healthy := int32(1)
+	if err != nil {
+		healthy = 0
+	}
+	if atomic.SwapInt32(&r.healthy, healthy) != healthy && p.log != nil {
+		if healthy == 1 {
+			p.log.Info("replica is back in rotation", zap.Int("index", r.index))
+		} else {
+			p.log.Warn("replica is taken out of rotation", zap.Int("index", r.index), zap.Error(err))
+		}
+	}
+}
+
+// default replication lag query of mysql and postgres, the lag of other databases is 0
+func defaultLagFunc(dialector string) LagFunc {
+	switch dialector {
+	case "mysql":
+		return mysqlLag
+	case "postgres":
+		return postgresLag
+	}
+	return func(ctx context.Context, db *sql.DB) (time.Duration, error) {
+		return 0, nil
+	}
+}
+
+func mysqlLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	rows, err := db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close() //nolint
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		return 0, rows.Err() // not a replica
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Master" {
+			continue
+		}
+		if !values[i].Valid {
+			return 0, fmt.Errorf("replication is not running")
+		}
+		var seconds int64
+		_, err = fmt.Sscan(values[i].String, &seconds)
+		return time.Duration(seconds) * time.Second, err
+	}
+	return 0, nil
+}
+
+func postgresLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	var seconds float64
+	err := db.QueryRowContext(ctx, `SELECT CASE WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`).Scan(&seconds)
+	return time.Duration(seconds * float64(time.Second)), err
+}
+
+// ------------------------------------------------------------------------------------------
+
+type rywCtxKey struct{}
+
+type rywState struct {
+	lastWrite int64 // unix nano
+}
+
+// ReadYourWrites return a context for reading your own writes, after a write with the context,
+// the reads with it are routed to the master within the window set by WithReadYourWrites.
+// it is usually called at the beginning of a request, e.g. in a middleware.
+func ReadYourWrites(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(rywCtxKey{}).(*rywState); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, rywCtxKey{}, &rywState{})
+}
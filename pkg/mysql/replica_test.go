@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// every database has a record whose name is the database name
+func newReplicaTestDB(t *testing.T, opts ...Option) (*gorm.DB, *replicaPool) {
+	dir := t.TempDir()
+	names := []string{"master", "replica1", "replica2"}
+	dsns := []string{}
+	for _, name := range names {
+		dsn := filepath.Join(dir, name+".db")
+		db, err := gorm.Open(sqliteDialector(dsn), &gorm.Config{NamingStrategy: schema.NamingStrategy{SingularTable: true}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = db.AutoMigrate(&txUser{}); err != nil {
+			t.Fatal(err)
+		}
+		if err = db.Create(&txUser{Name: name}).Error; err != nil {
+			t.Fatal(err)
+		}
+		dsns = append(dsns, dsn)
+	}
+
+	opts = append(opts, WithRWSeparation(dsns[1:]))
+	db, err := InitWithDialector(sqliteDialector, dsns[0], opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, ok := db.Config.Plugins["sponge:replica_pool"].(*replicaPool)
+	if !ok {
+		t.Fatal("replica pool is not registered")
+	}
+	return db, pool
+}
+
+func readName(ctx context.Context, t *testing.T, db *gorm.DB) string {
+	user := &txUser{}
+	if err := db.WithContext(ctx).Where("id = ?", 1).First(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user.Name
+}
+
+func TestReplicaPool_weight(t *testing.T) {
+	db, pool := newReplicaTestDB(t, WithReplicaWeights(2, 1))
+	ctx := context.Background()
+
+	counts := map[string]int{}
+	for i := 0; i < 6; i++ {
+		counts[readName(ctx, t, db)]++
+	}
+	assert.Equal(t, map[string]int{"replica1": 4, "replica2": 2}, counts)
+
+	// take replica1 out of rotation
+	atomic.StoreInt32(&pool.replicas[0].healthy, 0)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "replica2", readName(ctx, t, db))
+	}
+
+	// no healthy replica, route to the master
+	atomic.StoreInt32(&pool.replicas[1].healthy, 0)
+	assert.Equal(t, "master", readName(ctx, t, db))
+	var name string
+	err := db.Raw("SELECT name FROM tx_user WHERE id = 1").Scan(&name).Error
+	assert.NoError(t, err)
+	assert.Equal(t, "master", name)
+
+	// writes are always routed to the master
+	err = db.Model(&txUser{}).Where("id = ?", 1).Update("name", "master2").Error
+	assert.NoError(t, err)
+	atomic.StoreInt32(&pool.replicas[1].healthy, 1)
+	assert.Equal(t, "replica2", readName(ctx, t, db))
+}
+
+func TestReplicaPool_readYourWrites(t *testing.T) {
+	db, _ := newReplicaTestDB(t, WithReadYourWrites(time.Millisecond*200))
+
+	ctx := ReadYourWrites(context.Background())
+	assert.Equal(t, ctx, ReadYourWrites(ctx))
+	assert.NotEqual(t, "master", readName(ctx, t, db))
+
+	err := db.WithContext(ctx).Create(&txUser{Name: "foo"}).Error
+	assert.NoError(t, err)
+	assert.Equal(t, "master", readName(ctx, t, db))
+	// other contexts are not affected
+	assert.NotEqual(t, "master", readName(context.Background(), t, db))
+
+	time.Sleep(time.Millisecond * 250)
+	assert.NotEqual(t, "master", readName(ctx, t, db))
+}
+
+func TestReplicaPool_healthCheck(t *testing.T) {
+	var lag int64
+	db, pool := newReplicaTestDB(t,
+		WithReplicaHealthCheck(time.Millisecond*10, time.Second),
+		WithReplicaLagFunc(func(ctx context.Context, db *sql.DB) (time.Duration, error) {
+			return time.Duration(atomic.LoadInt64(&lag)), nil
+		}),
+	)
+
+	// lagging replicas are taken out of rotation
+	atomic.StoreInt64(&lag, int64(time.Minute))
+	time.Sleep(time.Millisecond * 50)
+	assert.False(t, pool.replicas[0].isHealthy())
+	assert.Equal(t, "master", readName(context.Background(), t, db))
+
+	atomic.StoreInt64(&lag, 0)
+	time.Sleep(time.Millisecond * 50)
+	assert.True(t, pool.replicas[0].isHealthy())
+
+	// the replica is down
+	_ = pool.replicas[0].db.Close()
+	time.Sleep(time.Millisecond * 50)
+	assert.False(t, pool.replicas[0].isHealthy())
+	assert.True(t, pool.replicas[1].isHealthy())
+	assert.Equal(t, "replica2", readName(context.Background(), t, db))
+
+	// the replicas are closed after the master is closed
+	sqlDB, _ := db.DB()
+	_ = sqlDB.Close()
+	time.Sleep(time.Millisecond * 50)
+	assert.Error(t, pool.replicas[1].db.Ping())
+}
+
+func Test_defaultLagFunc(t *testing.T) {
+	assert.NotNil(t, defaultLagFunc("mysql"))
+	assert.NotNil(t, defaultLagFunc("postgres"))
+	lag, err := defaultLagFunc("sqlite")(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), lag)
+}
@@ -0,0 +1,285 @@
+package sharding
+
+import (
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Algorithm compute the table suffix from the value of the sharding key
+type Algorithm interface {
+	// Suffix get the table suffix of the value, e.g. _03
+	Suffix(value interface{}) (string, error)
+	// Suffixes get all table suffixes, they are used by scatter-gather
+	Suffixes() []string
+}
+
+// ------------------------------------------------------------------------------------------
+
+type modAlgorithm struct {
+	n     int64
+	width int
+}
+
+// Mod the suffix is value % n, the value must be an integer, e.g. Mod(64), the suffixes are _00 ~ _63
+func Mod(n int) Algorithm {
+	return &modAlgorithm{n: int64(n), width: suffixWidth(n)}
+}
+
+func (a *modAlgorithm) Suffix(value interface{}) (string, error) {
+	v, err := toInt64(value)
+	if err != nil {
+		return "", err
+	}
+	if v < 0 {
+		v = -v
+	}
+	return formatSuffix(v%a.n, a.width), nil
+}
+
+func (a *modAlgorithm) Suffixes() []string {
+	return numberSuffixes(a.n, a.width)
+}
+
+// ------------------------------------------------------------------------------------------
+
+type hashAlgorithm struct {
+	n     int64
+	width int
+}
+
+// Hash the suffix is crc32(value) % n, the value can be any type, e.g. Hash(16), the suffixes are _00 ~ _15
+func Hash(n int) Algorithm {
+	return &hashAlgorithm{n: int64(n), width: suffixWidth(n)}
+}
+
+func (a *hashAlgorithm) Suffix(value interface{}) (string, error) {
+	s, err := toString(value)
+	if err != nil {
+		return "", err
+	}
+	return formatSuffix(int64(crc32.ChecksumIEEE([]byte(s)))%a.n, a.width), nil
+}
+
+func (a *hashAlgorithm) Suffixes() []string {
+	return numberSuffixes(a.n, a.width)
+}
+
+// ------------------------------------------------------------------------------------------
+
+// Period the period of date range sharding
+type Period string
+
+const (
+	// Daily a table per day, the suffix format is _20060102
+	Daily Period = "day"
+	// Monthly a table per month, the suffix format is _200601
+	Monthly Period = "month"
+	// Yearly a table per year, the suffix format is _2006
+	Yearly Period = "year"
+)
+
+var periodLayouts = map[Period]string{
+	Daily:   "20060102",
+	Monthly: "200601",
+	Yearly:  "2006",
+}
+
+type dateRangeAlgorithm struct {
+	period Period
+	start  time.Time
+	end    time.Time
+}
+
+// DateRange the suffix is the period of the value, the value must be a time or a time string,
+// the values out of [start, end] are rejected, e.g. DateRange(Monthly, 2023-01, 2023-12), the suffixes are _202301 ~ _202312
+func DateRange(period Period, start time.Time, end time.Time) Algorithm {
+	return &dateRangeAlgorithm{period: period, start: truncate(period, start), end: truncate(period, end)}
+}
+
+func (a *dateRangeAlgorithm) Suffix(value interface{}) (string, error) {
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	t = truncate(a.period, t)
+	if t.Before(a.start) || t.After(a.end) {
+		return "", fmt.Errorf("time %s is out of the range of the shards", t.Format(periodLayouts[a.period]))
+	}
+	return "_" + t.Format(periodLayouts[a.period]), nil
+}
+
+func (a *dateRangeAlgorithm) Suffixes() []string {
+	var suffixes []string
+	for t := a.start; !t.After(a.end); t = next(a.period, t) {
+		suffixes = append(suffixes, "_"+t.Format(periodLayouts[a.period]))
+	}
+	return suffixes
+}
+
+func truncate(period Period, t time.Time) time.Time {
+	switch period {
+	case Yearly:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	case Monthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func next(period Period, t time.Time) time.Time {
+	switch period {
+	case Yearly:
+		return t.AddDate(1, 0, 0)
+	case Monthly:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// ------------------------------------------------------------------------------------------
+
+// ParseAlgorithm parse algorithm from string, the formats are:
+//
+//	mod:64
+//	hash:16
+//	day:2023-01-01:2023-12-31
+//	month:2023-01:2024-12
+//	year:2020:2030
+func ParseAlgorithm(s string) (Algorithm, error) {
+	ss := strings.Split(strings.TrimSpace(s), ":")
+	switch strings.ToLower(ss[0]) {
+	case "mod", "hash":
+		if len(ss) != 2 {
+			return nil, fmt.Errorf("invalid algorithm '%s', the format is %s:<number of shards>", s, ss[0])
+		}
+		n, err := strconv.Atoi(ss[1])
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid number of shards '%s'", ss[1])
+		}
+		if strings.ToLower(ss[0]) == "mod" {
+			return Mod(n), nil
+		}
+		return Hash(n), nil
+
+	case string(Daily), string(Monthly), string(Yearly):
+		period := Period(strings.ToLower(ss[0]))
+		layout := map[Period]string{Daily: "2006-01-02", Monthly: "2006-01", Yearly: "2006"}[period]
+		if len(ss) != 3 {
+			return nil, fmt.Errorf("invalid algorithm '%s', the format is %s:<start>:<end>, the time layout is %s", s, ss[0], layout)
+		}
+		start, err := time.ParseInLocation(layout, ss[1], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time '%s', the layout is %s", ss[1], layout)
+		}
+		end, err := time.ParseInLocation(layout, ss[2], time.Local)
+		if err != nil || end.Before(start) {
+			return nil, fmt.Errorf("invalid end time '%s', the layout is %s", ss[2], layout)
+		}
+		return DateRange(period, start, end), nil
+	}
+
+	return nil, fmt.Errorf("unsupported algorithm '%s', only mod, hash, day, month and year are supported", s)
+}
+
+// MustParseAlgorithm parse algorithm from string, panic if error
+func MustParseAlgorithm(s string) Algorithm {
+	a, err := ParseAlgorithm(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// ------------------------------------------------------------------------------------------
+
+func suffixWidth(n int) int {
+	width := len(strconv.Itoa(n - 1))
+	if width < 2 {
+		width = 2
+	}
+	return width
+}
+
+func formatSuffix(i int64, width int) string {
+	return fmt.Sprintf("_%0*d", width, i)
+}
+
+func numberSuffixes(n int64, width int) []string {
+	suffixes := make([]string, 0, n)
+	for i := int64(0); i < n; i++ {
+		suffixes = append(suffixes, formatSuffix(i, width))
+	}
+	return suffixes
+}
+
+func indirect(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func toInt64(value interface{}) (int64, error) {
+	value = indirect(value)
+	v := reflect.ValueOf(value)
+	switch v.Kind() { //nolint
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f == float64(int64(f)) {
+			return int64(f), nil
+		}
+	case reflect.String:
+		return strconv.ParseInt(v.String(), 10, 64)
+	}
+	if b, ok := value.([]byte); ok {
+		return strconv.ParseInt(string(b), 10, 64)
+	}
+	return 0, fmt.Errorf("sharding key value %v(%T) is not an integer", value, value)
+}
+
+func toString(value interface{}) (string, error) {
+	value = indirect(value)
+	switch v := value.(type) {
+	case nil:
+		return "", fmt.Errorf("sharding key value is nil")
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+var timeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05Z07:00", "2006-01-02"}
+
+func toTime(value interface{}) (time.Time, error) {
+	value = indirect(value)
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string, []byte:
+		s, _ := toString(v)
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("sharding key value %v(%T) is not a time", value, value)
+}
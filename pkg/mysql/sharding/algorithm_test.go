@@ -0,0 +1,89 @@
+package sharding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMod(t *testing.T) {
+	a := Mod(64)
+	suffix, err := a.Suffix(uint64(130))
+	assert.NoError(t, err)
+	assert.Equal(t, "_02", suffix)
+	id := 63
+	suffix, err = a.Suffix(&id)
+	assert.NoError(t, err)
+	assert.Equal(t, "_63", suffix)
+	suffix, err = a.Suffix("65")
+	assert.NoError(t, err)
+	assert.Equal(t, "_01", suffix)
+	_, err = a.Suffix("foo")
+	assert.Error(t, err)
+	_, err = a.Suffix(1.5)
+	assert.Error(t, err)
+
+	suffixes := Mod(200).Suffixes()
+	assert.Len(t, suffixes, 200)
+	assert.Equal(t, "_000", suffixes[0])
+	assert.Equal(t, "_199", suffixes[199])
+}
+
+func TestHash(t *testing.T) {
+	a := Hash(16)
+	s1, err := a.Suffix("foo")
+	assert.NoError(t, err)
+	s2, err := a.Suffix([]byte("foo"))
+	assert.NoError(t, err)
+	assert.Equal(t, s1, s2)
+	assert.Contains(t, a.Suffixes(), s1)
+	_, err = a.Suffix(nil)
+	assert.Error(t, err)
+}
+
+func TestDateRange(t *testing.T) {
+	start := time.Date(2023, 1, 15, 0, 0, 0, 0, time.Local)
+	end := time.Date(2023, 12, 1, 0, 0, 0, 0, time.Local)
+	a := DateRange(Monthly, start, end)
+	assert.Len(t, a.Suffixes(), 12)
+
+	suffix, err := a.Suffix(time.Date(2023, 3, 31, 23, 0, 0, 0, time.Local))
+	assert.NoError(t, err)
+	assert.Equal(t, "_202303", suffix)
+	suffix, err = a.Suffix("2023-01-01 10:00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, "_202301", suffix)
+	_, err = a.Suffix("2024-01-01")
+	assert.Error(t, err)
+	_, err = a.Suffix(1)
+	assert.Error(t, err)
+
+	a = DateRange(Daily, start, start.AddDate(0, 0, 2))
+	assert.Equal(t, []string{"_20230115", "_20230116", "_20230117"}, a.Suffixes())
+	a = DateRange(Yearly, start, end)
+	assert.Equal(t, []string{"_2023"}, a.Suffixes())
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	valid := map[string]int{
+		"mod:64":                    64,
+		"hash:16":                   16,
+		"day:2023-01-01:2023-01-31": 31,
+		"month:2023-01:2024-12":     24,
+		"year:2020:2030":            11,
+	}
+	for s, n := range valid {
+		a, err := ParseAlgorithm(s)
+		assert.NoError(t, err, s)
+		assert.Len(t, a.Suffixes(), n, s)
+	}
+
+	invalid := []string{"", "mod", "mod:0", "hash:foo", "month:2023-01", "month:2023-12:2023-01", "day:2023:2024", "range:1"}
+	for _, s := range invalid {
+		_, err := ParseAlgorithm(s)
+		assert.Error(t, err, s)
+	}
+
+	assert.Panics(t, func() { MustParseAlgorithm("mod") })
+}
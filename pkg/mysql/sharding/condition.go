@@ -0,0 +1,207 @@
+package sharding
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// get the values of the sharding key from the where conditions, only the conditions joined by AND are used,
+// e.g. key = ?, key IN ?, map[string]interface{}{"key": v}, struct conditions.
+func whereValues(stmt *gorm.Statement, r *rule) []interface{} {
+	c, ok := stmt.Clauses["WHERE"]
+	if !ok {
+		return nil
+	}
+	where, ok := c.Expression.(clause.Where)
+	if !ok {
+		return nil
+	}
+	return exprsValues(where.Exprs, r)
+}
+
+func exprsValues(exprs []clause.Expression, r *rule) []interface{} {
+	// the conditions are joined by OR
+	for _, expr := range exprs {
+		if _, ok := expr.(clause.OrConditions); ok {
+			return nil
+		}
+	}
+
+	for _, expr := range exprs {
+		if values := exprValues(expr, r); len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+func exprValues(expr clause.Expression, r *rule) []interface{} {
+	key := r.Key
+	switch e := expr.(type) {
+	case clause.Eq:
+		if isKeyColumn(e.Column, key) {
+			return flatten(e.Value)
+		}
+	case clause.IN:
+		if isKeyColumn(e.Column, key) {
+			var values []interface{}
+			for _, v := range e.Values {
+				values = append(values, flatten(v)...)
+			}
+			return values
+		}
+	case clause.AndConditions:
+		return exprsValues(e.Exprs, r)
+	case clause.Expr:
+		return sqlValues(e.SQL, e.Vars, r.keyRegexp)
+	}
+	return nil
+}
+
+func isKeyColumn(column interface{}, key string) bool {
+	switch c := column.(type) {
+	case string:
+		return trimColumn(c) == key
+	case clause.Column:
+		return !c.Raw && c.Name == key
+	}
+	return false
+}
+
+// remove the quotes and the table name of a column
+func trimColumn(column string) string {
+	column = strings.NewReplacer("`", "", `"`, "").Replace(strings.TrimSpace(column))
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		column = column[i+1:]
+	}
+	return column
+}
+
+// key = ?, key IN ?, key IN (?), the column can be quoted and prefixed by the table name
+func keyRegexp(key string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)(?:^|[\\s(,])(?:[`\"]?\\w+[`\"]?\\.)?[`\"]?" + regexp.QuoteMeta(key) + "[`\"]?\\s*(?:=|\\bIN\\b)\\s*\\(?\\s*\\?")
+}
+
+func sqlValues(sql string, vars []interface{}, re *regexp.Regexp) []interface{} {
+	if strings.Contains(strings.ToUpper(sql), " OR ") {
+		return nil
+	}
+	loc := re.FindStringIndex(sql)
+	if loc == nil {
+		return nil
+	}
+	// the index of the var is the number of placeholders before the matched placeholder
+	index := strings.Count(sql[:loc[1]-1], "?")
+	if index >= len(vars) {
+		return nil
+	}
+	return flatten(vars[index])
+}
+
+// flatten the slice value, except []byte
+func flatten(value interface{}) []interface{} {
+	if _, ok := value.([]byte); ok {
+		return []interface{}{value}
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		if value == nil {
+			return nil
+		}
+		return []interface{}{value}
+	}
+	values := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values = append(values, v.Index(i).Interface())
+	}
+	return values
+}
+
+// get the values of the sharding key from the model or the records to be created, the zero values are ignored
+func modelValues(stmt *gorm.Statement, key string) []interface{} {
+	if stmt.Schema == nil {
+		return nil
+	}
+	field := stmt.Schema.LookUpField(key)
+	if field == nil {
+		return nil
+	}
+
+	rv := stmt.ReflectValue
+	var values []interface{}
+	switch rv.Kind() { //nolint
+	case reflect.Struct:
+		if v, isZero := field.ValueOf(stmt.Context, rv); !isZero {
+			values = append(values, v)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.Indirect(rv.Index(i))
+			if elem.Kind() != reflect.Struct {
+				continue
+			}
+			v, isZero := field.ValueOf(stmt.Context, elem)
+			if isZero {
+				return nil // every record must have the sharding key
+			}
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// get the new values of the sharding key set by the update, e.g. Update("key", v), Updates(map), Updates(struct)
+func updateValues(stmt *gorm.Statement, key string) []interface{} {
+	if stmt.Schema == nil {
+		return nil
+	}
+	field := stmt.Schema.LookUpField(key)
+	if field == nil {
+		return nil
+	}
+
+	if dest, ok := stmt.Dest.(map[string]interface{}); ok {
+		for _, name := range []string{field.DBName, field.Name} {
+			if v, ok := dest[name]; ok {
+				return flatten(v)
+			}
+		}
+		return nil
+	}
+	if stmt.Dest == stmt.Model {
+		return nil // the model is the record to be written, e.g. Save, Delete
+	}
+	rv := reflect.Indirect(reflect.ValueOf(stmt.Dest))
+	if rv.Kind() != reflect.Struct || rv.Type() != stmt.Schema.ModelType {
+		return nil
+	}
+	if v, isZero := field.ValueOf(stmt.Context, rv); !isZero {
+		return []interface{}{v}
+	}
+	return nil
+}
+
+// the primary key conditions of the model or the records, which are added by gorm when the statement is executed
+func primaryKeyConds(stmt *gorm.Statement) []clause.Expression {
+	if stmt.Schema == nil || len(stmt.Schema.PrimaryFields) == 0 || !stmt.ReflectValue.IsValid() {
+		return nil
+	}
+	var conds []clause.Expression
+	addConds := func(rv reflect.Value) {
+		_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, rv, stmt.Schema.PrimaryFields)
+		column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, queryValues)
+		if len(values) > 0 {
+			conds = append(conds, clause.IN{Column: column, Values: values})
+		}
+	}
+	addConds(stmt.ReflectValue)
+	if stmt.Model != nil && stmt.Dest != stmt.Model {
+		addConds(reflect.ValueOf(stmt.Model))
+	}
+	return conds
+}
@@ -0,0 +1,361 @@
+// Package sharding is a gorm plugin of horizontal table sharding, the logical table name in the statement is rewritten
+// to the shard computed from the value of the sharding key, the statements without the sharding key are rejected,
+// unless the scatter-gather mode is enabled by WithScatter.
+package sharding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	pluginName = "sponge:sharding"
+	scatterKey = "sponge:sharding_scatter"
+	skipKey    = "sponge:sharding_skip"
+)
+
+var (
+	// ErrMissingShardingKey the statement does not include the sharding key
+	ErrMissingShardingKey = errors.New("sharding key is required")
+	// ErrCrossShard the values of the sharding key are in different shards
+	ErrCrossShard = errors.New("sharding key values are in different shards")
+)
+
+// Rule sharding rule of a table
+type Rule struct {
+	Table     string    // logical table name, e.g. order
+	Key       string    // column name of the sharding key, e.g. user_id
+	Algorithm Algorithm // compute the table suffix from the value of the sharding key, e.g. Mod(64)
+
+	// generate the primary key of the created records whose primary key is zero, e.g. MustNewSnowflake(nodeID).NextID,
+	// the auto increment ids of the shards collide, so the ids must be generated globally
+	IDGenerator func() uint64
+}
+
+type rule struct {
+	Rule
+	keyRegexp *regexp.Regexp
+	suffixes  []string
+}
+
+// shard name of the suffix
+func (r *rule) shard(suffix string) string {
+	return r.Table + suffix
+}
+
+// Sharding gorm plugin of table sharding
+type Sharding struct {
+	rules map[string]*rule
+	err   error
+}
+
+// New create a sharding plugin, register it by mysql.WithGormPlugin or db.Use
+func New(rules ...Rule) *Sharding {
+	s := &Sharding{rules: map[string]*rule{}}
+	for _, r := range rules {
+		if r.Table == "" || r.Key == "" || r.Algorithm == nil {
+			s.err = fmt.Errorf("invalid sharding rule %+v, table, key and algorithm are required", r)
+			continue
+		}
+		if r.IDGenerator == nil {
+			s.err = fmt.Errorf("invalid sharding rule of table %s, id generator is required, "+
+				"the auto increment ids of the shards collide", r.Table)
+			continue
+		}
+		s.rules[r.Table] = &rule{Rule: r, keyRegexp: keyRegexp(r.Key), suffixes: r.Algorithm.Suffixes()}
+	}
+	return s
+}
+
+// Name plugin name
+func (s *Sharding) Name() string {
+	return pluginName
+}
+
+// Initialize register callbacks to rewrite the table name before executing the statement
+func (s *Sharding) Initialize(db *gorm.DB) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	cb := db.Callback()
+	err := cb.Create().Before("gorm:create").Register("sponge:sharding", s.rewrite(opCreate))
+	if err != nil {
+		return err
+	}
+	err = cb.Query().Before("gorm:query").Register("sponge:sharding", s.rewrite(opRead))
+	if err != nil {
+		return err
+	}
+	err = cb.Update().Before("gorm:update").Register("sponge:sharding", s.rewrite(opWrite))
+	if err != nil {
+		return err
+	}
+	err = cb.Delete().Before("gorm:delete").Register("sponge:sharding", s.rewrite(opWrite))
+	if err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("sponge:sharding", s.rewrite(opRead))
+}
+
+// Get get the sharding plugin registered in db
+func Get(db *gorm.DB) (*Sharding, bool) {
+	s, ok := db.Config.Plugins[pluginName].(*Sharding)
+	return s, ok
+}
+
+// WithScatter enable the scatter-gather mode of db, the reads without the sharding key are executed on all shards
+// and the results are merged, the writes without the sharding key locate the shard by the conditions first,
+// they are rejected if the records are in different shards. the statements with the sharding key are not affected.
+//
+// note that the reads without the sharding key scan all shards, use them only for list queries.
+func WithScatter(db *gorm.DB) *gorm.DB {
+	return db.Set(scatterKey, true).Session(&gorm.Session{})
+}
+
+// WithScatterIfSharded enable the scatter-gather mode of db if the table of the model is sharded by the plugin registered in db
+func WithScatterIfSharded(db *gorm.DB, model interface{}) *gorm.DB {
+	s, ok := Get(db)
+	if !ok {
+		return db
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil || !s.IsSharded(stmt.Table) {
+		return db
+	}
+	return WithScatter(db)
+}
+
+// IsSharded whether the table is sharded
+func (s *Sharding) IsSharded(table string) bool {
+	_, ok := s.rules[table]
+	return ok
+}
+
+// Shard get the shard name of the table by the value of the sharding key, e.g. order_03
+func (s *Sharding) Shard(table string, value interface{}) (string, error) {
+	r, ok := s.rules[table]
+	if !ok {
+		return "", fmt.Errorf("table %s is not sharded", table)
+	}
+	suffix, err := r.Algorithm.Suffix(value)
+	if err != nil {
+		return "", err
+	}
+	return r.shard(suffix), nil
+}
+
+// Shards get all shard names of the table
+func (s *Sharding) Shards(table string) []string {
+	r, ok := s.rules[table]
+	if !ok {
+		return nil
+	}
+	shards := make([]string, 0, len(r.suffixes))
+	for _, suffix := range r.suffixes {
+		shards = append(shards, r.shard(suffix))
+	}
+	return shards
+}
+
+type operation int
+
+const (
+	opCreate operation = iota + 1
+	opRead
+	opWrite // update and delete
+)
+
+func (s *Sharding) rewrite(op operation) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil {
+			return
+		}
+		stmt := db.Statement
+		r, ok := s.rules[stmt.Table]
+		if !ok {
+			return
+		}
+		if _, ok = stmt.Settings.Load(skipKey); ok {
+			return
+		}
+
+		_, isScatter := stmt.Settings.Load(scatterKey)
+		var values []interface{}
+		switch op {
+		case opCreate:
+			err := setIDs(stmt, r)
+			if err != nil {
+				_ = db.AddError(err)
+				return
+			}
+			values = modelValues(stmt, r.Key)
+		case opRead:
+			values = whereValues(stmt, r)
+		case opWrite:
+			values = whereValues(stmt, r)
+			if len(values) == 0 && isScatter {
+				// the records are located by the conditions and the primary key first, the sharding key of the model
+				// may be the new value of the update rather than the current one
+				var isLocated bool
+				var err error
+				values, isLocated, err = locate(db, r)
+				if err != nil {
+					_ = db.AddError(err)
+					return
+				}
+				if isLocated && len(values) == 0 { // no records to be written
+					setTable(stmt, r.shard(r.suffixes[0]))
+					return
+				}
+			}
+			if len(values) == 0 {
+				values = modelValues(stmt, r.Key)
+			}
+		}
+
+		if len(values) == 0 {
+			if op == opRead && isScatter {
+				setTableExpr(stmt, r, unionSQL(stmt, r, r.suffixes))
+				return
+			}
+			_ = db.AddError(fmt.Errorf("%w, table %s, key %s", ErrMissingShardingKey, r.Table, r.Key))
+			return
+		}
+
+		suffixes, err := suffixesOf(r, values)
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		if len(suffixes) == 1 {
+			if op == opWrite {
+				// the records can not be moved to another shard by updating the sharding key
+				newSuffixes, err := suffixesOf(r, updateValues(stmt, r.Key))
+				if err != nil {
+					_ = db.AddError(err)
+					return
+				}
+				for _, suffix := range newSuffixes {
+					if suffix != suffixes[0] {
+						_ = db.AddError(fmt.Errorf("%w, table %s, key %s, the records can not be moved to another shard",
+							ErrCrossShard, r.Table, r.Key))
+						return
+					}
+				}
+			}
+			setTable(stmt, r.shard(suffixes[0]))
+			return
+		}
+		if op != opRead || !isScatter {
+			_ = db.AddError(fmt.Errorf("%w, table %s, key %s", ErrCrossShard, r.Table, r.Key))
+			return
+		}
+		setTableExpr(stmt, r, unionSQL(stmt, r, suffixes))
+	}
+}
+
+// set the primary key of the records to be created by the id generator if it is zero
+func setIDs(stmt *gorm.Statement, r *rule) error {
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil {
+		return nil
+	}
+	field := stmt.Schema.PrioritizedPrimaryField
+
+	setID := func(rv reflect.Value) error {
+		if _, isZero := field.ValueOf(stmt.Context, rv); !isZero {
+			return nil
+		}
+		err := field.Set(stmt.Context, rv, r.IDGenerator())
+		if err != nil {
+			return fmt.Errorf("table %s, set id error: %v", r.Table, err)
+		}
+		return nil
+	}
+
+	rv := stmt.ReflectValue
+	switch rv.Kind() { //nolint
+	case reflect.Struct:
+		return setID(rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.Indirect(rv.Index(i))
+			if elem.Kind() != reflect.Struct {
+				continue
+			}
+			err := setID(elem)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// get the distinct suffixes of the values in order
+func suffixesOf(r *rule, values []interface{}) ([]string, error) {
+	var suffixes []string
+	exists := map[string]struct{}{}
+	for _, v := range values {
+		suffix, err := r.Algorithm.Suffix(v)
+		if err != nil {
+			return nil, fmt.Errorf("table %s, key %s: %v", r.Table, r.Key, err)
+		}
+		if _, ok := exists[suffix]; !ok {
+			exists[suffix] = struct{}{}
+			suffixes = append(suffixes, suffix)
+		}
+	}
+	return suffixes, nil
+}
+
+// the statement is executed on the shard
+func setTable(stmt *gorm.Statement, shard string) {
+	stmt.Table = shard
+	stmt.TableExpr = &clause.Expr{SQL: stmt.Quote(shard)}
+}
+
+// the statement is executed on the union of the shards, the alias is the logical table name,
+// so the conditions of the statement work without changes.
+func setTableExpr(stmt *gorm.Statement, r *rule, sql string) {
+	stmt.Table = r.Table
+	stmt.TableExpr = &clause.Expr{SQL: sql}
+}
+
+func unionSQL(stmt *gorm.Statement, r *rule, suffixes []string) string {
+	selects := make([]string, 0, len(suffixes))
+	for _, suffix := range suffixes {
+		selects = append(selects, "SELECT * FROM "+stmt.Quote(r.shard(suffix)))
+	}
+	return "(" + strings.Join(selects, " UNION ALL ") + ") AS " + stmt.Quote(r.Table)
+}
+
+// locate the sharding key values of the records to be written by the where conditions and the primary key of the model,
+// gorm adds the primary key conditions in gorm:update and gorm:delete after the shard is chosen.
+// isLocated is false if there are no conditions to locate the records.
+func locate(db *gorm.DB, r *rule) (values []interface{}, isLocated bool, err error) {
+	var conds []clause.Expression
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			conds = append(conds, where.Exprs...)
+		}
+	}
+	conds = append(conds, primaryKeyConds(db.Statement)...)
+	if len(conds) == 0 {
+		return nil, false, nil
+	}
+
+	tx := db.Session(&gorm.Session{NewDB: true, Context: db.Statement.Context}).Set(skipKey, true)
+	setTableExpr(tx.Statement, r, unionSQL(tx.Statement, r, r.suffixes))
+	err = tx.Clauses(clause.Where{Exprs: conds}).Distinct(r.Key).Pluck(r.Key, &values).Error
+	if err != nil {
+		return nil, true, err
+	}
+	return values, true, nil
+}
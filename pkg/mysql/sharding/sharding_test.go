@@ -0,0 +1,255 @@
+package sharding
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/hankyu66/sponge/pkg/mysql"
+)
+
+type userOrder struct {
+	ID     uint64 `gorm:"primaryKey"`
+	UserID uint64
+	Name   string
+}
+
+func newShardingTestDB(t *testing.T) (*gorm.DB, *Sharding) {
+	plugin := New(Rule{Table: "user_order", Key: "user_id", Algorithm: Mod(4), IDGenerator: MustNewSnowflake(1).NextID})
+	dsn := filepath.Join(t.TempDir(), "sharding.db")
+	db, err := mysql.InitWithDialector(func(dsn string) gorm.Dialector { return sqlite.Open(dsn) }, dsn,
+		mysql.WithGormPlugin(plugin),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, shard := range plugin.Shards("user_order") {
+		if err = db.Table(shard).AutoMigrate(&userOrder{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// user 1, 5 are in user_order_01, user 2 is in user_order_02
+	orders := []*userOrder{{ID: 1, UserID: 1, Name: "foo"}, {ID: 2, UserID: 5, Name: "bar"}, {ID: 3, UserID: 2, Name: "foo"}}
+	for _, order := range orders {
+		if err = db.Create(order).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db, plugin
+}
+
+func countShard(t *testing.T, db *gorm.DB, shard string) int64 {
+	var count int64
+	if err := db.Table(shard).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestNew(t *testing.T) {
+	plugin := New(Rule{Table: "user_order", Key: "user_id", Algorithm: Hash(16), IDGenerator: MustNewSnowflake(1).NextID})
+	assert.Equal(t, "sponge:sharding", plugin.Name())
+	assert.True(t, plugin.IsSharded("user_order"))
+	assert.False(t, plugin.IsSharded("user"))
+	assert.Len(t, plugin.Shards("user_order"), 16)
+	assert.Nil(t, plugin.Shards("user"))
+	_, err := plugin.Shard("user", 1)
+	assert.Error(t, err)
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the scatter-gather mode is enabled only for the sharded tables
+	type user struct{ ID uint64 }
+	sdb, _ := newShardingTestDB(t)
+	_, ok := WithScatterIfSharded(sdb, &userOrder{}).Statement.Settings.Load(scatterKey)
+	assert.True(t, ok)
+	_, ok = WithScatterIfSharded(sdb, &user{}).Statement.Settings.Load(scatterKey)
+	assert.False(t, ok)
+	_, ok = WithScatterIfSharded(db, &userOrder{}).Statement.Settings.Load(scatterKey)
+	assert.False(t, ok)
+
+	err = db.Use(New(Rule{Table: "user_order"}))
+	assert.Error(t, err)
+	err = db.Use(New(Rule{Table: "user_order", Key: "user_id", Algorithm: Hash(16)}))
+	assert.Error(t, err)
+	_, ok = Get(db)
+	assert.False(t, ok)
+}
+
+func TestSharding_create(t *testing.T) {
+	db, plugin := newShardingTestDB(t)
+	p, ok := Get(db)
+	assert.True(t, ok)
+	assert.Equal(t, plugin, p)
+
+	shard, err := plugin.Shard("user_order", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "user_order_01", shard)
+	assert.Equal(t, int64(2), countShard(t, db, "user_order_01"))
+	assert.Equal(t, int64(1), countShard(t, db, "user_order_02"))
+
+	// batch create in the same shard
+	err = db.Create([]*userOrder{{ID: 4, UserID: 3}, {ID: 5, UserID: 7}}).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), countShard(t, db, "user_order_03"))
+
+	err = db.Create([]*userOrder{{ID: 6, UserID: 3}, {ID: 7, UserID: 4}}).Error
+	assert.ErrorIs(t, err, ErrCrossShard)
+	err = db.Create(&userOrder{ID: 8}).Error
+	assert.ErrorIs(t, err, ErrMissingShardingKey)
+	err = WithScatter(db).Create(&userOrder{ID: 8}).Error
+	assert.ErrorIs(t, err, ErrMissingShardingKey)
+
+	// the ids are generated globally, the records in different shards do not have the same id
+	order1, order2 := &userOrder{UserID: 1}, &userOrder{UserID: 2}
+	assert.NoError(t, db.Create(order1).Error)
+	assert.NoError(t, db.Create(order2).Error)
+	assert.Greater(t, order1.ID, uint64(8))
+	assert.Greater(t, order2.ID, order1.ID)
+	orders := []*userOrder{{UserID: 3}, {ID: 9, UserID: 3}}
+	assert.NoError(t, db.Create(orders).Error)
+	assert.Greater(t, orders[0].ID, order2.ID)
+	assert.Equal(t, uint64(9), orders[1].ID)
+	order := &userOrder{}
+	assert.NoError(t, WithScatter(db).Where("id = ?", order2.ID).First(order).Error)
+	assert.Equal(t, uint64(2), order.UserID)
+}
+
+func TestSharding_query(t *testing.T) {
+	db, _ := newShardingTestDB(t)
+
+	var orders []*userOrder
+	err := db.Where("user_id = ?", 5).Find(&orders).Error
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, uint64(2), orders[0].ID)
+
+	order := &userOrder{}
+	err = db.Where(&userOrder{UserID: 1}).First(order).Error
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), order.ID)
+
+	var count int64
+	err = db.Model(&userOrder{}).Where(map[string]interface{}{"user_id": 2}).Count(&count).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// the sharding key is required
+	err = db.Where("name = ?", "foo").Find(&orders).Error
+	assert.ErrorIs(t, err, ErrMissingShardingKey)
+	err = db.Where("user_id = ? OR name = ?", 1, "foo").Find(&orders).Error
+	assert.ErrorIs(t, err, ErrMissingShardingKey)
+	err = db.Where("user_id IN ?", []uint64{1, 2}).Find(&orders).Error
+	assert.ErrorIs(t, err, ErrCrossShard)
+
+	// scatter-gather
+	orders = nil
+	err = WithScatter(db).Where("name = ?", "foo").Order("id desc").Find(&orders).Error
+	assert.NoError(t, err)
+	if assert.Len(t, orders, 2) {
+		assert.Equal(t, uint64(3), orders[0].ID)
+		assert.Equal(t, uint64(1), orders[1].ID)
+	}
+	orders = nil
+	err = WithScatter(db).Where("user_id IN ?", []uint64{1, 2}).Find(&orders).Error
+	assert.NoError(t, err)
+	assert.Len(t, orders, 2)
+	err = WithScatter(db).Model(&userOrder{}).Count(&count).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestSharding_scatterInTransaction(t *testing.T) {
+	db, _ := newShardingTestDB(t)
+	scatterDB := WithScatter(db) // e.g. the db of dao
+
+	err := mysql.Transaction(context.Background(), db, func(ctx context.Context) error {
+		var orders []*userOrder
+		err := mysql.GetDB(ctx, scatterDB).Where("name = ?", "foo").Find(&orders).Error
+		if err != nil {
+			return err
+		}
+		assert.Len(t, orders, 2)
+
+		err = mysql.GetDB(ctx, scatterDB).Model(&userOrder{}).Where("user_id = ? AND id = ?", 1, 1).Update("name", "baz").Error
+		if err != nil {
+			return err
+		}
+
+		// the scatter mode is not applied to the transaction of other db
+		err = mysql.GetDB(ctx, db).Where("name = ?", "foo").Find(&orders).Error
+		assert.ErrorIs(t, err, ErrMissingShardingKey)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	var count int64
+	err = WithScatter(db).Model(&userOrder{}).Where("name = ?", "baz").Count(&count).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestSharding_updateAndDelete(t *testing.T) {
+	db, _ := newShardingTestDB(t)
+
+	err := db.Model(&userOrder{}).Where("user_id = ? AND id = ?", 1, 1).Update("name", "baz").Error
+	assert.NoError(t, err)
+	err = db.Model(&userOrder{ID: 2, UserID: 5}).Update("name", "baz").Error
+	assert.NoError(t, err)
+	var count int64
+	err = db.Table("user_order_01").Where("name = ?", "baz").Count(&count).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	err = db.Model(&userOrder{}).Where("id = ?", 3).Update("name", "baz").Error
+	assert.ErrorIs(t, err, ErrMissingShardingKey)
+
+	// locate the shard by the conditions
+	err = WithScatter(db).Model(&userOrder{}).Where("id = ?", 3).Update("name", "qux").Error
+	assert.NoError(t, err)
+	order := &userOrder{}
+	err = db.Where("user_id = ?", 2).First(order).Error
+	assert.NoError(t, err)
+	assert.Equal(t, "qux", order.Name)
+	err = WithScatter(db).Model(&userOrder{}).Where("name = ?", "baz").Update("name", "qux").Error
+	assert.NoError(t, err)
+
+	// locate the shard by the primary key of the model, the sharding key of the model is the new value
+	err = WithScatter(db).Model(&userOrder{ID: 3}).Updates(map[string]interface{}{"name": "quux"}).Error
+	assert.NoError(t, err)
+	err = WithScatter(db).Model(&userOrder{ID: 3, UserID: 2}).Updates(map[string]interface{}{"name": "corge", "user_id": 2}).Error
+	assert.NoError(t, err)
+	order = &userOrder{}
+	assert.NoError(t, db.Where("user_id = ? AND id = ?", 2, 3).First(order).Error)
+	assert.Equal(t, "corge", order.Name)
+	err = WithScatter(db).Model(&userOrder{ID: 3, UserID: 3}).Updates(map[string]interface{}{"user_id": 3}).Error
+	assert.ErrorIs(t, err, ErrCrossShard)
+	err = WithScatter(db).Model(&userOrder{ID: 3}).Updates(&userOrder{UserID: 3}).Error
+	assert.ErrorIs(t, err, ErrCrossShard)
+	err = WithScatter(db).Model(&userOrder{ID: 100, UserID: 3}).Updates(map[string]interface{}{"name": "quux"}).Error
+	assert.NoError(t, err)
+	err = db.Model(&userOrder{ID: 3}).Updates(map[string]interface{}{"name": "quux"}).Error
+	assert.ErrorIs(t, err, ErrMissingShardingKey)
+	err = WithScatter(db).Model(&userOrder{ID: 3}).Update("name", "qux").Error
+	assert.NoError(t, err)
+	err = WithScatter(db).Model(&userOrder{}).Where("name = ?", "qux").Update("name", "foo").Error
+	assert.ErrorIs(t, err, ErrCrossShard)
+
+	err = db.Where("user_id = ?", 5).Delete(&userOrder{}).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), countShard(t, db, "user_order_01"))
+	err = WithScatter(db).Where("id = ?", 3).Delete(&userOrder{}).Error
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), countShard(t, db, "user_order_02"))
+	err = WithScatter(db).Where("id = ?", 100).Delete(&userOrder{}).Error
+	assert.NoError(t, err)
+	err = db.Where("id = ?", 1).Delete(&userOrder{}).Error
+	assert.ErrorIs(t, err, ErrMissingShardingKey)
+}
@@ -0,0 +1,66 @@
+package sharding
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	nodeBits     = 10
+	sequenceBits = 12
+
+	// MaxNode the maximum node id of the snowflake
+	MaxNode     = 1<<nodeBits - 1
+	maxSequence = 1<<sequenceBits - 1
+)
+
+// the epoch of the snowflake ids, 2023-01-01 00:00:00 UTC
+var snowflakeEpoch = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+// Snowflake generate the ids which are unique across the shards and the instances, the id is composed of
+// 41 bits milliseconds since the epoch, 10 bits node id and 12 bits sequence, the node id must be unique for each instance.
+type Snowflake struct {
+	mu       sync.Mutex
+	node     int64
+	lastTime int64
+	sequence int64
+}
+
+// NewSnowflake create a snowflake id generator of the node, the node id is 0 ~ MaxNode
+func NewSnowflake(node int64) (*Snowflake, error) {
+	if node < 0 || node > MaxNode {
+		return nil, fmt.Errorf("invalid node id %d, it must be 0 ~ %d", node, MaxNode)
+	}
+	return &Snowflake{node: node}, nil
+}
+
+// MustNewSnowflake create a snowflake id generator of the node, panic if the node id is invalid
+func MustNewSnowflake(node int64) *Snowflake {
+	s, err := NewSnowflake(node)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NextID generate the next id, the ids of a node are increasing, when the clock moves backwards or
+// the sequence of the millisecond is exhausted, the ids are generated in the following milliseconds
+func (s *Snowflake) NextID() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UnixMilli() - snowflakeEpoch
+	if now <= s.lastTime {
+		now = s.lastTime
+		s.sequence = (s.sequence + 1) & maxSequence
+		if s.sequence == 0 {
+			now++
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastTime = now
+
+	return uint64(now<<(nodeBits+sequenceBits) | s.node<<sequenceBits | s.sequence)
+}
@@ -0,0 +1,47 @@
+package sharding
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnowflake(t *testing.T) {
+	s1 := MustNewSnowflake(1)
+	s2 := MustNewSnowflake(2)
+
+	var mu sync.Mutex
+	ids := make(map[uint64]struct{})
+	var wg sync.WaitGroup
+	for _, s := range []*Snowflake{s1, s2, s1, s2} {
+		wg.Add(1)
+		go func(s *Snowflake) {
+			defer wg.Done()
+			last := uint64(0)
+			for i := 0; i < 10000; i++ {
+				id := s.NextID()
+				mu.Lock()
+				ids[id] = struct{}{}
+				mu.Unlock()
+				assert.Greater(t, id, last)
+				last = id
+			}
+		}(s)
+	}
+	wg.Wait()
+	assert.Equal(t, 40000, len(ids))
+
+	// the sequence is exhausted or the clock moves backwards
+	s1.lastTime += 1000
+	s1.sequence = maxSequence
+	id := s1.NextID()
+	assert.Equal(t, uint64(s1.lastTime<<(nodeBits+sequenceBits)|1<<sequenceBits), id)
+	assert.Greater(t, s1.NextID(), id)
+
+	_, err := NewSnowflake(-1)
+	assert.Error(t, err)
+	_, err = NewSnowflake(MaxNode + 1)
+	assert.Error(t, err)
+	assert.Panics(t, func() { MustNewSnowflake(MaxNode + 1) })
+}
@@ -0,0 +1,122 @@
+package mysql
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+type txCtxKey struct{}
+
+// transaction stored in the context
+type txContext struct {
+	tx *gorm.DB
+
+	mu          sync.Mutex
+	afterCommit []func(ctx context.Context)
+}
+
+func (tc *txContext) addAfterCommit(fns ...func(ctx context.Context)) {
+	tc.mu.Lock()
+	tc.afterCommit = append(tc.afterCommit, fns...)
+	tc.mu.Unlock()
+}
+
+func (tc *txContext) getAfterCommit() []func(ctx context.Context) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.afterCommit
+}
+
+// Transaction execute fn in a transaction, the transaction is stored in the ctx of fn,
+// get it by GetDB(ctx, db), so the dao methods called in fn use the same transaction without passing tx around.
+// the transaction is committed if fn returns nil, otherwise it is rolled back, it is also rolled back if fn panics.
+//
+// nested calls in fn create savepoints in the outer transaction, if the nested fn returns an error,
+// only the changes after the savepoint are rolled back.
+//
+// the functions registered by AfterCommit in fn are executed after the outermost transaction is committed successfully,
+// they are discarded if the transaction or the savepoint is rolled back.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	parent, isNested := ctx.Value(txCtxKey{}).(*txContext)
+	if isNested {
+		db = parent.tx
+	}
+
+	tc := &txContext{}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(ctx, txCtxKey{}, tc)
+		tc.tx = tx.WithContext(txCtx) // the tx carries tc, see TxContext
+		return fn(txCtx)
+	})
+	if err != nil {
+		return err
+	}
+
+	if isNested {
+		parent.addAfterCommit(tc.getAfterCommit()...)
+		return nil
+	}
+	for _, f := range tc.getAfterCommit() {
+		f(ctx)
+	}
+
+	return nil
+}
+
+// GetDB get the transaction in ctx, if ctx is not in a transaction, return db, the result is bound to ctx.
+// the settings of db, e.g. the scatter mode of sharding.WithScatter, are applied to the transaction.
+func GetDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	tc, ok := ctx.Value(txCtxKey{}).(*txContext)
+	if !ok {
+		return db.WithContext(ctx)
+	}
+
+	tx := tc.tx.WithContext(ctx)
+	if db == nil || db.Statement == nil {
+		return tx
+	}
+	isSet := false
+	db.Statement.Settings.Range(func(k, v interface{}) bool {
+		key, ok := k.(string)
+		if ok {
+			tx = tx.Set(key, v) // the first Set copies the statement, the transaction in ctx is not changed
+			isSet = true
+		}
+		return true
+	})
+	if isSet {
+		tx = tx.Session(&gorm.Session{})
+	}
+	return tx
+}
+
+// InTransaction whether ctx is in a transaction
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txCtxKey{}).(*txContext)
+	return ok
+}
+
+// TxContext bind ctx to the transaction of tx if tx is got by GetDB in Transaction,
+// so that the functions registered by AfterCommit(ctx) are executed after the transaction is committed,
+// otherwise, e.g. tx is created by gorm.DB.Transaction, ctx is returned as it is.
+func TxContext(ctx context.Context, tx *gorm.DB) context.Context {
+	if InTransaction(ctx) || tx == nil || tx.Statement == nil || tx.Statement.Context == nil {
+		return ctx
+	}
+	if tc, ok := tx.Statement.Context.Value(txCtxKey{}).(*txContext); ok {
+		return context.WithValue(ctx, txCtxKey{}, tc)
+	}
+	return ctx
+}
+
+// AfterCommit register fn to be executed after the transaction in ctx is committed, e.g. delete cache,
+// if ctx is not in a transaction, fn is executed immediately, it is safe for concurrent use.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if tc, ok := ctx.Value(txCtxKey{}).(*txContext); ok {
+		tc.addAfterCommit(fn)
+		return
+	}
+	fn(ctx)
+}
@@ -0,0 +1,179 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type txUser struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string
+}
+
+func newTxDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1) // every connection of memory database is a new database
+	if err = db.AutoMigrate(&txUser{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func countTxUsers(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	if err := db.Model(&txUser{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestTransaction(t *testing.T) {
+	db := newTxDB(t)
+	ctx := context.Background()
+	assert.False(t, InTransaction(ctx))
+
+	var deleted []string
+	err := Transaction(ctx, db, func(ctx context.Context) error {
+		assert.True(t, InTransaction(ctx))
+		if err := GetDB(ctx, db).Create(&txUser{Name: "foo"}).Error; err != nil {
+			return err
+		}
+		AfterCommit(ctx, func(ctx context.Context) {
+			deleted = append(deleted, "foo")
+		})
+
+		// the savepoint is committed
+		err := Transaction(ctx, db, func(ctx context.Context) error {
+			AfterCommit(ctx, func(ctx context.Context) {
+				deleted = append(deleted, "bar")
+			})
+			return GetDB(ctx, db).Create(&txUser{Name: "bar"}).Error
+		})
+		if err != nil {
+			return err
+		}
+
+		// the savepoint is rolled back, the outer transaction continues
+		err = Transaction(ctx, db, func(ctx context.Context) error {
+			AfterCommit(ctx, func(ctx context.Context) {
+				deleted = append(deleted, "baz")
+			})
+			if err := GetDB(ctx, db).Create(&txUser{Name: "baz"}).Error; err != nil {
+				return err
+			}
+			return errors.New("mock error")
+		})
+		assert.Error(t, err)
+
+		assert.Empty(t, deleted) // not executed before commit
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, deleted)
+	assert.Equal(t, int64(2), countTxUsers(t, db))
+}
+
+func TestTransaction_rollback(t *testing.T) {
+	db := newTxDB(t)
+	ctx := context.Background()
+
+	executed := false
+	err := Transaction(ctx, db, func(ctx context.Context) error {
+		AfterCommit(ctx, func(ctx context.Context) {
+			executed = true
+		})
+		if err := GetDB(ctx, db).Create(&txUser{Name: "foo"}).Error; err != nil {
+			return err
+		}
+		return errors.New("mock error")
+	})
+	assert.Error(t, err)
+	assert.False(t, executed)
+	assert.Equal(t, int64(0), countTxUsers(t, db))
+
+	// rollback after panic
+	assert.Panics(t, func() {
+		_ = Transaction(ctx, db, func(ctx context.Context) error {
+			_ = GetDB(ctx, db).Create(&txUser{Name: "foo"}).Error
+			panic("mock panic")
+		})
+	})
+	assert.Equal(t, int64(0), countTxUsers(t, db))
+}
+
+func TestAfterCommit(t *testing.T) {
+	executed := false
+	AfterCommit(context.Background(), func(ctx context.Context) {
+		executed = true
+	})
+	assert.True(t, executed)
+
+	// register concurrently
+	db := newTxDB(t)
+	var count int32
+	err := Transaction(context.Background(), db, func(ctx context.Context) error {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				AfterCommit(ctx, func(ctx context.Context) {
+					atomic.AddInt32(&count, 1)
+				})
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(100), count)
+}
+
+func TestTxContext(t *testing.T) {
+	db := newTxDB(t)
+	ctx := context.Background()
+
+	// the tx got by GetDB in Transaction, fn is executed after commit
+	executed := false
+	err := Transaction(ctx, db, func(txCtx context.Context) error {
+		tx := GetDB(txCtx, db)
+		AfterCommit(TxContext(ctx, tx), func(ctx context.Context) {
+			executed = true
+		})
+		assert.False(t, executed)
+		return tx.Create(&txUser{Name: "foo"}).Error
+	})
+	assert.NoError(t, err)
+	assert.True(t, executed)
+
+	// rolled back, fn is discarded
+	executed = false
+	err = Transaction(ctx, db, func(txCtx context.Context) error {
+		AfterCommit(TxContext(ctx, GetDB(txCtx, db)), func(ctx context.Context) {
+			executed = true
+		})
+		return errors.New("mock error")
+	})
+	assert.Error(t, err)
+	assert.False(t, executed)
+
+	// the tx is not created by Transaction, ctx is returned as it is
+	err = db.Transaction(func(tx *gorm.DB) error {
+		assert.False(t, InTransaction(TxContext(ctx, tx)))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, TxContext(ctx, nil))
+}
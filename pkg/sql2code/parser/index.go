@@ -0,0 +1,365 @@
+package parser
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/blastrain/vitess-sqlparser/tidbparser/ast"
+)
+
+// the methods of the dao, handler and service which cannot be generated by the indexes
+var reservedIndexMethods = map[string]struct{}{
+	"GetByID": {}, "GetByIDs": {}, "GetByCondition": {}, "GetByColumns": {}, "GetByIDWithPreload": {},
+	"ListByIDs": {}, "ListDeleted": {},
+}
+
+// tmplIndex unique or secondary index of the table, the lookup methods are generated for each index,
+// GetBy<Name> for the unique index, ListBy<Name> with paging for the non-unique index.
+type tmplIndex struct {
+	Name     string // camel names of the columns, e.g. Email, TenantIDStatus
+	Table    string // camel name of the table, e.g. User
+	IsUnique bool
+	Fields   []tmplIndexField
+}
+
+// tmplIndexField column of the index
+type tmplIndexField struct {
+	Name      string // field name in model, e.g. TenantID
+	ColName   string // e.g. tenant_id
+	JSONName  string
+	GoType    string // go type in model, e.g. sql.NullString
+	KeyType   string // go type of the parameter, e.g. string
+	ProtoType string // type in proto, e.g. int32
+	PbName    string // field name of the proto message in go, e.g. TenantId
+	Param     string // parameter name, e.g. tenantID
+	Comment   string
+}
+
+// Method name of the dao method, e.g. GetByEmail, ListByTenantIDStatus
+func (i *tmplIndex) Method() string {
+	if i.IsUnique {
+		return "GetBy" + i.Name
+	}
+	return "ListBy" + i.Name
+}
+
+// Message prefix of the names of the request and reply messages in proto, e.g. GetUserByEmail, ListUserByTenantIDStatus
+func (i *tmplIndex) Message() string {
+	if i.IsUnique {
+		return "Get" + i.Table + "By" + i.Name
+	}
+	return "List" + i.Table + "By" + i.Name
+}
+
+// FieldNumber the proto field number of the i-th column
+func (i *tmplIndex) FieldNumber(n int) int {
+	return n + 1
+}
+
+// ParamsNumber the proto field number of the query parameters
+func (i *tmplIndex) ParamsNumber() int {
+	return len(i.Fields) + 1
+}
+
+// Key name of the index in cache, e.g. email, tenant_id:code
+func (i *tmplIndex) Key() string {
+	columns := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		columns = append(columns, f.ColName)
+	}
+	return strings.Join(columns, ":")
+}
+
+// Columns names of the columns, e.g. email, (tenant_id, status)
+func (i *tmplIndex) Columns() string {
+	if len(i.Fields) == 1 {
+		return i.Fields[0].ColName
+	}
+	return "(" + strings.ReplaceAll(i.Key(), ":", ", ") + ")"
+}
+
+// Path route path of the index, e.g. email, tenantId/status
+func (i *tmplIndex) Path() string {
+	names := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		names = append(names, f.JSONName)
+	}
+	return strings.Join(names, "/")
+}
+
+// Params parameters of the dao method, e.g. tenantID uint64, status int
+func (i *tmplIndex) Params() string {
+	params := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		params = append(params, f.Param+" "+f.KeyType)
+	}
+	return strings.Join(params, ", ")
+}
+
+// Args arguments of calling the dao method, e.g. tenantID, status
+func (i *tmplIndex) Args() string {
+	args := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		args = append(args, f.Param)
+	}
+	return strings.Join(args, ", ")
+}
+
+// FormArgs arguments of calling the dao method from the fields of the request struct, e.g. form.TenantID, form.Status
+func (i *tmplIndex) FormArgs(form string) string {
+	args := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		args = append(args, form+"."+f.Name)
+	}
+	return strings.Join(args, ", ")
+}
+
+// PbArgs arguments of calling the dao method from the fields of the proto message, e.g. req.TenantId, int(req.Status)
+func (i *tmplIndex) PbArgs(req string) string {
+	args := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		if f.KeyType == f.ProtoType {
+			args = append(args, req+"."+f.PbName)
+		} else {
+			args = append(args, f.KeyType+"("+req+"."+f.PbName+")")
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// Where query condition of the index, e.g. tenant_id = ? AND status = ?
+func (i *tmplIndex) Where() string {
+	conditions := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		conditions = append(conditions, f.ColName+" = ?")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// Match expression of checking whether the record matches the parameters, e.g. record.Email == email
+func (i *tmplIndex) Match(record string) string {
+	conditions := make([]string, 0, len(i.Fields))
+	for _, f := range i.Fields {
+		field := record + "." + f.Name
+		switch {
+		case strings.HasPrefix(f.GoType, "*"):
+			conditions = append(conditions, field+" != nil && *"+field+" == "+f.Param)
+		case strings.HasPrefix(f.GoType, "sql.Null"):
+			conditions = append(conditions, field+".Valid && "+field+"."+strings.TrimPrefix(f.GoType, "sql.Null")+" == "+f.Param)
+		default:
+			conditions = append(conditions, field+" == "+f.Param)
+		}
+	}
+	return strings.Join(conditions, " && ")
+}
+
+// SwaggerType type of the query parameter in swagger
+func (f tmplIndexField) SwaggerType() string {
+	switch f.KeyType {
+	case "string":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "float32", "float64":
+		return "number"
+	}
+	return "integer"
+}
+
+// makeIndexes get the unique and secondary indexes of the table, the primary key, fulltext index, duplicate indexes
+// and the indexes with the columns which cannot be used as parameters are ignored, the deleted_at column of the
+// index is ignored because the soft deleted records are filtered out by gorm.
+func makeIndexes(stmt *ast.CreateTableStmt, data tmplData) []*tmplIndex {
+	type indexDef struct {
+		columns  []string
+		isUnique bool
+	}
+	var defs []indexDef
+	for _, col := range stmt.Cols {
+		for _, o := range col.Options {
+			if o.Tp == ast.ColumnOptionUniqKey {
+				defs = append(defs, indexDef{columns: []string{col.Name.Name.String()}, isUnique: true})
+			}
+		}
+	}
+	for _, con := range stmt.Constraints {
+		def := indexDef{}
+		switch con.Tp {
+		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+			def.isUnique = true
+		case ast.ConstraintKey, ast.ConstraintIndex:
+		default:
+			continue
+		}
+		for _, key := range con.Keys {
+			def.columns = append(def.columns, key.Column.Name.String())
+		}
+		defs = append(defs, def)
+	}
+
+	fields := make(map[string]tmplField, len(data.Fields))
+	for _, field := range data.Fields {
+		fields[field.ColName] = field
+	}
+	primaryKey := ""
+	if primaryKeys := getPrimaryKeys(stmt); len(primaryKeys) == 1 {
+		primaryKey = primaryKeys[0]
+	}
+
+	var indexes []*tmplIndex
+	methods := map[string]bool{}
+	for _, def := range defs {
+		index := &tmplIndex{Table: data.TableName, IsUnique: def.isUnique}
+		isValid := len(def.columns) > 0
+		for _, column := range def.columns {
+			if column == columnDeletedAt {
+				continue
+			}
+			field, ok := fields[column]
+			keyType := keyGoType(field.GoType)
+			if !ok || column == primaryKey || !isIndexKeyType(keyType) {
+				isValid = false
+				break
+			}
+			protoType := goTypeToProto([]tmplField{{GoType: keyType}})[0].GoType
+			index.Name += field.Name
+			index.Fields = append(index.Fields, tmplIndexField{
+				Name:      field.Name,
+				ColName:   column,
+				JSONName:  field.JSONName,
+				GoType:    field.GoType,
+				KeyType:   keyType,
+				ProtoType: protoType,
+				PbName:    pbFieldName(field.JSONName),
+				Param:     firstLetterToLow(field.Name),
+				Comment:   field.Comment,
+			})
+		}
+		if !isValid || len(index.Fields) == 0 {
+			continue
+		}
+		method := index.Method()
+		if _, ok := reservedIndexMethods[method]; ok || methods[method] {
+			continue
+		}
+		methods[method] = true
+		indexes = append(indexes, index)
+	}
+
+	// the records of the columns which have a unique index are got by the unique index only
+	newIndexes := make([]*tmplIndex, 0, len(indexes))
+	for _, index := range indexes {
+		if !index.IsUnique && methods["GetBy"+index.Name] {
+			continue
+		}
+		newIndexes = append(newIndexes, index)
+	}
+	return newIndexes
+}
+
+func isIndexKeyType(goType string) bool {
+	switch goType {
+	case "string", "bool", "int32", "int64", "int", "uint32", "uint64", "uint", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// pbFieldName the field name of the proto message generated by protoc-gen-go, e.g. tenant_id and tenantId are TenantId
+func pbFieldName(name string) string {
+	builder := strings.Builder{}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+			continue
+		case isASCIILower(c) && (i == 0 || name[i-1] == '_' || isASCIIDigit(name[i-1])):
+			builder.WriteByte(c - 'a' + 'A')
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// indexCode lookup methods of the indexes of a table
+type indexCode struct {
+	dao              string
+	daoInterface     string
+	handler          string
+	handlerInterface string
+	router           string
+	routerMock       string
+	handlerPb        string
+	service          string
+}
+
+func getIndexCode(data tmplData) (*indexCode, error) {
+	if len(data.Indexes) == 0 {
+		return nil, nil
+	}
+
+	code := &indexCode{}
+	for _, v := range []struct {
+		tmpl     *template.Template
+		code     *string
+		isFormat bool
+	}{
+		{daoIndexTmpl, &code.dao, true},
+		{daoIndexInterfaceTmpl, &code.daoInterface, false},
+		{handlerIndexTmpl, &code.handler, true},
+		{handlerIndexInterfaceTmpl, &code.handlerInterface, false},
+		{routerIndexTmpl, &code.router, false},
+		{routerIndexMockTmpl, &code.routerMock, true},
+		{handlerPbIndexTmpl, &code.handlerPb, true},
+		{serviceIndexTmpl, &code.service, true},
+	} {
+		builder := strings.Builder{}
+		err := data.templates.get(v.tmpl).Execute(&builder, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s error: %v", v.tmpl.Name(), err)
+		}
+		*v.code = strings.TrimLeft(builder.String(), "\n")
+		if v.isFormat {
+			formatCode, err := format.Source([]byte(*v.code))
+			if err != nil {
+				return nil, fmt.Errorf("%s format.Source error: %v", v.tmpl.Name(), err)
+			}
+			*v.code = string(formatCode)
+		}
+	}
+
+	return code, nil
+}
+
+// joinIndexCodes join the index codes of the tables by code type
+func joinIndexCodes(codes []*indexCode) map[string]string {
+	joinCodes := func(sep string, get func(c *indexCode) string) string {
+		ss := make([]string, 0, len(codes))
+		for _, c := range codes {
+			ss = append(ss, get(c))
+		}
+		return strings.Join(ss, sep)
+	}
+
+	return map[string]string{
+		CodeTypeDAOIndex:              joinCodes("\n\n", func(c *indexCode) string { return c.dao }),
+		CodeTypeDAOIndexInterface:     joinCodes("\n", func(c *indexCode) string { return c.daoInterface }),
+		CodeTypeHandlerIndex:          joinCodes("\n\n", func(c *indexCode) string { return c.handler }),
+		CodeTypeHandlerIndexInterface: joinCodes("\n", func(c *indexCode) string { return c.handlerInterface }),
+		CodeTypeRouterIndex:           joinCodes("\n", func(c *indexCode) string { return c.router }),
+		CodeTypeRouterIndexMock:       joinCodes("\n", func(c *indexCode) string { return c.routerMock }),
+		CodeTypeHandlerPbIndex:        joinCodes("\n\n", func(c *indexCode) string { return c.handlerPb }),
+		CodeTypeServiceIndex:          joinCodes("\n\n", func(c *indexCode) string { return c.service }),
+	}
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var indexSQL = "CREATE TABLE `account` (\n" +
+	"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `created_at` datetime DEFAULT NULL,\n" +
+	"  `updated_at` datetime DEFAULT NULL,\n" +
+	"  `deleted_at` datetime DEFAULT NULL,\n" +
+	"  `tenant_id` bigint unsigned NOT NULL,\n" +
+	"  `email` varchar(100) NOT NULL COMMENT 'email',\n" +
+	"  `phone` varchar(20) UNIQUE,\n" +
+	"  `status` tinyint NOT NULL,\n" +
+	"  `nickname` varchar(50) DEFAULT NULL,\n" +
+	"  `login_at` datetime DEFAULT NULL,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  UNIQUE KEY `uk_email` (`email`, `deleted_at`),\n" +
+	"  UNIQUE KEY `uk_id` (`id`),\n" +
+	"  KEY `idx_email` (`email`),\n" +
+	"  KEY `idx_tenant_status` (`tenant_id`, `status`),\n" +
+	"  KEY `idx_tenant_status2` (`tenant_id`, `status`),\n" +
+	"  KEY `idx_login_at` (`login_at`),\n" +
+	"  KEY `idx_nickname` (`nickname`)\n" +
+	");"
+
+func TestParseSQL_indexes(t *testing.T) {
+	codes, err := ParseSQL(indexSQL, WithJSONTag(1), WithEmbed())
+	assert.Nil(t, err)
+
+	daoInterface := codes[CodeTypeDAOIndexInterface]
+	assert.Equal(t, "\tGetByPhone(ctx context.Context, phone string) (*model.Account, error)\n"+
+		"\tGetByEmail(ctx context.Context, email string) (*model.Account, error)\n"+
+		"\tListByTenantIDStatus(ctx context.Context, tenantID uint64, status int, params *query.Params) ([]*model.Account, int64, error)\n"+
+		"\tListByNickname(ctx context.Context, nickname string, params *query.Params) ([]*model.Account, int64, error)", daoInterface)
+	assert.NotContains(t, daoInterface, "ListByEmail")   // the unique index of the same columns is used
+	assert.NotContains(t, daoInterface, "ListByLoginAt") // time is not supported as parameter
+	assert.NotContains(t, daoInterface, "GetByID(")      // the primary key is ignored
+
+	dao := codes[CodeTypeDAOIndex]
+	assert.Contains(t, dao, `d.cache.GetIndexID(ctx, "email", email)`)
+	assert.Contains(t, dao, `record.Email == email`)
+	assert.Contains(t, dao, `mysql.GetDB(ctx, d.db).Where("email = ?", email).First(table)`)
+	assert.Contains(t, dao, `d.cache.SetIndexID(ctx, table.ID, cache.AccountExpireTime, "email", email)`)
+	assert.Contains(t, dao, `return db.Where("tenant_id = ? AND status = ?", tenantID, status)`)
+
+	assert.Contains(t, codes[CodeTypeHandler], "type GetAccountByEmailRequest struct {\n\tEmail  string `form:\"email\" binding:\"\"` // email\n}")
+	assert.Contains(t, codes[CodeTypeHandler], "type ListAccountsByTenantIDStatusRequest struct {")
+	assert.Contains(t, codes[CodeTypeHandlerIndex], "// @Router /api/v1/account/by/email [get]")
+	assert.Contains(t, codes[CodeTypeHandlerIndex], "h.iDao.ListByTenantIDStatus(ctx, form.TenantID, form.Status, &form.Params)")
+	assert.Equal(t, "\tgroup.GET(\"/account/by/phone\", h.GetByPhone)\n"+
+		"\tgroup.GET(\"/account/by/email\", h.GetByEmail)\n"+
+		"\tgroup.POST(\"/account/list/by/tenantId/status\", h.ListByTenantIDStatus)\n"+
+		"\tgroup.POST(\"/account/list/by/nickname\", h.ListByNickname)", codes[CodeTypeRouterIndex])
+	assert.Contains(t, codes[CodeTypeRouterIndexMock], "func (u mock) ListByTenantIDStatus(c *gin.Context) { return }")
+	assert.Contains(t, codes[CodeTypeHandlerPbIndex], "h.accountDao.ListByTenantIDStatus(ctx, req.TenantId, int(req.Status), params)")
+	assert.Contains(t, codes[CodeTypeServiceIndex], "s.iDao.GetByEmail(ctx, req.Email)")
+
+	proto := codes[CodeTypeProto]
+	assert.Contains(t, proto, "rpc GetByEmail(GetAccountByEmailRequest) returns (GetAccountByEmailReply) {}")
+	assert.Contains(t, proto, "message ListAccountByTenantIDStatusRequest {\n  uint64 tenantId = 1;\n  int32 status = 2;\n  types.Params params = 3;\n}")
+	codes, err = ParseSQL(indexSQL, WithJSONTag(1), WithWebProto())
+	assert.Nil(t, err)
+	assert.Contains(t, codes[CodeTypeProto], `get: "/api/v1/account/by/email"`)
+	assert.Contains(t, codes[CodeTypeProto], `string email = 1 [(tagger.tags) = "form:\"email\"" ];`)
+
+	// no index
+	codes, err = ParseSQL("CREATE TABLE `foo` (`id` int NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` varchar(10));")
+	assert.Nil(t, err)
+	for _, codeType := range []string{CodeTypeDAOIndex, CodeTypeDAOIndexInterface, CodeTypeHandlerIndex, CodeTypeRouterIndex, CodeTypeServiceIndex} {
+		_, ok := codes[codeType]
+		assert.False(t, ok, codeType)
+	}
+}
+
+func TestParseSQL_postgresqlIndexes(t *testing.T) {
+	sql := `CREATE TABLE account (
+    id bigserial PRIMARY KEY,
+    tenant_id bigint NOT NULL,
+    code varchar(20) NOT NULL,
+    email varchar(100) NOT NULL,
+    phone varchar(20),
+    CONSTRAINT uk_tenant_code UNIQUE (tenant_id, code)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_email ON ONLY public.account USING btree (lower(email));
+CREATE INDEX idx_tenant ON account(tenant_id);
+ALTER TABLE ONLY public.account ADD CONSTRAINT account_phone_key UNIQUE(phone);`
+
+	ddl, _, err := convertPostgresqlDDL(sql)
+	assert.Nil(t, err)
+	assert.Contains(t, ddl, "  UNIQUE KEY (`tenant_id`, `code`),\n  KEY `idx_tenant` (`tenant_id`),\n  UNIQUE KEY (`phone`)\n")
+	assert.NotContains(t, ddl, "email`)") // the index of expression is ignored
+
+	codes, err := ParseSQL(sql, WithDBDriver(DBDriverPostgresql))
+	assert.Nil(t, err)
+	assert.Contains(t, codes[CodeTypeDAOIndexInterface], "GetByTenantIDCode(ctx context.Context, tenantID int64, code string)")
+	assert.Contains(t, codes[CodeTypeDAOIndexInterface], "ListByTenantID(ctx context.Context, tenantID int64, params *query.Params)")
+	assert.Contains(t, codes[CodeTypeDAOIndexInterface], "GetByPhone(ctx context.Context, phone string)")
+}
+
+func Test_pbFieldName(t *testing.T) {
+	testData := map[string]string{
+		"tenant_id":  "TenantId",
+		"tenantId":   "TenantId",
+		"email":      "Email",
+		"ip_v4":      "IpV4",
+		"user2_name": "User2Name",
+	}
+	for name, want := range testData {
+		assert.Equal(t, want, pbFieldName(name), name)
+	}
+}
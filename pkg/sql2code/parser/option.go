@@ -27,11 +27,24 @@ type options struct {
 	ForceTableName bool
 	IsEmbed        bool // is gorm.Model embedded
 	IsWebProto     bool // true: proto file include router path and swagger info, false: normal proto file without router and swagger
+
+	ShardingKey       string // column name of the sharding key
+	ShardingAlgorithm string // sharding algorithm, e.g. mod:64
+
+	DBDriver  string                          // database driver of the sql, mysql or postgres
+	pgColumns map[string]map[string]*pgColumn // postgresql information of the columns, table -> column -> info
+
+	RelatedSQL string                     // ddl of the other tables used to generate the associations
+	relations  map[string][]*tmplRelation // associations of the tables, table -> associations
+
+	TemplateDir string        // directory of the templates which override the built-in templates
+	templates   tmplOverrides // templates loaded from the template directory
 }
 
 var defaultOptions = options{
 	NullStyle: NullInSql,
 	Package:   "model",
+	DBDriver:  DBDriverMysql,
 }
 
 // WithCharset  set charset
@@ -119,6 +132,38 @@ func WithWebProto() Option {
 	}
 }
 
+// WithSharding set the sharding key and algorithm, the tables containing the key column are sharded,
+// the algorithm is one of mod:n, hash:n, day:start:end, month:start:end, year:start:end
+func WithSharding(key string, algorithm string) Option {
+	return func(o *options) {
+		o.ShardingKey = key
+		o.ShardingAlgorithm = algorithm
+	}
+}
+
+// WithDBDriver set the database driver of the sql, mysql or postgres, default is mysql
+func WithDBDriver(driver string) Option {
+	return func(o *options) {
+		o.DBDriver = driver
+	}
+}
+
+// WithRelatedSQL set the ddl of the other tables, the associations between the tables in the sql and
+// the related sql are generated, but the codes of the related tables are not generated
+func WithRelatedSQL(sql string) Option {
+	return func(o *options) {
+		o.RelatedSQL = sql
+	}
+}
+
+// WithTemplateDir set the directory of the templates, the <name>.tmpl file in the directory overrides the
+// built-in template of the same name, the other built-in templates are still used
+func WithTemplateDir(dir string) Option {
+	return func(o *options) {
+		o.TemplateDir = dir
+	}
+}
+
 func parseOption(options []Option) options {
 	o := defaultOptions
 	for _, f := range options {
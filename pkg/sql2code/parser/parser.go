@@ -17,6 +17,8 @@ import (
 	"github.com/blastrain/vitess-sqlparser/tidbparser/parser"
 	"github.com/huandu/xstrings"
 	"github.com/jinzhu/inflection"
+
+	"github.com/hankyu66/sponge/pkg/mysql/sharding"
 )
 
 const (
@@ -28,12 +30,47 @@ const (
 	CodeTypeJSON = "json"
 	// CodeTypeDAO update fields code
 	CodeTypeDAO = "dao"
+	// CodeTypeDAOColumns allow-list of the query columns code
+	CodeTypeDAOColumns = "daoColumns"
+	// CodeTypeDAORelation dao methods of the associations code
+	CodeTypeDAORelation = "daoRelation"
+	// CodeTypeDAORelationInterface declaration of the dao methods of the associations code
+	CodeTypeDAORelationInterface = "daoRelationInterface"
+	// CodeTypeDAOIndex dao lookup methods of the unique and secondary indexes code
+	CodeTypeDAOIndex = "daoIndex"
+	// CodeTypeDAOIndexInterface declaration of the dao lookup methods of the indexes code
+	CodeTypeDAOIndexInterface = "daoIndexInterface"
 	// CodeTypeHandler handler request and respond code
 	CodeTypeHandler = "handler"
+	// CodeTypeHandlerIndex http handler methods of the indexes code
+	CodeTypeHandlerIndex = "handlerIndex"
+	// CodeTypeHandlerIndexInterface declaration of the http handler methods of the indexes code
+	CodeTypeHandlerIndexInterface = "handlerIndexInterface"
+	// CodeTypeRouterIndex routes of the http handler methods of the indexes code
+	CodeTypeRouterIndex = "routerIndex"
+	// CodeTypeRouterIndexMock methods of the mock handler in the router test of the indexes code
+	CodeTypeRouterIndexMock = "routerIndexMock"
+	// CodeTypeHandlerPbIndex http handler methods of the indexes based on protobuf code
+	CodeTypeHandlerPbIndex = "handlerPbIndex"
 	// CodeTypeProto proto file code
 	CodeTypeProto = "proto"
 	// CodeTypeService grpc service code
 	CodeTypeService = "service"
+	// CodeTypeServiceIndex grpc service methods of the indexes code
+	CodeTypeServiceIndex = "serviceIndex"
+	// SoftDelete whether the table has the deleted_at column, the value is "true" or "false"
+	SoftDelete = "__soft_delete__"
+	// Sharding whether the table has the sharding key column, the value is "true" or "false"
+	Sharding = "__sharding__"
+	// DBDriver database driver of the sql, the value is "mysql" or "postgres"
+	DBDriver = "__db_driver__"
+
+	// DBDriverMysql mysql driver
+	DBDriverMysql = "mysql"
+	// DBDriverPostgresql postgresql driver
+	DBDriverPostgresql = "postgres"
+
+	shardingImportPath = "github.com/hankyu66/sponge/pkg/mysql/sharding"
 )
 
 // Codes content
@@ -55,35 +92,73 @@ type modelCodes struct {
 func ParseSQL(sql string, options ...Option) (map[string]string, error) {
 	initTemplate()
 	opt := parseOption(options)
-
-	stmts, err := parser.New().Parse(sql, opt.Charset, opt.Collation)
+	if opt.ShardingKey != "" {
+		if _, err := sharding.ParseAlgorithm(opt.ShardingAlgorithm); err != nil {
+			return nil, err
+		}
+	}
+	if opt.TemplateDir != "" {
+		templates, err := loadTemplateDir(opt.TemplateDir)
+		if err != nil {
+			return nil, err
+		}
+		opt.templates = templates
+	}
+	stmts, err := parseCreateTables(sql, &opt)
+	if err != nil {
+		return nil, err
+	}
+	tableStmts := stmts
+	if opt.RelatedSQL != "" {
+		relatedStmts, err := parseCreateTables(opt.RelatedSQL, &opt)
+		if err != nil {
+			return nil, err
+		}
+		tableStmts = mergeCreateTables(stmts, relatedStmts)
+	}
+	opt.relations, err = makeRelations(tableStmts, opt)
 	if err != nil {
 		return nil, err
 	}
+
 	modelStructCodes := make([]string, 0, len(stmts))
 	updateFieldsCodes := make([]string, 0, len(stmts))
+	daoColumnsCodes := make([]string, 0, len(stmts))
+	daoRelationCodes := make([]string, 0, len(stmts))
+	daoRelationInterfaceCodes := make([]string, 0, len(stmts))
+	indexCodes := make([]*indexCode, 0, len(stmts))
 	handlerStructCodes := make([]string, 0, len(stmts))
 	protoFileCodes := make([]string, 0, len(stmts))
 	serviceStructCodes := make([]string, 0, len(stmts))
 	modelJSONCodes := make([]string, 0, len(stmts))
 	importPath := make(map[string]struct{})
 	tableNames := make([]string, 0, len(stmts))
-	for _, stmt := range stmts {
-		if ct, ok := stmt.(*ast.CreateTableStmt); ok {
-			code, err2 := makeCode(ct, opt)
-			if err2 != nil {
-				return nil, err2
-			}
-			modelStructCodes = append(modelStructCodes, code.modelStruct)
-			updateFieldsCodes = append(updateFieldsCodes, code.updateFields)
-			handlerStructCodes = append(handlerStructCodes, code.handlerStruct)
-			protoFileCodes = append(protoFileCodes, code.protoFile)
-			serviceStructCodes = append(serviceStructCodes, code.serviceStruct)
-			modelJSONCodes = append(modelJSONCodes, code.modelJSON)
-			tableNames = append(tableNames, toCamel(ct.Table.Name.String()))
-			for _, s := range code.importPaths {
-				importPath[s] = struct{}{}
-			}
+	softDelete := true
+	isSharding := true
+	for _, ct := range stmts {
+		code, err2 := makeCode(ct, opt)
+		if err2 != nil {
+			return nil, err2
+		}
+		modelStructCodes = append(modelStructCodes, code.modelStruct)
+		updateFieldsCodes = append(updateFieldsCodes, code.updateFields)
+		daoColumnsCodes = append(daoColumnsCodes, code.daoColumns)
+		if code.daoRelation != "" {
+			daoRelationCodes = append(daoRelationCodes, code.daoRelation)
+			daoRelationInterfaceCodes = append(daoRelationInterfaceCodes, code.daoRelationInterface)
+		}
+		if code.index != nil {
+			indexCodes = append(indexCodes, code.index)
+		}
+		handlerStructCodes = append(handlerStructCodes, code.handlerStruct)
+		protoFileCodes = append(protoFileCodes, code.protoFile)
+		serviceStructCodes = append(serviceStructCodes, code.serviceStruct)
+		modelJSONCodes = append(modelJSONCodes, code.modelJSON)
+		tableNames = append(tableNames, toCamel(ct.Table.Name.String()))
+		softDelete = softDelete && code.softDelete
+		isSharding = isSharding && code.sharding
+		for _, s := range code.importPaths {
+			importPath[s] = struct{}{}
 		}
 	}
 
@@ -98,40 +173,120 @@ func ParseSQL(sql string, options ...Option) (map[string]string, error) {
 		ImportPath: importPathArr,
 		StructCode: modelStructCodes,
 	}
-	modelCode, err := getModelCode(mc)
+	modelCode, err := getModelCode(mc, opt.templates)
 	if err != nil {
 		return nil, err
 	}
 
 	var codesMap = map[string]string{
-		CodeTypeModel:   modelCode,
-		CodeTypeJSON:    strings.Join(modelJSONCodes, "\n\n"),
-		CodeTypeDAO:     strings.Join(updateFieldsCodes, "\n\n"),
-		CodeTypeHandler: strings.Join(handlerStructCodes, "\n\n"),
-		CodeTypeProto:   strings.Join(protoFileCodes, "\n\n"),
-		CodeTypeService: strings.Join(serviceStructCodes, "\n\n"),
-		TableName:       strings.Join(tableNames, ", "),
+		CodeTypeModel:      modelCode,
+		CodeTypeJSON:       strings.Join(modelJSONCodes, "\n\n"),
+		CodeTypeDAO:        strings.Join(updateFieldsCodes, "\n\n"),
+		CodeTypeDAOColumns: strings.Join(daoColumnsCodes, "\n\n"),
+		CodeTypeHandler:    strings.Join(handlerStructCodes, "\n\n"),
+		CodeTypeProto:      strings.Join(protoFileCodes, "\n\n"),
+		CodeTypeService:    strings.Join(serviceStructCodes, "\n\n"),
+		TableName:          strings.Join(tableNames, ", "),
+		SoftDelete:         fmt.Sprintf("%t", softDelete && len(tableNames) > 0),
+		Sharding:           fmt.Sprintf("%t", isSharding && len(tableNames) > 0),
+		DBDriver:           opt.DBDriver,
+	}
+	if len(daoRelationCodes) > 0 { // only the tables with associations have the relation methods
+		codesMap[CodeTypeDAORelation] = strings.Join(daoRelationCodes, "\n\n")
+		codesMap[CodeTypeDAORelationInterface] = strings.Join(daoRelationInterfaceCodes, "\n")
+	}
+	if len(indexCodes) > 0 { // only the tables with unique or secondary indexes have the lookup methods
+		for codeType, code := range joinIndexCodes(indexCodes) {
+			codesMap[codeType] = code
+		}
 	}
 
 	return codesMap, nil
 }
 
+// parseCreateTables parse the CREATE TABLE statements, the postgresql ddl is converted to mysql ddl first
+func parseCreateTables(sql string, opt *options) ([]*ast.CreateTableStmt, error) {
+	switch opt.DBDriver {
+	case DBDriverMysql:
+	case DBDriverPostgresql:
+		mysqlSQL, columns, err := convertPostgresqlDDL(sql)
+		if err != nil {
+			return nil, err
+		}
+		if opt.pgColumns == nil {
+			opt.pgColumns = make(map[string]map[string]*pgColumn, len(columns))
+		}
+		for table, cols := range columns {
+			opt.pgColumns[table] = cols
+		}
+		sql = mysqlSQL
+	default:
+		return nil, fmt.Errorf("unsupported database driver '%s', the driver is mysql or postgres", opt.DBDriver)
+	}
+
+	stmts, err := parser.New().Parse(sql, opt.Charset, opt.Collation)
+	if err != nil {
+		return nil, err
+	}
+	cts := make([]*ast.CreateTableStmt, 0, len(stmts))
+	for _, stmt := range stmts {
+		if ct, ok := stmt.(*ast.CreateTableStmt); ok {
+			cts = append(cts, ct)
+		}
+	}
+	return cts, nil
+}
+
+// the related tables with the same name as the tables are ignored
+func mergeCreateTables(stmts []*ast.CreateTableStmt, relatedStmts []*ast.CreateTableStmt) []*ast.CreateTableStmt {
+	names := make(map[string]struct{}, len(stmts))
+	for _, ct := range stmts {
+		names[ct.Table.Name.String()] = struct{}{}
+	}
+	merged := append([]*ast.CreateTableStmt{}, stmts...)
+	for _, ct := range relatedStmts {
+		if _, ok := names[ct.Table.Name.String()]; !ok {
+			merged = append(merged, ct)
+		}
+	}
+	return merged
+}
+
+// tmplData the data of the templates, the exported fields and methods of tmplData, tmplField, tmplRelation,
+// tmplIndex and tmplIndexField are the contract of the templates in the template directory, they are only
+// added but not changed or removed.
 type tmplData struct {
-	TableName    string
-	TName        string
-	NameFunc     bool
-	RawTableName string
-	Fields       []tmplField
-	Comment      string
+	TableName    string      // camel name of the table without prefix, e.g. UserOrder
+	TName        string      // TableName with the first letter lower case, e.g. userOrder
+	NameFunc     bool        // whether the model has the TableName method
+	RawTableName string      // name of the table, e.g. user_order
+	Fields       []tmplField // columns of the table, the go types are replaced by proto types in the proto templates
+	Comment      string      // comment of the table
+	SoftDelete   bool        // the table has the deleted_at column
+
+	ShardingKey       string // the table has the sharding key column
+	ShardingAlgorithm string
+
+	Relations []*tmplRelation // associations of the table
+	Indexes   []*tmplIndex    // unique and secondary indexes of the table
+
+	templates tmplOverrides // templates which override the built-in templates, not used in the templates
 }
 
+// RelationNumber the proto field number of the i-th association
+func (t tmplData) RelationNumber(i int) int {
+	return len(t.Fields) + i + 1
+}
+
+// tmplField the column of the table
 type tmplField struct {
-	Name     string
-	ColName  string
-	GoType   string
-	Tag      string
-	Comment  string
-	JSONName string
+	Name     string   // camel name of the column without prefix, e.g. UserID
+	ColName  string   // name of the column, e.g. user_id
+	GoType   string   // go type of the column, e.g. int64, *time.Time, sql.NullString
+	Tag      string   // tags of the model field, e.g. gorm:"column:user_id;NOT NULL" json:"userID"
+	Comment  string   // comment of the column
+	JSONName string   // json name of the column, snake case or camel case
+	Rule     tmplRule // validation rules derived from the column constraints
 }
 
 // ConditionZero type of condition 0
@@ -144,6 +299,10 @@ func (t tmplField) ConditionZero() string {
 		return `!= ""`
 	case "time.Time", "*time.Time", "sql.NullTime": //nolint
 		return `.IsZero() == false`
+	case "gorm.DeletedAt": //nolint
+		return `.Valid`
+	case "bool": //nolint
+		return `!= false`
 	}
 
 	return `!= ` + t.GoType
@@ -157,8 +316,10 @@ func (t tmplField) GoZero() string {
 		return `= 0`
 	case "string", "sql.NullString":
 		return `= "string"`
-	case "time.Time", "*time.Time", "sql.NullTime":
+	case "time.Time", "*time.Time", "sql.NullTime", "gorm.DeletedAt":
 		return `= "0000-01-00T00:00:00.000+08:00"`
+	case "bool", "sql.NullBool":
+		return `= false`
 	}
 
 	return `= ` + t.GoType
@@ -172,13 +333,67 @@ func (t tmplField) GoTypeZero() string {
 		return `0`
 	case "string", "sql.NullString":
 		return `""`
-	case "time.Time", "*time.Time", "sql.NullTime":
+	case "time.Time", "*time.Time", "sql.NullTime", "gorm.DeletedAt":
 		return `0 /*time.Now().Second()*/`
+	case "bool", "sql.NullBool":
+		return `false`
 	}
 
 	return t.GoType
 }
 
+// QueryType column type of the query conditions, the id column is always unsigned
+func (t tmplField) QueryType() string {
+	if t.ColName == columnID {
+		return "TypeUint"
+	}
+	switch strings.TrimPrefix(t.GoType, "*") {
+	case "int8", "int16", "int32", "int64", "int", "sql.NullInt32", "sql.NullInt64":
+		return "TypeInt"
+	case "uint8", "uint16", "uint32", "uint64", "uint":
+		return "TypeUint"
+	case "float64", "float32", "sql.NullFloat64":
+		return "TypeFloat"
+	case "bool", "sql.NullBool":
+		return "TypeBool"
+	case "time.Time", "sql.NullTime", "gorm.DeletedAt":
+		return "TypeTime"
+	}
+
+	return "TypeString"
+}
+
+// the column names containing the words are sensitive
+var sensitiveColumnWords = []string{"password", "passwd", "secret", "token", "salt"}
+
+// IsSensitive whether the column is sensitive, e.g. password, secret, token, or the field is not output in json,
+// the sensitive columns are not allowed in query conditions and sort fields by default, otherwise a client can
+// guess the value character by character with like conditions or sorting.
+func (t tmplField) IsSensitive() bool {
+	if strings.Contains(t.Tag, `json:"-"`) {
+		return true
+	}
+	name := strings.ToLower(t.ColName)
+	for _, word := range sensitiveColumnWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsVersion whether the column is the version number of optimistic locking
+func (t tmplField) IsVersion() bool {
+	if t.ColName != columnVersion {
+		return false
+	}
+	switch t.GoType {
+	case "int8", "int16", "int32", "int64", "int", "uint8", "uint16", "uint32", "uint64", "uint":
+		return true
+	}
+	return false
+}
+
 // AddOne counter
 func (t tmplField) AddOne(i int) int {
 	return i + 1
@@ -208,6 +423,7 @@ const (
 	columnCreatedAt  = "created_at"
 	columnUpdatedAt  = "updated_at"
 	columnDeletedAt  = "deleted_at"
+	columnVersion    = "version"
 	columnMysqlModel = __mysqlModel__
 )
 
@@ -231,22 +447,29 @@ func isIgnoreFields(colName string, falseColumn ...string) bool {
 }
 
 type codeText struct {
-	importPaths   []string
-	modelStruct   string
-	modelJSON     string
-	updateFields  string
-	handlerStruct string
-	protoFile     string
-	serviceStruct string
+	importPaths          []string
+	modelStruct          string
+	modelJSON            string
+	updateFields         string
+	daoColumns           string
+	daoRelation          string
+	daoRelationInterface string
+	index                *indexCode
+	handlerStruct        string
+	protoFile            string
+	serviceStruct        string
+	softDelete           bool
+	sharding             bool
 }
 
 // nolint
-func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
+func makeTmplData(stmt *ast.CreateTableStmt, opt options) (tmplData, []string, error) {
 	importPath := make([]string, 0, 1)
 	data := tmplData{
 		TableName:    stmt.Table.Name.String(),
 		RawTableName: stmt.Table.Name.String(),
 		Fields:       make([]tmplField, 0, 1),
+		templates:    opt.templates,
 	}
 	tablePrefix := opt.TablePrefix
 	if tablePrefix != "" && strings.HasPrefix(data.TableName, tablePrefix) {
@@ -292,6 +515,8 @@ func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
 			JSONName: jsonName,
 		}
 
+		pgCol := opt.pgColumns[stmt.Table.Name.String()][colName]
+
 		tags := make([]string, 0, 4)
 		// make GORM's tag
 		gormTag := strings.Builder{}
@@ -299,13 +524,18 @@ func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
 		gormTag.WriteString(colName)
 		if opt.GormType {
 			gormTag.WriteString(";type:")
-			gormTag.WriteString(col.Tp.InfoSchemaStr())
+			if pgCol != nil {
+				gormTag.WriteString(pgCol.dbType)
+			} else {
+				gormTag.WriteString(col.Tp.InfoSchemaStr())
+			}
 		}
 		if isPrimaryKey[colName] {
 			gormTag.WriteString(";primary_key")
 		}
 		isNotNull := false
 		canNull := false
+		hasDefault := false
 		for _, o := range col.Options {
 			switch o.Tp {
 			case ast.ColumnOptionPrimaryKey:
@@ -316,8 +546,10 @@ func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
 			case ast.ColumnOptionNotNull:
 				isNotNull = true
 			case ast.ColumnOptionAutoIncrement:
+				hasDefault = true
 				gormTag.WriteString(";AUTO_INCREMENT")
 			case ast.ColumnOptionDefaultValue:
+				hasDefault = true
 				if value := getDefaultValue(o.Expr); value != "" {
 					gormTag.WriteString(";default:")
 					gormTag.WriteString(value)
@@ -335,6 +567,11 @@ func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
 				//return "", nil, errors.Errorf(" unsupport option %d\n", o.Tp)
 			}
 		}
+		if pgCol != nil && pgCol.defaultValue != "" {
+			hasDefault = true
+			gormTag.WriteString(";default:")
+			gormTag.WriteString(pgCol.defaultValue)
+		}
 		if !isPrimaryKey[colName] && isNotNull {
 			gormTag.WriteString(";NOT NULL")
 		}
@@ -352,19 +589,69 @@ func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
 			nullStyle = NullDisable
 		}
 		goType, pkg := mysqlToGoType(col.Tp, nullStyle)
+		if pgCol != nil && pgCol.isBool {
+			goType, pkg = boolGoType(nullStyle)
+		}
+		if colName == columnID && isPrimaryKey[colName] {
+			// the id is uint64 in the model, dao, request and response types, including the nested types of the associations
+			goType, pkg = "uint64", ""
+		}
+		if colName == columnDeletedAt {
+			// the soft delete of gorm only works with the gorm.DeletedAt type, the embedded mysql.Model has the field
+			goType, pkg = "gorm.DeletedAt", ""
+			if !opt.IsEmbed {
+				pkg = "gorm.io/gorm"
+			}
+			data.SoftDelete = true
+		}
 		if pkg != "" {
 			importPath = append(importPath, pkg)
 		}
 		field.GoType = goType
+		if !isPrimaryKey[colName] {
+			field.Rule = makeRule(col.Tp, isNotNull, hasDefault, pgCol)
+		}
+		if opt.ShardingKey != "" && colName == opt.ShardingKey {
+			data.ShardingKey = opt.ShardingKey
+			data.ShardingAlgorithm = opt.ShardingAlgorithm
+			importPath = append(importPath, shardingImportPath)
+		}
 
 		data.Fields = append(data.Fields, field)
 	}
 
+	return data, importPath, nil
+}
+
+// nolint
+func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
+	data, importPath, err := makeTmplData(stmt, opt)
+	if err != nil {
+		return nil, err
+	}
+	data.Relations = opt.relations[data.RawTableName]
+	data.Indexes = makeIndexes(stmt, data)
+
 	updateFieldsCode, err := getUpdateFieldsCode(data, opt.IsEmbed)
 	if err != nil {
 		return nil, err
 	}
 
+	daoColumnsCode, err := getDAOColumnsCode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	daoRelationCode, daoRelationInterfaceCode, err := getDAORelationCode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	indexCode, err := getIndexCode(data)
+	if err != nil {
+		return nil, err
+	}
+
 	handlerStructCode, err := getHandlerStructCodes(data)
 	if err != nil {
 		return nil, err
@@ -391,13 +678,19 @@ func makeCode(stmt *ast.CreateTableStmt, opt options) (*codeText, error) {
 	}
 
 	return &codeText{
-		importPaths:   importPaths,
-		modelStruct:   modelStructCode,
-		modelJSON:     modelJSONCode,
-		updateFields:  updateFieldsCode,
-		handlerStruct: handlerStructCode,
-		protoFile:     protoFileCode,
-		serviceStruct: serviceStructCode,
+		importPaths:          importPaths,
+		modelStruct:          modelStructCode,
+		modelJSON:            modelJSONCode,
+		updateFields:         updateFieldsCode,
+		daoColumns:           daoColumnsCode,
+		daoRelation:          daoRelationCode,
+		daoRelationInterface: daoRelationInterfaceCode,
+		index:                indexCode,
+		handlerStruct:        handlerStructCode,
+		protoFile:            protoFileCode,
+		serviceStruct:        serviceStructCode,
+		softDelete:           data.SoftDelete,
+		sharding:             data.ShardingKey != "",
 	}, nil
 }
 
@@ -442,18 +735,13 @@ func getModelStructCode(data tmplData, importPaths []string, isEmbed bool) (stri
 		for i, field := range data.Fields {
 			if strings.Contains(field.GoType, "time.Time") {
 				data.Fields[i].GoType = "*time.Time"
-				continue
-			}
-			// force conversion of ID field to uint64 type
-			if field.Name == "ID" {
-				data.Fields[i].GoType = "uint64"
 			}
 		}
 		newImportPaths = importPaths
 	}
 
 	builder := strings.Builder{}
-	err := modelStructTmpl.Execute(&builder, data)
+	err := data.templates.get(modelStructTmpl).Execute(&builder, data)
 	if err != nil {
 		return "", nil, fmt.Errorf("modelStructTmpl.Execute error: %v", err)
 	}
@@ -471,9 +759,9 @@ func getModelStructCode(data tmplData, importPaths []string, isEmbed bool) (stri
 	return structCode, newImportPaths, nil
 }
 
-func getModelCode(data modelCodes) (string, error) {
+func getModelCode(data modelCodes, templates tmplOverrides) (string, error) {
 	builder := strings.Builder{}
-	err := modelTmpl.Execute(&builder, data)
+	err := templates.get(modelTmpl).Execute(&builder, data)
 	if err != nil {
 		return "", err
 	}
@@ -501,7 +789,46 @@ func getUpdateFieldsCode(data tmplData, isEmbed bool) (string, error) {
 	data.Fields = newFields
 
 	builder := strings.Builder{}
-	err := updateFieldTmpl.Execute(&builder, data)
+	err := data.templates.get(updateFieldTmpl).Execute(&builder, data)
+	if err != nil {
+		return "", err
+	}
+
+	code, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return "", err
+	}
+
+	return string(code), nil
+}
+
+func getDAORelationCode(data tmplData) (string, string, error) {
+	if len(data.Relations) == 0 {
+		return "", "", nil
+	}
+
+	builder := strings.Builder{}
+	err := data.templates.get(daoRelationTmpl).Execute(&builder, data)
+	if err != nil {
+		return "", "", err
+	}
+	code, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return "", "", fmt.Errorf("format.Source error: %v", err)
+	}
+
+	interfaceBuilder := strings.Builder{}
+	err = data.templates.get(daoRelationInterfaceTmpl).Execute(&interfaceBuilder, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(code), interfaceBuilder.String(), nil
+}
+
+func getDAOColumnsCode(data tmplData) (string, error) {
+	builder := strings.Builder{}
+	err := data.templates.get(daoColumnsTmpl).Execute(&builder, data)
 	if err != nil {
 		return "", err
 	}
@@ -515,7 +842,7 @@ func getUpdateFieldsCode(data tmplData, isEmbed bool) (string, error) {
 }
 
 func getHandlerStructCodes(data tmplData) (string, error) {
-	postStructCode, err := tmplExecuteWithFilter(data, handlerCreateStructTmpl)
+	postStructCode, err := tmplExecuteWithFilter(withoutVersion(data), handlerCreateStructTmpl)
 	if err != nil {
 		return "", fmt.Errorf("handlerCreateStructTmpl error: %v", err)
 	}
@@ -530,7 +857,12 @@ func getHandlerStructCodes(data tmplData) (string, error) {
 		return "", fmt.Errorf("handlerDetailStructTmpl error: %v", err)
 	}
 
-	return postStructCode + putStructCode + getStructCode, nil
+	indexStructCode, err := tmplExecuteWithFilter(data, handlerIndexStructTmpl)
+	if err != nil {
+		return "", fmt.Errorf("handlerIndexStructTmpl error: %v", err)
+	}
+
+	return postStructCode + putStructCode + getStructCode + indexStructCode, nil
 }
 
 // customized filter fields
@@ -545,16 +877,28 @@ func tmplExecuteWithFilter(data tmplData, tmpl *template.Template, reservedColum
 	data.Fields = newFields
 
 	builder := strings.Builder{}
-	err := tmpl.Execute(&builder, data)
+	err := data.templates.get(tmpl).Execute(&builder, data)
 	if err != nil {
 		return "", fmt.Errorf("tmpl.Execute error: %v", err)
 	}
 	return builder.String(), nil
 }
 
+// withoutVersion remove the version of optimistic locking, it is not set by the create request
+func withoutVersion(data tmplData) tmplData {
+	fields := make([]tmplField, 0, len(data.Fields))
+	for _, field := range data.Fields {
+		if !field.IsVersion() {
+			fields = append(fields, field)
+		}
+	}
+	data.Fields = fields
+	return data
+}
+
 func getModelJSONCode(data tmplData) (string, error) {
 	builder := strings.Builder{}
-	err := modelJSONTmpl.Execute(&builder, data)
+	err := data.templates.get(modelJSONTmpl).Execute(&builder, data)
 	if err != nil {
 		return "", err
 	}
@@ -572,22 +916,29 @@ func getModelJSONCode(data tmplData) (string, error) {
 
 func getProtoFileCode(data tmplData, isWebProto bool) (string, error) {
 	data.Fields = goTypeToProto(data.Fields)
+	relations := make([]*tmplRelation, 0, len(data.Relations))
+	for _, r := range data.Relations {
+		protoRelation := *r
+		protoRelation.Fields = goTypeToProto(r.Fields)
+		relations = append(relations, &protoRelation)
+	}
+	data.Relations = relations
 
 	builder := strings.Builder{}
 	if isWebProto {
-		err := protoFileForWebTmpl.Execute(&builder, data)
+		err := data.templates.get(protoFileForWebTmpl).Execute(&builder, data)
 		if err != nil {
 			return "", err
 		}
 	} else {
-		err := protoFileTmpl.Execute(&builder, data)
+		err := data.templates.get(protoFileTmpl).Execute(&builder, data)
 		if err != nil {
 			return "", err
 		}
 	}
 	code := builder.String()
 
-	protoMessageCreateCode, err := tmplExecuteWithFilter(data, protoMessageCreateTmpl)
+	protoMessageCreateCode, err := tmplExecuteWithFilter(withoutVersion(data), protoMessageCreateTmpl)
 	if err != nil {
 		return "", fmt.Errorf("handlerCreateStructTmpl error: %v", err)
 	}
@@ -608,6 +959,9 @@ func getProtoFileCode(data tmplData, isWebProto bool) (string, error) {
 	code = strings.ReplaceAll(code, "// protoMessageCreateCode", protoMessageCreateCode)
 	code = strings.ReplaceAll(code, "// protoMessageUpdateCode", protoMessageUpdateCode)
 	code = strings.ReplaceAll(code, "// protoMessageDetailCode", protoMessageDetailCode)
+	if strings.Contains(code, "(validate.rules)") {
+		code = strings.Replace(code, `//import "validate/validate.proto";`, `import "validate/validate.proto";`, 1)
+	}
 	code = strings.ReplaceAll(code, "*time.Time", "int64")
 	code = strings.ReplaceAll(code, "time.Time", "int64")
 
@@ -616,13 +970,13 @@ func getProtoFileCode(data tmplData, isWebProto bool) (string, error) {
 
 func getServiceStructCode(data tmplData) (string, error) {
 	builder := strings.Builder{}
-	err := serviceStructTmpl.Execute(&builder, data)
+	err := data.templates.get(serviceStructTmpl).Execute(&builder, data)
 	if err != nil {
 		return "", err
 	}
 	code := builder.String()
 
-	serviceCreateStructCode, err := tmplExecuteWithFilter(data, serviceCreateStructTmpl)
+	serviceCreateStructCode, err := tmplExecuteWithFilter(withoutVersion(data), serviceCreateStructTmpl)
 	if err != nil {
 		return "", fmt.Errorf("handlerCreateStructTmpl error: %v", err)
 	}
@@ -684,7 +1038,7 @@ func mysqlToGoType(colTp *types.FieldType, style NullStyle) (name string, path s
 			name = "sql.NullInt64"
 		case mysql.TypeFloat, mysql.TypeDouble:
 			name = "sql.NullFloat64"
-		case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString,
+		case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeEnum, mysql.TypeSet,
 			mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
 			name = "sql.NullString"
 		case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDate:
@@ -712,7 +1066,7 @@ func mysqlToGoType(colTp *types.FieldType, style NullStyle) (name string, path s
 			}
 		case mysql.TypeFloat, mysql.TypeDouble:
 			name = "float64"
-		case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString,
+		case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeEnum, mysql.TypeSet,
 			mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
 			name = "string"
 		case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDate:
@@ -732,6 +1086,16 @@ func mysqlToGoType(colTp *types.FieldType, style NullStyle) (name string, path s
 	return name, path
 }
 
+func boolGoType(style NullStyle) (name string, path string) {
+	switch style {
+	case NullInSql:
+		return "sql.NullBool", "database/sql"
+	case NullInPointer:
+		return "*bool", ""
+	}
+	return "bool", ""
+}
+
 func goTypeToProto(fields []tmplField) []tmplField {
 	var newFields []tmplField
 	for _, field := range fields {
@@ -740,7 +1104,7 @@ func goTypeToProto(fields []tmplField) []tmplField {
 			field.GoType = "int32"
 		case "uint":
 			field.GoType = "uint32"
-		case "time.Time":
+		case "time.Time", "gorm.DeletedAt":
 			field.GoType = "int64"
 		case "float32":
 			field.GoType = "float"
@@ -36,6 +36,115 @@ func TestParseSql(t *testing.T) {
 	}
 }
 
+func TestParseSQL_daoColumns(t *testing.T) {
+	sql := `CREATE TABLE user (
+  id BIGINT(11) PRIMARY KEY AUTO_INCREMENT NOT NULL,
+  name VARCHAR(30) NOT NULL,
+  age INT(11) unsigned NULL,
+  score DOUBLE NULL,
+  login_at datetime NULL,
+  password VARCHAR(64) NOT NULL,
+  api_token VARCHAR(64) NULL
+  );`
+
+	codes, err := ParseSQL(sql, WithNullStyle(NullInSql))
+	assert.Nil(t, err)
+	code := codes[CodeTypeDAOColumns]
+	assert.Contains(t, code, "var userColumns = query.NewColumnSchema(")
+	assert.Contains(t, code, `query.ColumnRule{Name: "id", Type: query.TypeUint, Filter: true, Sort: true}`)
+	assert.Contains(t, code, `query.ColumnRule{Name: "name", Type: query.TypeString, Filter: true, Sort: true}`)
+	assert.Contains(t, code, `query.ColumnRule{Name: "age", Type: query.TypeInt, Filter: true, Sort: true}`)
+	assert.Contains(t, code, `query.ColumnRule{Name: "score", Type: query.TypeFloat, Filter: true, Sort: true}`)
+	assert.Contains(t, code, `query.ColumnRule{Name: "login_at", Type: query.TypeTime, Filter: true, Sort: true}`)
+	// the sensitive columns are not allowed in query conditions and sort fields
+	assert.Contains(t, code, `query.ColumnRule{Name: "password", Type: query.TypeString}, `)
+	assert.Contains(t, code, `query.ColumnRule{Name: "api_token", Type: query.TypeString}, `)
+}
+
+func Test_tmplField_IsSensitive(t *testing.T) {
+	for colName, want := range map[string]bool{
+		"password": true, "Passwd": true, "client_secret": true, "refresh_token": true, "salt": true,
+		"name": false, "email": false,
+	} {
+		assert.Equal(t, want, tmplField{ColName: colName}.IsSensitive(), colName)
+	}
+	assert.True(t, tmplField{ColName: "foo", Tag: `gorm:"column:foo" json:"-"`}.IsSensitive())
+}
+
+func TestParseSQL_softDeleteAndVersion(t *testing.T) {
+	sql := `CREATE TABLE user (
+  id BIGINT(11) PRIMARY KEY AUTO_INCREMENT NOT NULL,
+  name VARCHAR(30) NOT NULL,
+  version INT(11) unsigned NOT NULL DEFAULT 0,
+  deleted_at datetime NULL
+  );`
+
+	codes, err := ParseSQL(sql)
+	assert.Nil(t, err)
+	assert.Equal(t, "true", codes[SoftDelete])
+	assert.Contains(t, codes[CodeTypeDAO], "if table.Version != 0 {\n\t\t// optimistic locking")
+	assert.Contains(t, codes[CodeTypeDAO], `tx = tx.Where("version = ?", table.Version)`)
+	assert.Contains(t, codes[CodeTypeDAO], `update["version"] = table.Version + 1`)
+	assert.Contains(t, codes[CodeTypeDAO], `update["version"] = gorm.Expr("version + 1")`)
+	assert.Contains(t, codes[CodeTypeProto], "rpc Restore(RestoreUserRequest) returns (RestoreUserReply) {}")
+	assert.Contains(t, codes[CodeTypeProto], "message ListDeletedUserReply {")
+
+	codes, err = ParseSQL(sql, WithWebProto())
+	assert.Nil(t, err)
+	assert.Contains(t, codes[CodeTypeProto], `put: "/api/v1/user/{id}/restore"`)
+	assert.Contains(t, codes[CodeTypeProto], `delete: "/api/v1/user/{id}/purge"`)
+
+	// the deleted_at field is gorm.DeletedAt without the embedded mysql.Model, otherwise the record is hard-deleted
+	codes, err = ParseSQL(sql, WithJSONTag(1), WithNullStyle(NullInPointer))
+	assert.Nil(t, err)
+	assert.Equal(t, "true", codes[SoftDelete])
+	assert.Contains(t, codes[CodeTypeModel], "\"gorm.io/gorm\"")
+	assert.Contains(t, codes[CodeTypeModel], "DeletedAt gorm.DeletedAt `gorm:\"column:deleted_at\" json:\"deletedAt\"`")
+	assert.NotContains(t, codes[CodeTypeModel], "\"time\"")
+	assert.Contains(t, codes[CodeTypeDAOColumns], `query.ColumnRule{Name: "deleted_at", Type: query.TypeTime, Filter: true, Sort: true}`)
+	assert.Contains(t, codes[CodeTypeJSON], `"deleted_at": "0000-01-00T00:00:00.000+08:00"`)
+	codes, err = ParseSQL(sql, WithEmbed())
+	assert.Nil(t, err)
+	assert.Equal(t, "true", codes[SoftDelete])
+	assert.NotContains(t, codes[CodeTypeModel], "gorm.io/gorm")
+
+	sql = `CREATE TABLE user (
+  id BIGINT(11) PRIMARY KEY AUTO_INCREMENT NOT NULL,
+  version VARCHAR(30) NOT NULL
+  );`
+	codes, err = ParseSQL(sql)
+	assert.Nil(t, err)
+	assert.Equal(t, "false", codes[SoftDelete])
+	assert.Contains(t, codes[CodeTypeDAO], `update["version"] = table.Version`)
+	assert.NotContains(t, codes[CodeTypeProto], "Restore")
+}
+
+func TestParseSQL_sharding(t *testing.T) {
+	sql := `CREATE TABLE order_info (
+  id BIGINT(11) PRIMARY KEY AUTO_INCREMENT NOT NULL,
+  user_id BIGINT(11) NOT NULL,
+  amount INT(11) NOT NULL
+  );`
+
+	codes, err := ParseSQL(sql, WithSharding("user_id", "mod:64"))
+	assert.Nil(t, err)
+	assert.Equal(t, "true", codes[Sharding])
+	assert.Contains(t, codes[CodeTypeModel], `"github.com/hankyu66/sponge/pkg/mysql/sharding"`)
+	assert.Contains(t, codes[CodeTypeModel], "var OrderInfoShardingRule = sharding.Rule{")
+	assert.Contains(t, codes[CodeTypeModel], `Table:     "order_info",`)
+	assert.Contains(t, codes[CodeTypeModel], `Algorithm: sharding.MustParseAlgorithm("mod:64"),`)
+	assert.Contains(t, codes[CodeTypeModel], "// IDGenerator is required")
+
+	// the table has no sharding key column
+	codes, err = ParseSQL(sql, WithSharding("tenant_id", "hash:16"))
+	assert.Nil(t, err)
+	assert.Equal(t, "false", codes[Sharding])
+	assert.NotContains(t, codes[CodeTypeModel], "sharding")
+
+	_, err = ParseSQL(sql, WithSharding("user_id", "mod"))
+	assert.Error(t, err)
+}
+
 var testData = [][]string{
 	{
 		"CREATE TABLE information (age INT(11) NULL);",
@@ -113,6 +222,8 @@ func Test_parseOption(t *testing.T) {
 		WithGormType(),
 		WithForceTableName(),
 		WithEmbed(),
+		WithSharding("user_id", "mod:64"),
+		WithDBDriver(DBDriverPostgresql),
 	}
 	o := parseOption(opts)
 	assert.NotNil(t, o)
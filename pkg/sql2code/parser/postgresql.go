@@ -0,0 +1,978 @@
+package parser
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib" //nolint
+)
+
+// the postgresql ddl is converted to the equivalent mysql ddl and parsed by the mysql parser, so that the
+// generated codes are the same as mysql, the original postgresql types and default values are kept in pgColumn.
+
+// pgColumn the postgresql information of the column which can't be expressed in mysql ddl
+type pgColumn struct {
+	dbType       string // postgresql type, used in gorm type tag
+	defaultValue string // postgresql default value, used in gorm default tag
+	isBool       bool   // boolean type, mapped to go bool
+}
+
+type pgTable struct {
+	name        string
+	comment     string
+	columns     []*pgColumnDef
+	primaryKey  []string
+	foreignKeys []*pgForeignKey
+	indexes     []*pgIndex
+}
+
+type pgIndex struct {
+	name     string
+	columns  []string
+	isUnique bool
+}
+
+type pgForeignKey struct {
+	column    string
+	refTable  string
+	refColumn string // the primary key of the referenced table if empty
+}
+
+type pgColumnDef struct {
+	name          string
+	mysqlType     string
+	notNull       bool
+	autoIncrement bool
+	primaryKey    bool
+	unique        bool
+	comment       string
+	reference     *pgForeignKey
+	pgColumn
+}
+
+// pgColumnKeywords the keywords of the column constraints
+var pgColumnKeywords = map[string]struct{}{
+	"constraint": {}, "not": {}, "null": {}, "default": {}, "primary": {}, "unique": {},
+	"generated": {}, "references": {}, "check": {}, "collate": {},
+}
+
+var (
+	pgTypeArgsRegexp = regexp.MustCompile(`\s*\(([^)]*)\)`)
+	pgCastRegexp     = regexp.MustCompile(`::[a-zA-Z_][\w ."]*(\(\d+(,\s*\d+)?\))?(\[\])*$`)
+)
+
+// convertPostgresqlDDL convert the postgresql CREATE TABLE, CREATE INDEX and COMMENT ON statements to mysql ddl,
+// the other statements are ignored
+func convertPostgresqlDDL(ddl string) (string, map[string]map[string]*pgColumn, error) {
+	tables := []*pgTable{}
+	tableMap := map[string]*pgTable{}
+	comments := [][3]string{} // table, column, comment
+
+	for _, stmt := range splitTopLevel(stripSQLComments(ddl), ';') {
+		tokens := splitTokens(stmt)
+		switch {
+		case matchKeywords(tokens, "create") && indexKeyword(tokens, "table") > 0:
+			table, err := parsePgCreateTable(stmt)
+			if err != nil {
+				return "", nil, err
+			}
+			if table == nil {
+				continue
+			}
+			tables = append(tables, table)
+			tableMap[table.name] = table
+
+		case matchKeywords(tokens, "create", "index") || matchKeywords(tokens, "create", "unique", "index"):
+			tableName, index := parsePgCreateIndex(tokens)
+			if table, ok := tableMap[tableName]; ok && index != nil {
+				table.indexes = append(table.indexes, index)
+			}
+
+		case matchKeywords(tokens, "comment", "on") && len(tokens) >= 6 && strings.EqualFold(tokens[5], "null"):
+			continue
+
+		case matchKeywords(tokens, "comment", "on", "column") && len(tokens) >= 6:
+			names := splitQualifiedName(tokens[3])
+			if len(names) < 2 {
+				return "", nil, fmt.Errorf("invalid column name in statement: %s", stmt)
+			}
+			comments = append(comments, [3]string{names[len(names)-2], names[len(names)-1], unquoteString(tokens[5])})
+
+		case matchKeywords(tokens, "comment", "on", "table") && len(tokens) >= 6:
+			names := splitQualifiedName(tokens[3])
+			comments = append(comments, [3]string{names[len(names)-1], "", unquoteString(tokens[5])})
+
+		case matchKeywords(tokens, "alter", "table"):
+			// the statements generated by pg_dump, ALTER TABLE [ONLY] name ADD [CONSTRAINT name] PRIMARY KEY (columns),
+			// ALTER TABLE [ONLY] name ADD [CONSTRAINT name] FOREIGN KEY (column) REFERENCES table (column)
+			// and ALTER TABLE [ONLY] name ALTER [COLUMN] column SET DEFAULT nextval(...)
+			i := 2
+			if matchKeywords(tokens[i:], "only") {
+				i++
+			}
+			if i >= len(tokens) {
+				continue
+			}
+			names := splitQualifiedName(tokens[i])
+			table, ok := tableMap[names[len(names)-1]]
+			if !ok {
+				continue
+			}
+			if j := indexKeyword(tokens, "primary"); j > 0 {
+				table.primaryKey = primaryKeyColumns(tokens[j+1:])
+			}
+			if j := indexKeyword(tokens, "foreign"); j > 0 {
+				if fk := parsePgForeignKey(tokens[j+1:]); fk != nil {
+					table.foreignKeys = append(table.foreignKeys, fk)
+				}
+			}
+			if matchKeywords(tokens[i+1:], "add") {
+				for j := i + 2; j < len(tokens); j++ {
+					if keywordOf(tokens[j]) == "unique" {
+						if index := parsePgUnique(tokens[j:]); index != nil {
+							table.indexes = append(table.indexes, index)
+						}
+						break
+					}
+				}
+			}
+			if j := indexKeyword(tokens, "default"); j > 0 && j+1 < len(tokens) && matchKeywords(tokens[i+1:], "alter") {
+				k := i + 2
+				if matchKeywords(tokens[k:], "column") {
+					k++
+				}
+				_, isSequence := pgDefaultValue(strings.Join(tokens[j+1:], " "))
+				for _, col := range table.columns {
+					if k < len(tokens) && col.name == unquoteName(tokens[k]) && isSequence {
+						col.autoIncrement = true
+						col.defaultValue = ""
+					}
+				}
+			}
+		}
+	}
+
+	for _, c := range comments {
+		table, ok := tableMap[c[0]]
+		if !ok {
+			continue
+		}
+		if c[1] == "" {
+			table.comment = c[2]
+			continue
+		}
+		for _, col := range table.columns {
+			if col.name == c[1] {
+				col.comment = c[2]
+			}
+		}
+	}
+
+	columns := map[string]map[string]*pgColumn{}
+	builder := strings.Builder{}
+	for _, table := range tables {
+		columns[table.name] = map[string]*pgColumn{}
+		builder.WriteString("CREATE TABLE " + quoteMysqlName(table.name) + " (\n")
+		items := make([]string, 0, len(table.columns)+1)
+		foreignKeys := table.foreignKeys
+		for _, col := range table.columns {
+			pc := col.pgColumn
+			columns[table.name][col.name] = &pc
+			items = append(items, "  "+col.mysqlDefinition())
+			if col.reference != nil {
+				foreignKeys = append(foreignKeys, col.reference)
+			}
+		}
+		if len(table.primaryKey) > 0 {
+			keys := make([]string, 0, len(table.primaryKey))
+			for _, key := range table.primaryKey {
+				keys = append(keys, quoteMysqlName(key))
+			}
+			items = append(items, "  PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+		}
+		for _, index := range table.indexes {
+			keys := make([]string, 0, len(index.columns))
+			for _, column := range index.columns {
+				keys = append(keys, quoteMysqlName(column))
+			}
+			item := "  KEY "
+			if index.isUnique {
+				item = "  UNIQUE KEY "
+			}
+			if index.name != "" {
+				item += quoteMysqlName(index.name) + " "
+			}
+			items = append(items, item+"("+strings.Join(keys, ", ")+")")
+		}
+		for _, fk := range foreignKeys {
+			refColumn := fk.refColumn
+			if refColumn == "" {
+				refColumn = tableMap[fk.refTable].primaryKeyColumn()
+			}
+			if refColumn == "" {
+				continue // the referenced table is not in the ddl
+			}
+			items = append(items, fmt.Sprintf("  FOREIGN KEY (%s) REFERENCES %s (%s)",
+				quoteMysqlName(fk.column), quoteMysqlName(fk.refTable), quoteMysqlName(refColumn)))
+		}
+		builder.WriteString(strings.Join(items, ",\n"))
+		builder.WriteString("\n)")
+		if table.comment != "" {
+			builder.WriteString(" COMMENT=" + quoteMysqlString(table.comment))
+		}
+		builder.WriteString(";\n")
+	}
+
+	return builder.String(), columns, nil
+}
+
+// primaryKeyColumn get the column of the single column primary key
+func (t *pgTable) primaryKeyColumn() string {
+	if t == nil {
+		return ""
+	}
+	if len(t.primaryKey) == 1 {
+		return t.primaryKey[0]
+	}
+	for _, col := range t.columns {
+		if col.primaryKey {
+			return col.name
+		}
+	}
+	return ""
+}
+
+func (c *pgColumnDef) mysqlDefinition() string {
+	s := quoteMysqlName(c.name) + " " + c.mysqlType
+	if c.notNull || c.autoIncrement || c.primaryKey {
+		s += " NOT NULL"
+	}
+	if c.autoIncrement {
+		s += " AUTO_INCREMENT"
+	}
+	if c.primaryKey {
+		s += " PRIMARY KEY"
+	}
+	if c.unique {
+		s += " UNIQUE"
+	}
+	if c.comment != "" {
+		s += " COMMENT " + quoteMysqlString(c.comment)
+	}
+	return s
+}
+
+// parsePgCreateTable parse CREATE [TEMP|UNLOGGED] TABLE [IF NOT EXISTS] name (items) [options]
+func parsePgCreateTable(stmt string) (*pgTable, error) {
+	start := strings.Index(stmt, "(")
+	if start < 0 {
+		return nil, nil // e.g. CREATE TABLE ... AS SELECT
+	}
+	tokens := splitTokens(stmt[:start])
+	if len(tokens) == 0 || indexKeyword(tokens, "table") < 0 || indexKeyword(tokens, "of") > 0 {
+		return nil, nil // e.g. CREATE TABLE ... PARTITION OF
+	}
+	names := splitQualifiedName(tokens[len(tokens)-1])
+	table := &pgTable{name: names[len(names)-1]}
+
+	body, ok := enclosedBody(stmt[start:])
+	if !ok {
+		return nil, fmt.Errorf("unclosed parenthesis in statement: %s", stmt)
+	}
+	for _, item := range splitTopLevel(body, ',') {
+		tokens = splitTokens(item)
+		if len(tokens) == 0 {
+			continue
+		}
+		if matchKeywords(tokens, "constraint") && len(tokens) > 2 {
+			tokens = tokens[2:]
+		}
+		switch keywordOf(tokens[0]) {
+		case "primary":
+			table.primaryKey = primaryKeyColumns(tokens[1:])
+			continue
+		case "foreign":
+			if fk := parsePgForeignKey(tokens[1:]); fk != nil {
+				table.foreignKeys = append(table.foreignKeys, fk)
+			}
+			continue
+		case "unique":
+			if index := parsePgUnique(tokens); index != nil {
+				table.indexes = append(table.indexes, index)
+			}
+			continue
+		case "check", "exclude", "like":
+			continue
+		}
+
+		col, err := parsePgColumn(tokens)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %v", table.name, err)
+		}
+		table.columns = append(table.columns, col)
+	}
+
+	if len(table.columns) == 0 {
+		return nil, fmt.Errorf("table %s has no column", table.name)
+	}
+	return table, nil
+}
+
+// parsePgColumn parse name type [constraints]
+func parsePgColumn(tokens []string) (*pgColumnDef, error) {
+	if len(tokens) < 2 {
+		return nil, fmt.Errorf("invalid column definition: %s", strings.Join(tokens, " "))
+	}
+	col := &pgColumnDef{name: unquoteName(tokens[0])}
+
+	i := 1
+	for i < len(tokens) && !isPgColumnKeyword(tokens[i]) {
+		i++
+	}
+	dbType := strings.Join(tokens[1:i], " ")
+	col.mysqlType, col.autoIncrement, col.isBool = pgToMysqlType(dbType)
+	col.dbType = unquoteTypeName(dbType)
+
+	for i < len(tokens) {
+		switch keywordOf(tokens[i]) {
+		case "not":
+			col.notNull = true
+			i += 2
+		case "primary":
+			col.primaryKey = true
+			i += 2
+		case "unique":
+			col.unique = true
+			i++
+		case "default":
+			j := i + 2
+			for j < len(tokens) && !isPgColumnKeyword(tokens[j]) {
+				j++
+			}
+			if j > len(tokens) {
+				j = len(tokens)
+			}
+			value, isSequence := pgDefaultValue(strings.Join(tokens[i+1:j], " "))
+			if isSequence {
+				col.autoIncrement = true
+			} else {
+				col.defaultValue = value
+			}
+			i = j
+		case "generated":
+			// GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY, GENERATED ALWAYS AS (expr) STORED
+			j := i + 1
+			for j < len(tokens) && !strings.EqualFold(tokens[j], "as") {
+				j++
+			}
+			if j+1 < len(tokens) && strings.EqualFold(tokens[j+1], "identity") {
+				col.autoIncrement = true
+			}
+			i = j + 2
+			for i < len(tokens) && !isPgColumnKeyword(tokens[i]) {
+				i++
+			}
+		case "references":
+			j := i + 1
+			for j < len(tokens) && !isPgColumnKeyword(tokens[j]) {
+				j++
+			}
+			if refTable, refColumn := parsePgReference(tokens[i+1 : j]); refTable != "" {
+				col.reference = &pgForeignKey{column: col.name, refTable: refTable, refColumn: refColumn}
+			}
+			i = j
+		default: // NULL, CONSTRAINT name, CHECK (...), COLLATE name
+			i++
+			for i < len(tokens) && !isPgColumnKeyword(tokens[i]) {
+				i++
+			}
+		}
+	}
+
+	if col.autoIncrement {
+		col.defaultValue = ""
+	}
+	if col.isBool && col.defaultValue != "" {
+		col.defaultValue = strings.ToLower(col.defaultValue)
+	}
+	return col, nil
+}
+
+// pgToMysqlType convert the postgresql type to mysql type, the types not supported by mysql,
+// e.g. array, uuid, interval, inet, enum, are converted to text and mapped to go string
+func pgToMysqlType(pgType string) (mysqlType string, isAutoIncrement bool, isBool bool) {
+	t := strings.ToLower(strings.Join(strings.Fields(pgType), " "))
+	if strings.HasSuffix(t, "]") || strings.Contains(t, " array") {
+		return "text", false, false
+	}
+
+	args := ""
+	if m := pgTypeArgsRegexp.FindStringSubmatch(t); len(m) == 2 {
+		args = strings.ReplaceAll(m[1], " ", "")
+		t = strings.TrimSpace(pgTypeArgsRegexp.ReplaceAllString(t, ""))
+	}
+	if i := strings.LastIndex(t, "."); i >= 0 { // e.g. pg_catalog.int4
+		t = t[i+1:]
+	}
+	withArgs := func(name string, defaultArgs string) string {
+		if args == "" {
+			args = defaultArgs
+		}
+		if args == "" {
+			return name
+		}
+		return name + "(" + args + ")"
+	}
+
+	switch t {
+	case "smallint", "int2":
+		return "smallint", false, false
+	case "integer", "int", "int4":
+		return "int", false, false
+	case "bigint", "int8":
+		return "bigint", false, false
+	case "smallserial", "serial2":
+		return "smallint", true, false
+	case "serial", "serial4":
+		return "int", true, false
+	case "bigserial", "serial8":
+		return "bigint", true, false
+	case "real", "float4":
+		return "float", false, false
+	case "double precision", "float8", "float":
+		return "double", false, false
+	case "numeric", "decimal":
+		return withArgs("decimal", ""), false, false
+	case "money":
+		return "decimal(19,2)", false, false
+	case "boolean", "bool":
+		return "tinyint(1)", false, true
+	case "character varying", "varchar":
+		return withArgs("varchar", "255"), false, false
+	case "character", "char", "bpchar":
+		return withArgs("char", "1"), false, false
+	case "bytea":
+		return "blob", false, false
+	case "json", "jsonb":
+		return "json", false, false
+	case "date":
+		return "date", false, false
+	case "timestamp", "timestamp without time zone":
+		return "datetime", false, false
+	case "timestamptz", "timestamp with time zone":
+		return "timestamp", false, false
+	case "uuid":
+		return "varchar(36)", false, false
+	}
+
+	return "text", false, false // text, citext, time, interval, inet, xml, enum, etc.
+}
+
+// pgDefaultValue remove the type casts and quotes of the default value, e.g. 'foo'::character varying,
+// the default value nextval('seq'::regclass) means the column is auto increment
+func pgDefaultValue(expr string) (value string, isSequence bool) {
+	value = strings.TrimSpace(expr)
+	if strings.HasPrefix(strings.ToLower(value), "nextval(") {
+		return "", true
+	}
+	for {
+		v := strings.TrimSpace(pgCastRegexp.ReplaceAllString(value, ""))
+		if body, ok := enclosedBody(v); ok && len(body) == len(v)-2 {
+			v = strings.TrimSpace(body)
+		}
+		if v == value {
+			break
+		}
+		value = v
+	}
+	if strings.EqualFold(value, "null") {
+		return "", false
+	}
+	return unquoteString(value), false
+}
+
+// GetPostgresqlTableInfo get the ddl of the table from postgresql, the table name can be prefixed with the schema
+func GetPostgresqlTableInfo(dsn, tableName string) (string, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return "", fmt.Errorf("connect postgresql error, %v", err)
+	}
+	defer db.Close() //nolint
+
+	rows, err := db.Query(`SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+       COALESCE(pg_get_expr(d.adbin, d.adrelid), ''), a.attidentity <> '', COALESCE(col_description(a.attrelid, a.attnum), '')
+FROM pg_attribute a LEFT JOIN pg_attrdef d ON a.attrelid = d.adrelid AND a.attnum = d.adnum
+WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`, tableName)
+	if err != nil {
+		return "", fmt.Errorf("query columns error, %v", err)
+	}
+	defer rows.Close() //nolint
+
+	names := splitQualifiedName(tableName)
+	table := quotePgName(names[len(names)-1])
+	items := []string{}
+	comments := []string{}
+	for rows.Next() {
+		var name, tp, defaultValue, comment string
+		var notNull, isIdentity bool
+		if err = rows.Scan(&name, &tp, &notNull, &defaultValue, &isIdentity, &comment); err != nil {
+			return "", err
+		}
+		item := quotePgName(name) + " " + tp
+		if isIdentity {
+			item += " GENERATED BY DEFAULT AS IDENTITY"
+		}
+		if notNull {
+			item += " NOT NULL"
+		}
+		if defaultValue != "" {
+			item += " DEFAULT " + defaultValue
+		}
+		items = append(items, item)
+		if comment != "" {
+			comments = append(comments, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table, quotePgName(name), quotePgString(comment)))
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", fmt.Errorf("not found table '%s'", tableName)
+	}
+
+	var keys []string
+	rows2, err := db.Query(`SELECT a.attname FROM pg_index i
+JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+WHERE i.indrelid = $1::regclass AND i.indisprimary`, tableName)
+	if err != nil {
+		return "", fmt.Errorf("query primary key error, %v", err)
+	}
+	defer rows2.Close() //nolint
+	for rows2.Next() {
+		var name string
+		if err = rows2.Scan(&name); err != nil {
+			return "", err
+		}
+		keys = append(keys, quotePgName(name))
+	}
+	if len(keys) > 0 {
+		items = append(items, "PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+	}
+
+	rows3, err := db.Query(`SELECT a.attname, c.confrelid::regclass::text, fa.attname FROM pg_constraint c
+JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = c.conkey[1]
+JOIN pg_attribute fa ON fa.attrelid = c.confrelid AND fa.attnum = c.confkey[1]
+WHERE c.conrelid = $1::regclass AND c.contype = 'f' AND array_length(c.conkey, 1) = 1`, tableName)
+	if err != nil {
+		return "", fmt.Errorf("query foreign keys error, %v", err)
+	}
+	defer rows3.Close() //nolint
+	for rows3.Next() {
+		var column, refTable, refColumn string
+		if err = rows3.Scan(&column, &refTable, &refColumn); err != nil {
+			return "", err
+		}
+		items = append(items, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", quotePgName(column), refTable, quotePgName(refColumn)))
+	}
+
+	var indexes []string
+	rows4, err := db.Query(`SELECT pg_get_indexdef(i.indexrelid) FROM pg_index i
+WHERE i.indrelid = $1::regclass AND NOT i.indisprimary`, tableName)
+	if err != nil {
+		return "", fmt.Errorf("query indexes error, %v", err)
+	}
+	defer rows4.Close() //nolint
+	for rows4.Next() {
+		var indexDef string
+		if err = rows4.Scan(&indexDef); err != nil {
+			return "", err
+		}
+		indexes = append(indexes, indexDef+";")
+	}
+
+	var tableComment string
+	err = db.QueryRow(`SELECT COALESCE(obj_description($1::regclass, 'pg_class'), '')`, tableName).Scan(&tableComment)
+	if err != nil {
+		return "", fmt.Errorf("query table comment error, %v", err)
+	}
+	if tableComment != "" {
+		comments = append(comments, fmt.Sprintf("COMMENT ON TABLE %s IS %s;", table, quotePgString(tableComment)))
+	}
+
+	ddl := "CREATE TABLE " + table + " (\n  " + strings.Join(items, ",\n  ") + "\n);\n"
+	if len(indexes) > 0 {
+		ddl += strings.Join(indexes, "\n") + "\n"
+	}
+	if len(comments) > 0 {
+		ddl += strings.Join(comments, "\n") + "\n"
+	}
+	return ddl, nil
+}
+
+// stripSQLComments remove the -- and /* */ comments outside the quotes
+func stripSQLComments(s string) string {
+	builder := strings.Builder{}
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			builder.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '-' && i+1 < len(s) && s[i+1] == '-':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+			c = '\n'
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return builder.String()
+			}
+			i += end + 3
+			c = ' '
+		}
+		builder.WriteByte(c)
+	}
+	return builder.String()
+}
+
+// splitTopLevel split the string by the separator outside the quotes and parentheses
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, strings.TrimSpace(s[start:]))
+
+	result := parts[:0]
+	for _, p := range parts {
+		if p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// splitTokens split the string by the whitespaces outside the quotes and parentheses,
+// a parenthesized group is a token, e.g. varchar(20), (id, name)
+func splitTokens(s string) []string {
+	var tokens []string
+	var quote byte
+	depth := 0
+	token := strings.Builder{}
+	flush := func() {
+		if token.Len() > 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			token.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			if depth == 0 && token.Len() > 0 && !isIdentChar(s[i-1]) {
+				flush()
+			}
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && (c == ' ' || c == '\t' || c == '\n' || c == '\r'):
+			flush()
+			continue
+		}
+		token.WriteByte(c)
+	}
+	flush()
+	return tokens
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '"' || c == ']' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// enclosedBody return the content of the parentheses at the beginning of the string
+func enclosedBody(s string) (string, bool) {
+	if !strings.HasPrefix(s, "(") {
+		return "", false
+	}
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[1:i], true
+			}
+		}
+	}
+	return "", false
+}
+
+func isPgColumnKeyword(token string) bool {
+	_, ok := pgColumnKeywords[keywordOf(token)]
+	return ok
+}
+
+// keywordOf return the lower case keyword of the token, e.g. check(age > 0) is check
+func keywordOf(token string) string {
+	if i := strings.Index(token, "("); i > 0 {
+		token = token[:i]
+	}
+	return strings.ToLower(token)
+}
+
+// primaryKeyColumns get the columns of the tokens after PRIMARY, e.g. KEY (id), KEY(id)
+func primaryKeyColumns(tokens []string) []string {
+	s := strings.TrimSpace(strings.Join(tokens, " "))
+	if len(s) < 3 || !strings.EqualFold(s[:3], "key") {
+		return nil
+	}
+	return splitColumnNames(strings.TrimSpace(s[3:]))
+}
+
+// parsePgForeignKey parse the tokens after FOREIGN, e.g. KEY (user_id) REFERENCES "user" (id) ON DELETE CASCADE,
+// the foreign key of multiple columns is ignored
+func parsePgForeignKey(tokens []string) *pgForeignKey {
+	i := indexKeyword(tokens, "references")
+	if i < 0 {
+		return nil
+	}
+	columns := primaryKeyColumns(tokens[:i])
+	if len(columns) != 1 {
+		return nil
+	}
+	refTable, refColumn := parsePgReference(tokens[i+1:])
+	if refTable == "" {
+		return nil
+	}
+	return &pgForeignKey{column: columns[0], refTable: refTable, refColumn: refColumn}
+}
+
+// parsePgCreateIndex parse CREATE [UNIQUE] INDEX [CONCURRENTLY] [[IF NOT EXISTS] name] ON [ONLY] table [USING method] (columns),
+// the index of expressions is ignored
+func parsePgCreateIndex(tokens []string) (string, *pgIndex) {
+	i := indexKeyword(tokens, "on")
+	if i < 0 || i+1 >= len(tokens) {
+		return "", nil
+	}
+	index := &pgIndex{isUnique: strings.EqualFold(tokens[1], "unique")}
+	if name := tokens[i-1]; !strings.EqualFold(name, "index") && !strings.EqualFold(name, "concurrently") &&
+		!strings.EqualFold(name, "exists") {
+		names := splitQualifiedName(name)
+		index.name = names[len(names)-1]
+	}
+
+	i++
+	if matchKeywords(tokens[i:], "only") {
+		i++
+	}
+	if i >= len(tokens) {
+		return "", nil
+	}
+	rest := tokens[i+1:]
+	name := tokens[i]
+	if j := strings.Index(name, "("); j > 0 { // e.g. "user"(name)
+		rest = append([]string{name[j:]}, rest...)
+		name = name[:j]
+	}
+	if matchKeywords(rest, "using") && len(rest) > 1 {
+		rest = rest[2:]
+	}
+	index.columns = indexColumns(rest)
+	if len(index.columns) == 0 {
+		return "", nil
+	}
+	names := splitQualifiedName(name)
+	return names[len(names)-1], index
+}
+
+// parsePgUnique parse the unique constraint, e.g. UNIQUE (tenant_id, code), UNIQUE(email)
+func parsePgUnique(tokens []string) *pgIndex {
+	tokens = append([]string{strings.TrimSpace(tokens[0][len("unique"):])}, tokens[1:]...)
+	if tokens[0] == "" {
+		tokens = tokens[1:]
+	}
+	columns := indexColumns(tokens)
+	if len(columns) == 0 {
+		return nil
+	}
+	return &pgIndex{columns: columns, isUnique: true}
+}
+
+// indexColumns get the columns of the tokens beginning with the parenthesized columns, e.g. (tenant_id, lower(email)),
+// nil is returned if any column is an expression
+func indexColumns(tokens []string) []string {
+	if len(tokens) == 0 {
+		return nil
+	}
+	body, ok := enclosedBody(strings.TrimSpace(tokens[0]))
+	if !ok {
+		return nil
+	}
+	var columns []string
+	for _, item := range splitTopLevel(body, ',') {
+		column := splitTokens(item)[0] // e.g. email DESC, email varchar_pattern_ops
+		if strings.Contains(column, "(") {
+			return nil
+		}
+		columns = append(columns, unquoteName(column))
+	}
+	return columns
+}
+
+// parsePgReference parse the tokens after REFERENCES, e.g. public."user"(id) ON DELETE CASCADE
+func parsePgReference(tokens []string) (table string, column string) {
+	if len(tokens) == 0 {
+		return "", ""
+	}
+	s := tokens[0]
+	if len(tokens) > 1 && strings.HasPrefix(tokens[1], "(") {
+		s += tokens[1]
+	}
+	if i := strings.LastIndex(s, "("); i > 0 && strings.HasSuffix(s, ")") {
+		columns := splitColumnNames(s[i:])
+		if len(columns) == 1 {
+			column = columns[0]
+		}
+		s = s[:i]
+	}
+	names := splitQualifiedName(s)
+	return names[len(names)-1], column
+}
+
+func matchKeywords(tokens []string, keywords ...string) bool {
+	if len(tokens) < len(keywords) {
+		return false
+	}
+	for i, k := range keywords {
+		if !strings.EqualFold(tokens[i], k) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexKeyword(tokens []string, keyword string) int {
+	for i, t := range tokens {
+		if strings.EqualFold(t, keyword) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitQualifiedName split the name like schema."table".column
+func splitQualifiedName(s string) []string {
+	var names []string
+	for _, name := range splitTopLevel(s, '.') {
+		names = append(names, unquoteName(name))
+	}
+	if len(names) == 0 {
+		return []string{""}
+	}
+	return names
+}
+
+// splitColumnNames split the column names in parentheses, e.g. (id, "name")
+func splitColumnNames(s string) []string {
+	body, ok := enclosedBody(s)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, name := range splitTopLevel(body, ',') {
+		names = append(names, unquoteName(name))
+	}
+	return names
+}
+
+// unquoteName remove the double quotes of the identifier, the unquoted identifier is case-insensitive
+func unquoteName(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return strings.ToLower(s)
+}
+
+// unquoteTypeName remove the double quotes of the identifiers in the type name, e.g. "char", public."Status"[],
+// the double quotes are invalid in the gorm tag.
+func unquoteTypeName(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+// unquoteString remove the single quotes of the string literal, E'...' is not supported
+func unquoteString(s string) string {
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	return s
+}
+
+func quoteMysqlName(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+func quoteMysqlString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(s) + "'"
+}
+
+func quotePgName(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quotePgString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
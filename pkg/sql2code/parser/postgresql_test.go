@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pgSQL = `-- user table
+CREATE TABLE IF NOT EXISTS public."user" (
+    id bigserial PRIMARY KEY,
+    name character varying(50) NOT NULL DEFAULT ''::character varying,
+    email varchar(100) UNIQUE,
+    age integer DEFAULT 0 CHECK(age >= 0),
+    is_active boolean NOT NULL DEFAULT TRUE,
+    tags text[] DEFAULT '{}'::text[],
+    profile jsonb,
+    balance numeric(10, 2),
+    login_at timestamp(6) with time zone,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+COMMENT ON TABLE public."user" IS 'user table';
+COMMENT ON COLUMN "user".name IS 'user''s name';
+CREATE INDEX idx_user_name ON "user" (name);
+
+/* identity column and primary key constraint */
+CREATE TABLE user_log (
+    log_id integer GENERATED ALWAYS AS IDENTITY (START WITH 10),
+    user_id bigint NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    content text,
+    CONSTRAINT user_log_pk PRIMARY KEY(log_id)
+);`
+
+func TestParseSQL_postgresql(t *testing.T) {
+	codes, err := ParseSQL(pgSQL, WithDBDriver(DBDriverPostgresql), WithGormType(), WithJSONTag(0))
+	assert.Nil(t, err)
+	assert.Equal(t, DBDriverPostgresql, codes[DBDriver])
+	assert.Equal(t, "User, UserLog", codes[TableName])
+
+	model := codes[CodeTypeModel]
+	assert.Contains(t, model, "// User user table")
+	assert.Contains(t, model, "`gorm:\"column:id;type:bigserial;AUTO_INCREMENT;primary_key\" json:\"id\"`")
+	assert.Contains(t, model, "`gorm:\"column:name;type:character varying(50);NOT NULL\" json:\"name\"` // user's name")
+	assert.Contains(t, model, "`gorm:\"column:email;type:varchar(100);unique\" json:\"email\"`")
+	assert.Contains(t, model, "`gorm:\"column:age;type:integer;default:0\" json:\"age\"`")
+	assert.Contains(t, model, "IsActive  bool ")
+	assert.Contains(t, model, "`gorm:\"column:is_active;type:boolean;default:true;NOT NULL\" json:\"is_active\"`")
+	assert.Contains(t, model, "`gorm:\"column:tags;type:text[];default:{}\" json:\"tags\"`")
+	assert.Contains(t, model, "`gorm:\"column:profile;type:jsonb\" json:\"profile\"`")
+	assert.Contains(t, model, "`gorm:\"column:created_at;type:timestamptz;default:now();NOT NULL\" json:\"created_at\"`")
+	assert.Contains(t, model, "`gorm:\"column:log_id;type:integer;primary_key;AUTO_INCREMENT\" json:\"log_id\"`")
+	assert.Contains(t, model, "`gorm:\"column:user_id;type:bigint;NOT NULL\" json:\"user_id\"`")
+
+	assert.Contains(t, codes[CodeTypeJSON], `"is_active": false`)
+	assert.Contains(t, codes[CodeTypeProto], "bool is_active = 4;")
+	assert.Contains(t, codes[CodeTypeProto], "string tags = 5;")
+	assert.Contains(t, codes[CodeTypeProto], "int64 login_at = 8;")
+
+	// the same output as the equivalent mysql ddl, except that boolean is mapped to bool instead of tinyint(1)
+	mysqlSQL := "CREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` varchar(50) NOT NULL COMMENT 'user''s name', " +
+		"`email` varchar(100) UNIQUE, `age` int, `is_active` tinyint(1) NOT NULL, `tags` text, `profile` json, " +
+		"`balance` decimal(10,2), `login_at` timestamp, `created_at` timestamp NOT NULL, KEY `idx_user_name` (`name`)) COMMENT='user table';"
+	pgCodes, err := ParseSQL(pgSQL[:strings.Index(pgSQL, "/*")], WithDBDriver(DBDriverPostgresql))
+	assert.Nil(t, err)
+	mysqlCodes, err := ParseSQL(mysqlSQL)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.ReplaceAll(mysqlCodes[CodeTypeProto], "int32 is_active", "bool is_active"), pgCodes[CodeTypeProto])
+	assert.Equal(t, strings.ReplaceAll(mysqlCodes[CodeTypeDAOColumns], `"is_active", Type: query.TypeInt`, `"is_active", Type: query.TypeBool`),
+		pgCodes[CodeTypeDAOColumns])
+
+	_, err = ParseSQL(pgSQL, WithDBDriver("sqlite"))
+	assert.Error(t, err)
+	_, err = ParseSQL("CREATE TABLE foo (id integer", WithDBDriver(DBDriverPostgresql))
+	assert.Error(t, err)
+}
+
+func Test_convertPostgresqlDDL(t *testing.T) {
+	sql := `CREATE TABLE public.account (
+    id integer NOT NULL,
+    "Name" text COLLATE pg_catalog."default",
+    deleted_at timestamp without time zone
+);
+CREATE SEQUENCE public.account_id_seq;
+ALTER TABLE ONLY public.account ALTER COLUMN id SET DEFAULT nextval('public.account_id_seq'::regclass);
+ALTER TABLE ONLY public.account ADD CONSTRAINT account_pkey PRIMARY KEY (id);
+COMMENT ON COLUMN public.account."Name" IS NULL;`
+
+	ddl, columns, err := convertPostgresqlDDL(sql)
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE TABLE `account` (\n"+
+		"  `id` int NOT NULL AUTO_INCREMENT,\n"+
+		"  `Name` text,\n"+
+		"  `deleted_at` datetime,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		");\n", ddl)
+	assert.Equal(t, "timestamp without time zone", columns["account"]["deleted_at"].dbType)
+}
+
+func TestParseSQL_postgresqlQuotedType(t *testing.T) {
+	sql := `CREATE TYPE public."Status" AS ENUM ('active', 'banned');
+CREATE TABLE public.flag (
+    id bigint NOT NULL PRIMARY KEY,
+    e "char" NOT NULL,
+    status public."Status" DEFAULT 'active'::public."Status",
+    statuses "Status"[]
+);`
+
+	codes, err := ParseSQL(sql, WithDBDriver(DBDriverPostgresql), WithGormType(), WithJSONTag(0))
+	assert.Nil(t, err)
+	model := codes[CodeTypeModel]
+	assert.Contains(t, model, "`gorm:\"column:e;type:char;NOT NULL\" json:\"e\"`")
+	assert.Contains(t, model, "`gorm:\"column:status;type:public.Status;default:active\" json:\"status\"`")
+	assert.Contains(t, model, "`gorm:\"column:statuses;type:Status[]\" json:\"statuses\"`")
+	assert.Contains(t, model, "E        string ")
+
+	assert.Equal(t, `public.Status[]`, unquoteTypeName(`public."Status"[]`))
+}
+
+func Test_pgToMysqlType(t *testing.T) {
+	testData := map[string]string{
+		"int2":                        "smallint",
+		"serial":                      "int",
+		"double precision":            "double",
+		"numeric":                     "decimal",
+		"NUMERIC(10, 2)":              "decimal(10,2)",
+		"character varying":           "varchar(255)",
+		"character(2)":                "char(2)",
+		"timestamp(3)":                "datetime",
+		"timestamp(6) with time zone": "timestamp",
+		"integer[]":                   "text",
+		"int ARRAY":                   "text",
+		"uuid":                        "varchar(36)",
+		"interval":                    "text",
+		"pg_catalog.int8":             "bigint",
+	}
+	for pgType, mysqlType := range testData {
+		tp, _, _ := pgToMysqlType(pgType)
+		assert.Equal(t, mysqlType, tp, pgType)
+	}
+
+	_, isAutoIncrement, _ := pgToMysqlType("bigserial")
+	assert.True(t, isAutoIncrement)
+	_, _, isBool := pgToMysqlType("bool")
+	assert.True(t, isBool)
+}
+
+func Test_pgDefaultValue(t *testing.T) {
+	testData := map[string]string{
+		"'foo'::character varying": "foo",
+		"'it''s'::text":            "it's",
+		"(0)::numeric":             "0",
+		"'2020-01-01 00:00:00'::timestamp without time zone": "2020-01-01 00:00:00",
+		"CURRENT_TIMESTAMP":       "CURRENT_TIMESTAMP",
+		"NULL::character varying": "",
+	}
+	for expr, value := range testData {
+		v, isSequence := pgDefaultValue(expr)
+		assert.False(t, isSequence, expr)
+		assert.Equal(t, value, v, expr)
+	}
+
+	_, isSequence := pgDefaultValue("nextval('user_id_seq'::regclass)")
+	assert.True(t, isSequence)
+}
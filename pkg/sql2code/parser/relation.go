@@ -0,0 +1,332 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/tidbparser/ast"
+	"github.com/huandu/xstrings"
+	"github.com/jinzhu/inflection"
+)
+
+// association types of the tables
+const (
+	relationBelongsTo = "belongsTo"
+	relationHasMany   = "hasMany"
+	relationMany2Many = "many2many"
+)
+
+// tmplRelation association field of the table
+type tmplRelation struct {
+	Kind      string // belongsTo, hasMany, many2many
+	Name      string // field name of the association, e.g. User, Orders, Roles
+	JSONName  string
+	Table     string // camel name of the associated table, e.g. Order
+	RawTable  string // name of the associated table, e.g. order
+	GormTag   string // gorm tag of the association field, e.g. foreignKey:UserID
+	Tag       string // tags of the association field in model
+	KeyName   string // field name of the key which the dao method lists the records by, e.g. UserID
+	KeyColumn string // column of the key, e.g. user_id, user_role.user_id
+	KeyType   string // go type of the key
+	JoinTable string // join table of many2many
+	JoinOn    string // join condition of many2many, e.g. user_role.role_id = role.id
+	Nested    string // name of the nested response type, e.g. UserOrdersDetail
+	Fields    []tmplField
+}
+
+// IsMany whether the association is a slice
+func (r *tmplRelation) IsMany() bool {
+	return r.Kind != relationBelongsTo
+}
+
+// ListMethod name of the dao method which lists the associated records, e.g. ListOrdersByUserID
+func (r *tmplRelation) ListMethod() string {
+	return "List" + inflection.Plural(r.Table) + "By" + r.KeyName
+}
+
+// KeyParam parameter name of the key, e.g. userID
+func (r *tmplRelation) KeyParam() string {
+	return firstLetterToLow(r.KeyName)
+}
+
+type foreignKey struct {
+	column    string
+	refTable  string
+	refColumn string
+}
+
+type tableMeta struct {
+	stmt        *ast.CreateTableStmt
+	data        tmplData
+	primaryKeys []string
+	foreignKeys []foreignKey
+}
+
+// the column of the single-column primary key, empty if the primary key is composite or missing
+func (t *tableMeta) singlePrimaryKey() string {
+	if len(t.primaryKeys) == 1 {
+		return t.primaryKeys[0]
+	}
+	return ""
+}
+
+func (t *tableMeta) field(colName string) (tmplField, bool) {
+	for _, field := range t.data.Fields {
+		if field.ColName == colName {
+			return field, true
+		}
+	}
+	return tmplField{}, false
+}
+
+// the table is a join table if it has two foreign keys referencing different tables, and the other columns are
+// id and time columns
+func (t *tableMeta) isJoinTable() bool {
+	if len(t.foreignKeys) != 2 || t.foreignKeys[0].refTable == t.foreignKeys[1].refTable {
+		return false
+	}
+	for _, field := range t.data.Fields {
+		if field.ColName == t.foreignKeys[0].column || field.ColName == t.foreignKeys[1].column {
+			continue
+		}
+		if _, ok := ignoreColumns[field.ColName]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// makeRelations get the associations of the tables from the foreign keys and the column names like <table>_id,
+// the tables with two foreign keys only are join tables of many2many.
+func makeRelations(stmts []*ast.CreateTableStmt, opt options) (map[string][]*tmplRelation, error) {
+	tables := make([]*tableMeta, 0, len(stmts))
+	tableMap := make(map[string]*tableMeta, len(stmts))
+	for _, stmt := range stmts {
+		data, _, err := makeTmplData(stmt, opt)
+		if err != nil {
+			return nil, err
+		}
+		t := &tableMeta{stmt: stmt, data: data, primaryKeys: getPrimaryKeys(stmt)}
+		tables = append(tables, t)
+		tableMap[data.RawTableName] = t
+	}
+
+	for _, t := range tables {
+		t.foreignKeys = getForeignKeys(t, tableMap)
+	}
+
+	relations := make(map[string][]*tmplRelation, len(tables))
+	add := func(owner *tableMeta, r *tmplRelation) {
+		for _, field := range owner.data.Fields {
+			if field.Name == r.Name {
+				return
+			}
+		}
+		for _, v := range relations[owner.data.RawTableName] {
+			if v.Name == r.Name {
+				return
+			}
+		}
+		r.JSONName = xstrings.ToSnakeCase(r.Name)
+		if opt.JSONNamedType != 0 {
+			r.JSONName = xstrings.FirstRuneToLower(r.Name)
+		}
+		r.Tag = `gorm:"` + r.GormTag + `"`
+		if opt.JSONTag {
+			r.Tag += ` json:"` + r.JSONName + `,omitempty"`
+		}
+		r.Nested = owner.data.TableName + r.Name + "Detail"
+		relations[owner.data.RawTableName] = append(relations[owner.data.RawTableName], r)
+	}
+
+	for _, child := range tables {
+		isJoinTable := child.isJoinTable()
+		for _, fk := range child.foreignKeys {
+			parent := tableMap[fk.refTable]
+			fkField, _ := child.field(fk.column)
+			refTag := ""
+			if fk.refColumn != columnID {
+				if refField, ok := parent.field(fk.refColumn); ok {
+					refTag = ";references:" + refField.Name
+				}
+			}
+
+			// the child belongs to the parent
+			add(child, &tmplRelation{
+				Kind:     relationBelongsTo,
+				Name:     associationName(fk.column, parent.data.TableName),
+				Table:    parent.data.TableName,
+				RawTable: parent.data.RawTableName,
+				GormTag:  "foreignKey:" + fkField.Name + refTag,
+				Fields:   detailFields(parent.data.Fields),
+			})
+			if isJoinTable {
+				continue
+			}
+
+			// the parent has many children
+			name := inflection.Plural(child.data.TableName)
+			if countForeignKeys(child, fk.refTable) > 1 {
+				name += "By" + associationName(fk.column, "")
+			}
+			add(parent, &tmplRelation{
+				Kind:      relationHasMany,
+				Name:      name,
+				Table:     child.data.TableName,
+				RawTable:  child.data.RawTableName,
+				GormTag:   "foreignKey:" + fkField.Name + refTag,
+				KeyName:   fkField.Name,
+				KeyColumn: fk.column,
+				KeyType:   keyGoType(fkField.GoType),
+				Fields:    detailFields(child.data.Fields),
+			})
+		}
+
+		if !isJoinTable {
+			continue
+		}
+		for i, fk := range child.foreignKeys {
+			other := child.foreignKeys[1-i]
+			owner, target := tableMap[fk.refTable], tableMap[other.refTable]
+			fkField, _ := child.field(fk.column)
+			name := inflection.Plural(associationName(other.column, target.data.TableName))
+			add(owner, &tmplRelation{
+				Kind:     relationMany2Many,
+				Name:     name,
+				Table:    target.data.TableName,
+				RawTable: target.data.RawTableName,
+				GormTag: "many2many:" + child.data.RawTableName + ";joinForeignKey:" + toCamel(fk.column) +
+					";joinReferences:" + toCamel(other.column),
+				KeyName:   toCamel(fk.column),
+				KeyColumn: child.data.RawTableName + "." + fk.column,
+				KeyType:   keyGoType(fkField.GoType),
+				JoinTable: child.data.RawTableName,
+				JoinOn:    child.data.RawTableName + "." + other.column + " = " + target.data.RawTableName + "." + other.refColumn,
+				Fields:    detailFields(target.data.Fields),
+			})
+		}
+	}
+
+	return relations, nil
+}
+
+// associationName get the name of the association from the foreign key column, e.g. user_id is User,
+// created_by is CreatedByUser
+func associationName(column string, table string) string {
+	if strings.HasSuffix(column, "_id") && len(column) > 3 {
+		return toCamel(strings.TrimSuffix(column, "_id"))
+	}
+	return toCamel(column) + table
+}
+
+func countForeignKeys(t *tableMeta, refTable string) int {
+	count := 0
+	for _, fk := range t.foreignKeys {
+		if fk.refTable == refTable {
+			count++
+		}
+	}
+	return count
+}
+
+// getPrimaryKeys get the columns of the primary key, the composite primary key has several columns
+func getPrimaryKeys(stmt *ast.CreateTableStmt) []string {
+	for _, con := range stmt.Constraints {
+		if con.Tp == ast.ConstraintPrimaryKey && len(con.Keys) > 0 {
+			columns := make([]string, 0, len(con.Keys))
+			for _, key := range con.Keys {
+				columns = append(columns, key.Column.String())
+			}
+			return columns
+		}
+	}
+	for _, col := range stmt.Cols {
+		for _, o := range col.Options {
+			if o.Tp == ast.ColumnOptionPrimaryKey {
+				return []string{col.Name.Name.String()}
+			}
+		}
+	}
+	return nil
+}
+
+// getForeignKeys get the foreign keys referencing the tables in the ddl, the column named <table>_id without
+// foreign key references the id of the table.
+func getForeignKeys(t *tableMeta, tableMap map[string]*tableMeta) []foreignKey {
+	var fks []foreignKey
+	isForeignKey := map[string]bool{}
+	addForeignKey := func(column string, refer *ast.ReferenceDef) {
+		if refer == nil || refer.Table == nil || isForeignKey[column] {
+			return
+		}
+		parent, ok := tableMap[refer.Table.Name.String()]
+		if !ok {
+			return
+		}
+		refColumn := parent.singlePrimaryKey()
+		if len(refer.IndexColNames) > 0 {
+			refColumn = refer.IndexColNames[0].Column.String()
+		}
+		if refColumn == "" {
+			return
+		}
+		isForeignKey[column] = true
+		fks = append(fks, foreignKey{column: column, refTable: parent.data.RawTableName, refColumn: refColumn})
+	}
+
+	for _, con := range t.stmt.Constraints {
+		if con.Tp == ast.ConstraintForeignKey && len(con.Keys) > 0 {
+			addForeignKey(con.Keys[0].Column.String(), con.Refer)
+		}
+	}
+	for _, col := range t.stmt.Cols {
+		for _, o := range col.Options {
+			if o.Tp == ast.ColumnOptionReference {
+				addForeignKey(col.Name.Name.String(), o.Refer)
+			}
+		}
+	}
+
+	// naming convention
+	for _, field := range t.data.Fields {
+		colName := field.ColName
+		// the column of the composite primary key may be a foreign key, e.g. order_id of order_tag(order_id, tag_id)
+		if isForeignKey[colName] || colName == t.singlePrimaryKey() || !strings.HasSuffix(colName, "_id") {
+			continue
+		}
+		name := strings.TrimSuffix(colName, "_id")
+		for _, tableName := range []string{name, inflection.Plural(name)} {
+			if parent, ok := tableMap[tableName]; ok && parent.singlePrimaryKey() == columnID {
+				isForeignKey[colName] = true
+				fks = append(fks, foreignKey{column: colName, refTable: tableName, refColumn: columnID})
+				break
+			}
+		}
+	}
+
+	return fks
+}
+
+// the fields of the nested response type, the deleted_at column is ignored
+func detailFields(fields []tmplField) []tmplField {
+	newFields := make([]tmplField, 0, len(fields))
+	for _, field := range fields {
+		if field.ColName != columnDeletedAt {
+			newFields = append(newFields, field)
+		}
+	}
+	return newFields
+}
+
+// keyGoType the go type of the key parameter, e.g. sql.NullInt64 is int64
+func keyGoType(goType string) string {
+	goType = strings.TrimPrefix(goType, "*")
+	switch goType {
+	case "sql.NullInt64":
+		return "int64"
+	case "sql.NullInt32":
+		return "int32"
+	case "sql.NullString":
+		return "string"
+	}
+	return goType
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var relationSQL = "CREATE TABLE `user` (`id` bigint unsigned NOT NULL AUTO_INCREMENT, `name` varchar(50) NOT NULL, " +
+	"`created_at` datetime, `deleted_at` datetime, PRIMARY KEY (`id`));\n" +
+	"CREATE TABLE `order` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `user_id` bigint unsigned NOT NULL, " +
+	"`amount` int, `created_by` bigint unsigned, FOREIGN KEY (`created_by`) REFERENCES `user` (`id`));\n" +
+	"CREATE TABLE `role` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` varchar(20));\n" +
+	"CREATE TABLE `user_role` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, " +
+	"`user_id` bigint unsigned NOT NULL, `role_id` bigint unsigned NOT NULL);"
+
+func TestParseSQL_relations(t *testing.T) {
+	codes, err := ParseSQL(relationSQL, WithJSONTag(1), WithEmbed())
+	assert.Nil(t, err)
+
+	model := codes[CodeTypeModel]
+	assert.Contains(t, model, "OrdersByCreatedBy []*Order `gorm:\"foreignKey:CreatedBy\" json:\"ordersByCreatedBy,omitempty\"`")
+	assert.Contains(t, model, "OrdersByUser      []*Order `gorm:\"foreignKey:UserID\" json:\"ordersByUser,omitempty\"`")
+	assert.Contains(t, model, "Roles             []*Role  `gorm:\"many2many:user_role;joinForeignKey:UserID;joinReferences:RoleID\" json:\"roles,omitempty\"`")
+	assert.Contains(t, model, "CreatedByUser *User  `gorm:\"foreignKey:CreatedBy\" json:\"createdByUser,omitempty\"`")
+	assert.Contains(t, model, "User          *User  `gorm:\"foreignKey:UserID\" json:\"user,omitempty\"`")
+	assert.Contains(t, model, "Users []*User `gorm:\"many2many:user_role;joinForeignKey:RoleID;joinReferences:UserID\" json:\"users,omitempty\"`")
+
+	daoInterface := codes[CodeTypeDAORelationInterface]
+	assert.Contains(t, daoInterface, "GetByIDWithPreload(ctx context.Context, id uint64, associations ...string) (*model.User, error)")
+	assert.Contains(t, daoInterface, "ListOrdersByUserID(ctx context.Context, userID uint64) ([]*model.Order, error)")
+	assert.Contains(t, daoInterface, "ListUsersByRoleID(ctx context.Context, roleID uint64) ([]*model.User, error)")
+	dao := codes[CodeTypeDAORelation]
+	assert.Contains(t, dao, `associations = []string{"OrdersByCreatedBy", "OrdersByUser", "Roles"}`)
+	assert.Contains(t, dao, `Where("created_by = ?", createdBy)`)
+	assert.Contains(t, dao, `Joins("JOIN user_role ON user_role.role_id = role.id").`+"\n\t\t"+`Where("user_role.user_id = ?", userID)`)
+
+	handler := codes[CodeTypeHandler]
+	assert.Contains(t, handler, "Roles  []*UserRolesDetail `json:\"roles,omitempty\"`")
+	assert.Contains(t, handler, "User  *OrderUserDetail `json:\"user,omitempty\"`")
+	assert.Contains(t, handler, "type OrderUserDetail struct {")
+	assert.NotContains(t, handler, "DeletedAt")
+
+	proto := codes[CodeTypeProto]
+	assert.Contains(t, proto, "repeated UserRolesDetail roles = 6;")
+	assert.Contains(t, proto, "message UserRolesDetail {")
+
+	// the id of the nested types is uint64 as the top-level type, even if the id column is signed
+	for _, isEmbed := range []bool{true, false} {
+		opts := []Option{WithJSONTag(1)}
+		if isEmbed {
+			opts = append(opts, WithEmbed())
+		}
+		codes, err = ParseSQL(strings.ReplaceAll(relationSQL, "`id` bigint unsigned", "`id` int"), opts...)
+		assert.Nil(t, err)
+		proto = codes[CodeTypeProto]
+		assert.Contains(t, proto, "message UserRolesDetail {\n\tuint64 id = 1; ")
+		assert.Contains(t, proto, "message User {\n\tuint64 id = 1; ")
+		assert.NotContains(t, proto, "int32 id = 1;")
+		assert.Contains(t, codes[CodeTypeHandler], "type UserRolesDetail struct {\n\tID  uint64 `json:\"id\"`")
+	}
+
+	// the join table with the composite primary key
+	codes, err = ParseSQL("CREATE TABLE `order` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `amount` int);\n"+
+		"CREATE TABLE `tag` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` varchar(20));\n"+
+		"CREATE TABLE `order_tag` (`order_id` bigint unsigned NOT NULL, `tag_id` bigint unsigned NOT NULL, PRIMARY KEY (`order_id`, `tag_id`));",
+		WithJSONTag(1))
+	assert.Nil(t, err)
+	model = codes[CodeTypeModel]
+	assert.Contains(t, model, "Tags   []*Tag `gorm:\"many2many:order_tag;joinForeignKey:OrderID;joinReferences:TagID\" json:\"tags,omitempty\"`")
+	assert.Contains(t, model, "Orders []*Order `gorm:\"many2many:order_tag;joinForeignKey:TagID;joinReferences:OrderID\" json:\"orders,omitempty\"`")
+	assert.NotContains(t, model, "OrderTags")
+
+	// the associations with the tables in the related sql
+	codes, err = ParseSQL("CREATE TABLE `order` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `user_id` bigint unsigned NOT NULL);",
+		WithRelatedSQL(relationSQL), WithJSONTag(0))
+	assert.Nil(t, err)
+	assert.Equal(t, "Order", codes[TableName])
+	assert.Contains(t, codes[CodeTypeModel], "User   *User  `gorm:\"foreignKey:UserID\" json:\"user,omitempty\"`")
+	assert.NotContains(t, codes[CodeTypeModel], "CreatedByUser")
+	assert.Contains(t, codes[CodeTypeHandler], "type OrderUserDetail struct {")
+
+	// no associations
+	codes, err = ParseSQL("CREATE TABLE `order` (`id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY, `user_id` bigint unsigned NOT NULL);")
+	assert.Nil(t, err)
+	assert.Empty(t, codes[CodeTypeDAORelation])
+	assert.Empty(t, codes[CodeTypeDAORelationInterface])
+}
+
+func TestParseSQL_postgresqlRelations(t *testing.T) {
+	sql := `CREATE TABLE "user" (id bigserial PRIMARY KEY, name text);
+CREATE TABLE article (id serial PRIMARY KEY, title text, author integer REFERENCES "user" ON DELETE CASCADE, editor integer);
+ALTER TABLE ONLY public.article ADD CONSTRAINT article_editor_fkey FOREIGN KEY (editor) REFERENCES public."user"(id);`
+
+	ddl, _, err := convertPostgresqlDDL(sql)
+	assert.Nil(t, err)
+	assert.Contains(t, ddl, "  FOREIGN KEY (`editor`) REFERENCES `user` (`id`),\n  FOREIGN KEY (`author`) REFERENCES `user` (`id`)\n")
+
+	codes, err := ParseSQL(sql, WithDBDriver(DBDriverPostgresql), WithJSONTag(0))
+	assert.Nil(t, err)
+	assert.Contains(t, codes[CodeTypeModel], "ArticlesByAuthor []*Article `gorm:\"foreignKey:Author\" json:\"articles_by_author,omitempty\"`")
+	assert.Contains(t, codes[CodeTypeModel], "EditorUser *User  `gorm:\"foreignKey:Editor\" json:\"editor_user,omitempty\"`")
+}
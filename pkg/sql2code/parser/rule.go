@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blastrain/vitess-sqlparser/tidbparser/dependency/mysql"
+	"github.com/blastrain/vitess-sqlparser/tidbparser/dependency/types"
+)
+
+// tmplRule validation rules of the column derived from the column constraints, they are used in the binding tags
+// of the http request structs and the protoc-gen-validate rules of the proto request messages.
+type tmplRule struct {
+	IsRequired bool     // NOT NULL column without default value, except boolean
+	MaxLength  int      // max characters of char and varchar
+	Min        string   // min value of the unsigned float
+	Max        string   // max value of the unsigned integer
+	Enums      []string // values of enum
+	Sets       []string // values of set
+}
+
+// the max values of the unsigned integer types which are narrower than the uint32 of proto,
+// the ranges which are the same as the type are not emitted.
+var uintMaxValues = map[byte]string{
+	mysql.TypeTiny:  "255",
+	mysql.TypeShort: "65535",
+	mysql.TypeInt24: "16777215",
+}
+
+// makeRule get the validation rules of the column, the ranges are only derived from the constraints which narrow the type,
+// i.e. unsigned and enum, the NOT NULL column without default value is required in the create request.
+func makeRule(tp *types.FieldType, isNotNull bool, hasDefault bool, pgCol *pgColumn) tmplRule {
+	rule := tmplRule{}
+	isUnsigned := mysql.HasUnsignedFlag(tp.Flag)
+	switch tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+		// the boolean of postgresql and tinyint(1) of mysql, false is a valid value
+		if pgCol != nil && pgCol.isBool || tp.Tp == mysql.TypeTiny && tp.Flen == 1 {
+			break
+		}
+		if isUnsigned {
+			rule.Max = uintMaxValues[tp.Tp]
+		}
+		rule.IsRequired = isNotNull && !hasDefault
+	case mysql.TypeFloat, mysql.TypeDouble:
+		if isUnsigned {
+			rule.Min = "0"
+		}
+		rule.IsRequired = isNotNull && !hasDefault
+	case mysql.TypeDecimal, mysql.TypeNewDecimal:
+		rule.IsRequired = isNotNull && !hasDefault
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString:
+		// the length of the postgresql varchar without length is not limited
+		if tp.Flen > 0 && (pgCol == nil || strings.Contains(pgCol.dbType, "(")) {
+			rule.MaxLength = tp.Flen
+		}
+		rule.IsRequired = isNotNull && !hasDefault
+	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		rule.IsRequired = isNotNull && !hasDefault
+	case mysql.TypeEnum:
+		rule.Enums = tp.Elems
+		rule.IsRequired = isNotNull && !hasDefault
+	case mysql.TypeSet:
+		rule.Sets = tp.Elems
+	}
+	return rule
+}
+
+// BindingRules the rules of the binding tag in the create request, e.g. required,max=32
+func (t tmplField) BindingRules() string {
+	return t.bindingRules(t.Rule.IsRequired)
+}
+
+// UpdateBindingRules the rules of the binding tag in the update request, the fields are optional
+func (t tmplField) UpdateBindingRules() string {
+	return t.bindingRules(false)
+}
+
+func (t tmplField) bindingRules(isRequired bool) string {
+	rule := t.Rule
+	var rules []string
+	switch strings.TrimPrefix(t.GoType, "*") {
+	case "string":
+		if rule.MaxLength > 0 {
+			rules = append(rules, "max="+strconv.Itoa(rule.MaxLength))
+		}
+		if values, ok := oneofValues(rule.Enums); ok {
+			rules = append(rules, "oneof="+values)
+		}
+		if isRequired {
+			rules = append([]string{"required"}, rules...)
+		} else if len(rules) > 0 {
+			rules = append([]string{"omitempty"}, rules...)
+		}
+	case "int", "uint", "int64", "uint64", "float64":
+		if isRequired {
+			rules = append(rules, "required")
+		}
+		if rule.Min != "" {
+			rules = append(rules, "min="+rule.Min)
+		}
+		if rule.Max != "" {
+			rules = append(rules, "max="+rule.Max)
+		}
+	}
+	return strings.Join(rules, ",")
+}
+
+// ValidateRules the protoc-gen-validate rules of the field in the create request message,
+// e.g. [(validate.rules).string = {min_len: 1, max_len: 32}]
+func (t tmplField) ValidateRules() string {
+	return t.validateRules(t.Rule.IsRequired)
+}
+
+// UpdateValidateRules the protoc-gen-validate rules of the field in the update request message,
+// the empty value is ignored
+func (t tmplField) UpdateValidateRules() string {
+	return t.validateRules(false)
+}
+
+func (t tmplField) validateRules(isRequired bool) string {
+	rule := t.Rule
+	var rules []string
+	switch t.GoType {
+	case "string":
+		if isRequired {
+			rules = append(rules, "min_len: 1")
+		}
+		if rule.MaxLength > 0 {
+			rules = append(rules, "max_len: "+strconv.Itoa(rule.MaxLength))
+		}
+		if len(rule.Enums) > 0 {
+			values := make([]string, 0, len(rule.Enums))
+			for _, v := range rule.Enums {
+				values = append(values, strconv.Quote(v))
+			}
+			rules = append(rules, "in: ["+strings.Join(values, ", ")+"]")
+		}
+		if len(rule.Sets) > 0 {
+			values := make([]string, 0, len(rule.Sets))
+			for _, v := range rule.Sets {
+				values = append(values, regexp.QuoteMeta(v))
+			}
+			pattern := "(" + strings.Join(values, "|") + ")"
+			rules = append(rules, "pattern: "+strconv.Quote("^"+pattern+"(,"+pattern+")*$"))
+		}
+		if !isRequired && len(rules) > 0 {
+			rules = append(rules, "ignore_empty: true")
+		}
+	case "int32", "uint32", "int64", "uint64", "double":
+		// the zero value of proto3 can't be distinguished from the missing value, so it is rejected
+		if isRequired {
+			rules = append(rules, "not_in: [0]")
+		}
+		if rule.Min != "" {
+			rules = append(rules, "gte: "+rule.Min)
+		}
+		if rule.Max != "" {
+			rules = append(rules, "lte: "+rule.Max)
+		}
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" [(validate.rules).%s = {%s}]", t.GoType, strings.Join(rules, ", "))
+}
+
+// oneofValues the values of the oneof rule, the value with spaces is quoted by single quotes,
+// the values which can't be expressed in the binding tag are not supported.
+func oneofValues(enums []string) (string, bool) {
+	if len(enums) == 0 {
+		return "", false
+	}
+	values := make([]string, 0, len(enums))
+	for _, v := range enums {
+		if v == "" || strings.ContainsAny(v, "',|\"`") {
+			return "", false
+		}
+		if strings.Contains(v, " ") {
+			v = "'" + v + "'"
+		}
+		values = append(values, v)
+	}
+	return strings.Join(values, " "), true
+}
@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ruleSQL = "CREATE TABLE `member` (\n" +
+	"  `id` int unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `name` varchar(32) NOT NULL,\n" +
+	"  `nickname` varchar(20) NOT NULL DEFAULT '',\n" +
+	"  `age` tinyint unsigned NOT NULL DEFAULT 0,\n" +
+	"  `score` smallint,\n" +
+	"  `balance` double unsigned,\n" +
+	"  `role` enum('admin','normal user') NOT NULL,\n" +
+	"  `tags` set('a','b.c') DEFAULT NULL,\n" +
+	"  `bio` text NOT NULL,\n" +
+	"  `total` bigint,\n" +
+	"  `tenant_id` bigint unsigned NOT NULL,\n" +
+	"  `quota` int unsigned NOT NULL DEFAULT 0,\n" +
+	"  `version` int NOT NULL DEFAULT 0,\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	");"
+
+func TestParseSQL_rules(t *testing.T) {
+	codes, err := ParseSQL(ruleSQL, WithJSONTag(1))
+	assert.Nil(t, err)
+
+	handler := codes[CodeTypeHandler]
+	createStruct := handler[:indexOf(t, handler, "type UpdateMemberByIDRequest struct")]
+	assert.Contains(t, createStruct, "`json:\"name\" binding:\"required,max=32\"`")
+	assert.Contains(t, createStruct, "`json:\"nickname\" binding:\"omitempty,max=20\"`")
+	assert.Contains(t, createStruct, "`json:\"age\" binding:\"max=255\"`")
+	assert.Contains(t, createStruct, "`json:\"score\" binding:\"\"`")
+	assert.Contains(t, createStruct, "`json:\"balance\" binding:\"min=0\"`")
+	assert.Contains(t, createStruct, "`json:\"role\" binding:\"required,oneof=admin 'normal user'\"`")
+	assert.Contains(t, createStruct, "`json:\"tags\" binding:\"\"`")
+	assert.Contains(t, createStruct, "`json:\"bio\" binding:\"required\"`")
+	assert.Contains(t, createStruct, "`json:\"total\" binding:\"\"`")
+	assert.Contains(t, createStruct, "`json:\"tenantId\" binding:\"required\"`")
+	assert.Contains(t, createStruct, "`json:\"quota\" binding:\"\"`")
+	assert.NotContains(t, createStruct, "version")
+
+	updateStruct := handler[indexOf(t, handler, "type UpdateMemberByIDRequest struct"):]
+	assert.Contains(t, updateStruct, "`json:\"id\" binding:\"\"`")
+	assert.Contains(t, updateStruct, "`json:\"name\" binding:\"omitempty,max=32\"`")
+	assert.Contains(t, updateStruct, "`json:\"role\" binding:\"omitempty,oneof=admin 'normal user'\"`")
+	assert.Contains(t, updateStruct, "`json:\"bio\" binding:\"\"`")
+	assert.Contains(t, updateStruct, "`json:\"tenantId\" binding:\"\"`")
+	assert.Contains(t, updateStruct, "`json:\"version\" binding:\"\"`")
+
+	proto := codes[CodeTypeProto]
+	assert.Contains(t, proto, "\nimport \"validate/validate.proto\";")
+	assert.Contains(t, proto, `string name = 1 [(validate.rules).string = {min_len: 1, max_len: 32}];`)
+	assert.Contains(t, proto, `string nickname = 2 [(validate.rules).string = {max_len: 20, ignore_empty: true}];`)
+	assert.Contains(t, proto, `uint32 age = 3 [(validate.rules).uint32 = {lte: 255}];`)
+	assert.Contains(t, proto, "int32 score = 4;")
+	assert.Contains(t, proto, `double balance = 5 [(validate.rules).double = {gte: 0}];`)
+	assert.Contains(t, proto, `string role = 6 [(validate.rules).string = {min_len: 1, in: ["admin", "normal user"]}];`)
+	assert.Contains(t, proto, `string tags = 7 [(validate.rules).string = {pattern: "^(a|b\\.c)(,(a|b\\.c))*$", ignore_empty: true}];`)
+	assert.Contains(t, proto, `string bio = 8 [(validate.rules).string = {min_len: 1}];`)
+	assert.Contains(t, proto, "int64 total = 9;")
+	assert.Contains(t, proto, `uint64 tenantId = 10 [(validate.rules).uint64 = {not_in: [0]}];`)
+	assert.Contains(t, proto, "uint32 quota = 11;")
+	assert.Contains(t, proto, "uint64 tenantId = 11;") // update request
+	assert.Contains(t, proto, "int32 version = 13;")   // update request
+	assert.NotContains(t, proto, "int32 version = 12")
+	assert.Contains(t, proto, "uint64 id = 1; \n\tstring name = 2 [(validate.rules).string = {max_len: 32, ignore_empty: true}];") // update request
+	assert.Contains(t, proto, "string bio = 9;")
+
+	codes, err = ParseSQL(ruleSQL, WithJSONTag(1), WithWebProto())
+	assert.Nil(t, err)
+	assert.Contains(t, codes[CodeTypeProto], `uint64 id = 1 [(tagger.tags) = "uri:\"id\"" ]; `)
+	assert.Contains(t, codes[CodeTypeProto], `string name = 2 [(validate.rules).string = {max_len: 32, ignore_empty: true}];`)
+
+	// enum and set are string
+	assert.Contains(t, codes[CodeTypeModel], "Role     string ")
+	assert.Contains(t, codes[CodeTypeModel], "Tags     string ")
+
+	// no rules, the validate.proto is not imported
+	codes, err = ParseSQL("CREATE TABLE `foo` (`id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY, `total` bigint);")
+	assert.Nil(t, err)
+	assert.Contains(t, codes[CodeTypeProto], "//import \"validate/validate.proto\";")
+}
+
+func Test_oneofValues(t *testing.T) {
+	values, ok := oneofValues([]string{"a", "b c"})
+	assert.True(t, ok)
+	assert.Equal(t, "a 'b c'", values)
+
+	for _, enums := range [][]string{nil, {"a", ""}, {"a,b"}, {"it's"}} {
+		_, ok = oneofValues(enums)
+		assert.False(t, ok, enums)
+	}
+}
+
+func indexOf(t *testing.T, s string, substr string) int {
+	i := strings.Index(s, substr)
+	if i < 0 {
+		t.Fatalf("%q not found", substr)
+	}
+	return i
+}
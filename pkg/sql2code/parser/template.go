@@ -17,6 +17,9 @@ type {{.TableName}} struct {
 {{- range .Fields}}
 	{{.Name}} {{.GoType}} {{if .Tag}}` + "`{{.Tag}}`" + `{{end}}{{if .Comment}} // {{.Comment}}{{end}}
 {{- end}}
+{{- range .Relations}}
+	{{.Name}} {{if .IsMany}}[]{{end}}*{{.Table}} ` + "`{{.Tag}}`" + `
+{{- end}}
 }
 {{if .NameFunc}}
 // TableName table name
@@ -24,6 +27,16 @@ func (m *{{.TableName}}) TableName() string {
 	return "{{.RawTableName}}"
 }
 {{end}}
+{{- if .ShardingKey}}
+// {{.TableName}}ShardingRule the table is sharded by {{.ShardingKey}}, set the IDGenerator, e.g. sharding.MustNewSnowflake(nodeID).NextID,
+// and register it by mysql.WithGormPlugin(sharding.New({{.TableName}}ShardingRule)), the node id must be unique for each instance
+var {{.TableName}}ShardingRule = sharding.Rule{
+	Table:     "{{.RawTableName}}",
+	Key:       "{{.ShardingKey}}",
+	Algorithm: sharding.MustParseAlgorithm("{{.ShardingAlgorithm}}"),
+	// IDGenerator is required, the auto increment ids of the shards collide in GetByID and the cache of the ids
+}
+{{end}}
 `
 
 	modelTmpl    *template.Template
@@ -42,9 +55,441 @@ import (
 	updateFieldTmpl    *template.Template
 	updateFieldTmplRaw = `
 {{- range .Fields}}
+{{- if .IsVersion}}
+	if table.{{.Name}} {{.ConditionZero}} {
+		// optimistic locking, the record is updated only if the version has not been changed,
+		// the new version is assigned to table after the update
+		tx = tx.Where("{{.ColName}} = ?", table.{{.Name}})
+		update["{{.ColName}}"] = table.{{.Name}} + 1
+	} else {
+		// the version is not checked if it is not specified
+		update["{{.ColName}}"] = gorm.Expr("{{.ColName}} + 1")
+	}
+{{- else}}
 	if table.{{.Name}} {{.ConditionZero}} {
 		update["{{.ColName}}"] = table.{{.Name}}
 	}
+{{- end}}
+{{- end}}`
+
+	daoColumnsTmpl    *template.Template
+	daoColumnsTmplRaw = `
+// {{.TName}}Columns the columns that are allowed in query conditions and sort fields, other columns are rejected
+var {{.TName}}Columns = query.NewColumnSchema(
+{{- range .Fields}}
+{{- if .IsSensitive}}
+	query.ColumnRule{Name: "{{.ColName}}", Type: query.{{.QueryType}}}, // sensitive column, set Filter or Sort to true to allow it
+{{- else}}
+	query.ColumnRule{Name: "{{.ColName}}", Type: query.{{.QueryType}}, Filter: true, Sort: true},
+{{- end}}
+{{- end}}
+)`
+
+	daoRelationInterfaceTmpl    *template.Template
+	daoRelationInterfaceTmplRaw = `	GetByIDWithPreload(ctx context.Context, id uint64, associations ...string) (*model.{{.TableName}}, error)
+{{- range .Relations}}{{if .KeyName}}
+	{{.ListMethod}}(ctx context.Context, {{.KeyParam}} {{.KeyType}}) ([]*model.{{.Table}}, error)
+{{- end}}{{end}}`
+
+	daoRelationTmpl    *template.Template
+	daoRelationTmplRaw = `
+// GetByIDWithPreload get a record by id from mysql without cache, and preload the associations,
+// all associations are preloaded if not specified, the associations are {{range $i, $r := .Relations}}{{if $i}}, {{end}}{{$r.Name}}{{end}}
+func (d *{{.TName}}Dao) GetByIDWithPreload(ctx context.Context, id uint64, associations ...string) (*model.{{.TableName}}, error) {
+	if len(associations) == 0 {
+		associations = []string{ {{- range $i, $r := .Relations}}{{if $i}}, {{end}}"{{$r.Name}}"{{end -}} }
+	}
+	db := mysql.GetDB(ctx, d.db)
+	for _, name := range associations {
+		db = db.Preload(name)
+	}
+
+	table := &model.{{.TableName}}{}
+	err := db.Where("id = ?", id).First(table).Error
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+{{- range .Relations}}{{if .KeyName}}
+
+// {{.ListMethod}} list of the associated {{.RawTable}} records by {{.KeyColumn}}
+func (d *{{$.TName}}Dao) {{.ListMethod}}(ctx context.Context, {{.KeyParam}} {{.KeyType}}) ([]*model.{{.Table}}, error) {
+	records := []*model.{{.Table}}{}
+	err := mysql.GetDB(ctx, d.db){{if .JoinTable}}.Joins("JOIN {{.JoinTable}} ON {{.JoinOn}}"){{end}}.
+		Where("{{.KeyColumn}} = ?", {{.KeyParam}}).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+{{- end}}{{end}}`
+
+	daoIndexInterfaceTmpl    *template.Template
+	daoIndexInterfaceTmplRaw = `
+{{- range .Indexes}}
+	{{.Method}}(ctx context.Context, {{.Params}}{{if not .IsUnique}}, params *query.Params{{end}}) ({{if .IsUnique}}*model.{{$.TableName}}, error{{else}}[]*model.{{$.TableName}}, int64, error{{end}})
+{{- end}}`
+
+	daoIndexTmpl    *template.Template
+	daoIndexTmplRaw = `
+{{- range .Indexes}}
+{{- if .IsUnique}}
+
+// {{.Method}} get a record by the unique index {{.Columns}}, the id of the record is cached and the record is got by GetByID,
+// the cached id is deleted if the record does not match any more, if ctx is in a transaction, get from the transaction without cache.
+func (d *{{$.TName}}Dao) {{.Method}}(ctx context.Context, {{.Params}}) (*model.{{$.TableName}}, error) {
+	isInTransaction := mysql.InTransaction(ctx)
+	if !isInTransaction {
+		if id, err := d.cache.GetIndexID(ctx, "{{.Key}}", {{.Args}}); err == nil {
+			if record, err := d.GetByID(ctx, id); err == nil && {{.Match "record"}} {
+				return record, nil
+			}
+			// the indexed columns of the record have been changed or the record has been deleted
+			_ = d.cache.DelIndexID(ctx, "{{.Key}}", {{.Args}})
+		}
+	}
+
+	table := &model.{{$.TableName}}{}
+	err := mysql.GetDB(ctx, d.db).Where("{{.Where}}", {{.Args}}).First(table).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if !isInTransaction {
+		_ = d.cache.SetIndexID(ctx, table.ID, cache.{{$.TableName}}ExpireTime, "{{.Key}}", {{.Args}})
+	}
+	return table, nil
+}
+{{- else}}
+
+// {{.Method}} get records by the index {{.Columns}} and paging, the params are the same as GetByColumns,
+// the records are got from mysql without cache.
+func (d *{{$.TName}}Dao) {{.Method}}(ctx context.Context, {{.Params}}, params *query.Params) ([]*model.{{$.TableName}}, int64, error) {
+	err := check{{$.TableName}}Params(params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return d.getByColumns(ctx, params, func(db *gorm.DB) *gorm.DB {
+		return db.Where("{{.Where}}", {{.Args}})
+	})
+}
+{{- end}}
+{{- end}}`
+
+	handlerIndexStructTmpl    *template.Template
+	handlerIndexStructTmplRaw = `
+{{- range .Indexes}}
+{{- if .IsUnique}}
+
+// Get{{$.TableName}}By{{.Name}}Request request params
+type Get{{$.TableName}}By{{.Name}}Request struct {
+{{- range .Fields}}
+	{{.Name}}  {{.KeyType}} ` + "`" + `form:"{{.JSONName}}" binding:""` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+{{- else}}
+
+// List{{$.TableName}}sBy{{.Name}}Request request params
+type List{{$.TableName}}sBy{{.Name}}Request struct {
+{{- range .Fields}}
+	{{.Name}}  {{.KeyType}} ` + "`" + `json:"{{.JSONName}}" binding:""` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+	query.Params
+}
+{{- end}}
+{{- end}}
+`
+
+	handlerIndexInterfaceTmpl    *template.Template
+	handlerIndexInterfaceTmplRaw = `
+{{- range .Indexes}}
+	{{.Method}}(c *gin.Context)
+{{- end}}`
+
+	routerIndexTmpl    *template.Template
+	routerIndexTmplRaw = `
+{{- range .Indexes}}
+{{- if .IsUnique}}
+	group.GET("/{{$.TName}}/by/{{.Path}}", h.{{.Method}})
+{{- else}}
+	group.POST("/{{$.TName}}/list/by/{{.Path}}", h.{{.Method}})
+{{- end}}
+{{- end}}`
+
+	routerIndexMockTmpl    *template.Template
+	routerIndexMockTmplRaw = `
+{{- range .Indexes}}
+func (u mock) {{.Method}}(c *gin.Context) { return }
+{{- end}}`
+
+	handlerIndexTmpl    *template.Template
+	handlerIndexTmplRaw = `
+{{- range .Indexes}}
+{{- if .IsUnique}}
+
+// {{.Method}} get a record by the unique index {{.Columns}}
+// @Summary get {{$.TName}} by {{.Columns}}
+// @Description get {{$.TName}} detail by the unique index {{.Columns}}
+// @Tags {{$.TName}}
+{{- range .Fields}}
+// @Param {{.JSONName}} query {{.SwaggerType}} true "{{.ColName}}"
+{{- end}}
+// @Accept json
+// @Produce json
+// @Success 200 {object} types.Get{{$.TableName}}ByIDRespond{}
+// @Router /api/v1/{{$.TName}}/by/{{.Path}} [get]
+func (h *{{$.TName}}Handler) {{.Method}}(c *gin.Context) {
+	form := &types.Get{{$.TableName}}By{{.Name}}Request{}
+	err := c.ShouldBindQuery(form)
+	if err != nil {
+		logger.Warn("ShouldBindQuery error: ", logger.Err(err), middleware.GCtxRequestIDField(c))
+		response.Error(c, ecode.InvalidParams)
+		return
+	}
+
+	ctx := middleware.WrapCtx(c)
+	{{$.TName}}, err := h.iDao.{{.Method}}(ctx, {{.FormArgs "form"}})
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("{{.Method}} not found", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.NotFound)
+		} else {
+			logger.Error("{{.Method}} error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Output(c, ecode.InternalServerError.ToHTTPCode())
+		}
+		return
+	}
+
+	data, err := convert{{$.TableName}}({{$.TName}})
+	if err != nil {
+		response.Error(c, ecode.ErrGetByID{{$.TableName}})
+		return
+	}
+
+	response.Success(c, gin.H{"{{$.TName}}": data})
+}
+{{- else}}
+
+// {{.Method}} list of records by the index {{.Columns}} and query parameters
+// @Summary list of {{$.TName}}s by {{.Columns}}
+// @Description list of {{$.TName}}s by the index {{.Columns}}, paging and conditions
+// @Tags {{$.TName}}
+// @accept json
+// @Produce json
+// @Param data body types.List{{$.TableName}}sBy{{.Name}}Request true "query parameters"
+// @Success 200 {object} types.List{{$.TableName}}sRespond{}
+// @Router /api/v1/{{$.TName}}/list/by/{{.Path}} [post]
+func (h *{{$.TName}}Handler) {{.Method}}(c *gin.Context) {
+	form := &types.List{{$.TableName}}sBy{{.Name}}Request{}
+	err := c.ShouldBindJSON(form)
+	if err != nil {
+		logger.Warn("ShouldBindJSON error: ", logger.Err(err), middleware.GCtxRequestIDField(c))
+		response.Error(c, ecode.InvalidParams)
+		return
+	}
+
+	ctx := middleware.WrapCtx(c)
+	{{$.TName}}s, total, err := h.iDao.{{.Method}}(ctx, {{.FormArgs "form"}}, &form.Params)
+	if err != nil {
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("{{.Method}} error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+			response.Error(c, ecode.InvalidParams.WithDetails(err.Error()))
+			return
+		}
+		logger.Error("{{.Method}} error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+		response.Output(c, ecode.InternalServerError.ToHTTPCode())
+		return
+	}
+
+	data, err := convert{{$.TableName}}s({{$.TName}}s)
+	if err != nil {
+		response.Error(c, ecode.ErrList{{$.TableName}})
+		return
+	}
+	nextCursor, err := form.Params.NextCursor({{$.TName}}s)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("form", form), middleware.GCtxRequestIDField(c))
+		response.Error(c, ecode.ErrList{{$.TableName}})
+		return
+	}
+
+	response.Success(c, gin.H{
+		"{{$.TName}}s": data,
+		"total":        total,
+		"nextCursor":   nextCursor,
+	})
+}
+{{- end}}
+{{- end}}`
+
+	handlerPbIndexTmpl    *template.Template
+	handlerPbIndexTmplRaw = `
+{{- range .Indexes}}
+{{- if .IsUnique}}
+
+// {{.Method}} get a record by the unique index {{.Columns}}
+func (h *{{$.TName}}PbHandler) {{.Method}}(ctx context.Context, req *serverNameExampleV1.{{.Message}}Request) (*serverNameExampleV1.{{.Message}}Reply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InvalidParams.Err()
+	}
+
+	record, err := h.{{$.TName}}Dao.{{.Method}}(ctx, {{.PbArgs "req"}})
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("{{.Method}} error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.NotFound.Err()
+		}
+		logger.Error("{{.Method}} error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InternalServerError.Err()
+	}
+
+	data, err := convert{{$.TableName}}Pb(record)
+	if err != nil {
+		logger.Warn("convert{{$.TableName}} error", logger.Err(err), logger.Any("{{$.TName}}", record), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.ErrGetByID{{$.TableName}}.Err()
+	}
+
+	return &serverNameExampleV1.{{.Message}}Reply{
+		{{$.TableName}}: data,
+	}, nil
+}
+{{- else}}
+
+// {{.Method}} list of records by the index {{.Columns}} and query parameters
+func (h *{{$.TName}}PbHandler) {{.Method}}(ctx context.Context, req *serverNameExampleV1.{{.Message}}Request) (*serverNameExampleV1.{{.Message}}Reply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InvalidParams.Err()
+	}
+
+	params := &query.Params{}
+	err = copier.Copy(params, req.Params)
+	if err != nil {
+		return nil, ecode.ErrList{{$.TableName}}.Err()
+	}
+	params.Size = int(req.Params.Limit)
+
+	records, total, err := h.{{$.TName}}Dao.{{.Method}}(ctx, {{.PbArgs "req"}}, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("{{.Method}} error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+			return nil, ecode.InvalidParams.WithDetails(err.Error()).Err()
+		}
+		logger.Error("{{.Method}} error", logger.Err(err), logger.Any("req", req), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.InternalServerError.Err()
+	}
+
+	{{$.TName}}s := []*serverNameExampleV1.{{$.TableName}}{}
+	for _, record := range records {
+		data, err := convert{{$.TableName}}Pb(record)
+		if err != nil {
+			logger.Warn("convert{{$.TableName}} error", logger.Err(err), logger.Any("id", record.ID), middleware.CtxRequestIDField(ctx))
+			continue
+		}
+		{{$.TName}}s = append({{$.TName}}s, data)
+	}
+	nextCursor, err := params.NextCursor(records)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("params", params), middleware.CtxRequestIDField(ctx))
+		return nil, ecode.ErrList{{$.TableName}}.Err()
+	}
+
+	return &serverNameExampleV1.{{.Message}}Reply{
+		Total:        total,
+		{{$.TableName}}s: {{$.TName}}s,
+		NextCursor:   nextCursor,
+	}, nil
+}
+{{- end}}
+{{- end}}`
+
+	serviceIndexTmpl    *template.Template
+	serviceIndexTmplRaw = `
+{{- range .Indexes}}
+{{- if .IsUnique}}
+
+// {{.Method}} get a record by the unique index {{.Columns}}
+func (s *{{$.TName}}) {{.Method}}(ctx context.Context, req *serverNameExampleV1.{{.Message}}Request) (*serverNameExampleV1.{{.Message}}Reply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInvalidParams.Err()
+	}
+
+	ctx = context.WithValue(ctx, interceptor.ContextRequestIDKey, interceptor.ServerCtxRequestID(ctx)) //nolint
+	record, err := s.iDao.{{.Method}}(ctx, {{.PbArgs "req"}})
+	if err != nil {
+		if errors.Is(err, query.ErrNotFound) {
+			logger.Warn("{{.Method}} error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+			return nil, ecode.StatusNotFound.Err()
+		}
+		logger.Error("{{.Method}} error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInternalServerError.ToRPCErr()
+	}
+
+	data, err := convert{{$.TableName}}(record)
+	if err != nil {
+		logger.Warn("convert{{$.TableName}} error", logger.Err(err), logger.Any("{{$.TName}}", record), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusGetByID{{$.TableName}}.Err()
+	}
+
+	return &serverNameExampleV1.{{.Message}}Reply{ {{- $.TableName}}: data}, nil
+}
+{{- else}}
+
+// {{.Method}} list of records by the index {{.Columns}} and query parameters
+func (s *{{$.TName}}) {{.Method}}(ctx context.Context, req *serverNameExampleV1.{{.Message}}Request) (*serverNameExampleV1.{{.Message}}Reply, error) {
+	err := req.Validate()
+	if err != nil {
+		logger.Warn("req.Validate error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInvalidParams.Err()
+	}
+
+	params := &query.Params{}
+	err = copier.Copy(params, req.Params)
+	if err != nil {
+		return nil, ecode.StatusList{{$.TableName}}.Err()
+	}
+	params.Size = int(req.Params.Limit)
+
+	ctx = context.WithValue(ctx, interceptor.ContextRequestIDKey, interceptor.ServerCtxRequestID(ctx)) //nolint
+	records, total, err := s.iDao.{{.Method}}(ctx, {{.PbArgs "req"}}, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "query params error:") {
+			logger.Warn("{{.Method}} error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+			return nil, ecode.StatusInvalidParams.Err(ecode.Any("err", err))
+		}
+		logger.Error("{{.Method}} error", logger.Err(err), logger.Any("req", req), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusInternalServerError.ToRPCErr()
+	}
+
+	{{$.TName}}s := []*serverNameExampleV1.{{$.TableName}}{}
+	for _, record := range records {
+		data, err := convert{{$.TableName}}(record)
+		if err != nil {
+			logger.Warn("convert{{$.TableName}} error", logger.Err(err), logger.Any("id", record.ID), interceptor.ServerCtxRequestIDField(ctx))
+			continue
+		}
+		{{$.TName}}s = append({{$.TName}}s, data)
+	}
+	nextCursor, err := params.NextCursor(records)
+	if err != nil {
+		logger.Warn("NextCursor error", logger.Err(err), logger.Any("params", params), interceptor.ServerCtxRequestIDField(ctx))
+		return nil, ecode.StatusList{{$.TableName}}.Err()
+	}
+
+	return &serverNameExampleV1.{{.Message}}Reply{
+		Total:        total,
+		{{$.TableName}}s: {{$.TName}}s,
+		NextCursor:   nextCursor,
+	}, nil
+}
+{{- end}}
 {{- end}}`
 
 	handlerCreateStructTmpl    *template.Template
@@ -52,7 +497,7 @@ import (
 // Create{{.TableName}}Request request params
 type Create{{.TableName}}Request struct {
 {{- range .Fields}}
-	{{.Name}}  {{.GoType}} ` + "`" + `json:"{{.JSONName}}" binding:""` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
+	{{.Name}}  {{.GoType}} ` + "`" + `json:"{{.JSONName}}" binding:"{{.BindingRules}}"` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
 {{- end}}
 }
 `
@@ -62,7 +507,7 @@ type Create{{.TableName}}Request struct {
 // Update{{.TableName}}ByIDRequest request params
 type Update{{.TableName}}ByIDRequest struct {
 {{- range .Fields}}
-	{{.Name}}  {{.GoType}} ` + "`" + `json:"{{.JSONName}}" binding:""` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
+	{{.Name}}  {{.GoType}} ` + "`" + `json:"{{.JSONName}}" binding:"{{.UpdateBindingRules}}"` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
 {{- end}}
 }
 `
@@ -74,7 +519,19 @@ type {{.TableName}}ObjDetail struct {
 {{- range .Fields}}
 	{{.Name}}  {{.GoType}} ` + "`" + `json:"{{.JSONName}}"` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
 {{- end}}
-}`
+{{- range .Relations}}
+	{{.Name}}  {{if .IsMany}}[]{{end}}*{{.Nested}} ` + "`" + `json:"{{.JSONName}},omitempty"` + "`" + `
+{{- end}}
+}
+{{- range .Relations}}
+
+// {{.Nested}} detail of the associated {{.RawTable}}
+type {{.Nested}} struct {
+{{- range .Fields}}
+	{{.Name}}  {{.GoType}} ` + "`" + `json:"{{.JSONName}}"` + "`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+{{- end}}`
 
 	modelJSONTmpl    *template.Template
 	modelJSONTmplRaw = `{
@@ -118,6 +575,22 @@ service {{.TName}} {
 
   // list of {{.TName}} by query parameters
   rpc List(List{{.TableName}}Request) returns (List{{.TableName}}Reply) {}
+{{- range .Indexes}}
+
+  {{if .IsUnique}}// get {{$.TName}} by {{.Columns}}{{else}}// list of {{$.TName}} by {{.Columns}} and query parameters{{end}}
+  rpc {{.Method}}({{.Message}}Request) returns ({{.Message}}Reply) {}
+{{- end}}
+{{- if .SoftDelete}}
+
+  // restore a deleted {{.TName}} by id
+  rpc Restore(Restore{{.TableName}}Request) returns (Restore{{.TableName}}Reply) {}
+
+  // list of deleted {{.TName}} by query parameters
+  rpc ListDeleted(ListDeleted{{.TableName}}Request) returns (ListDeleted{{.TableName}}Reply) {}
+
+  // permanently delete a deleted {{.TName}} by id
+  rpc Purge(Purge{{.TableName}}Request) returns (Purge{{.TableName}}Reply) {}
+{{- end}}
 }
 
 // Some notes on defining fields under message:
@@ -184,7 +657,65 @@ message List{{.TableName}}Request {
 message List{{.TableName}}Reply {
   int64 total =1;
   repeated {{.TableName}} {{.TName}}s = 2;
+  string nextCursor = 3; // cursor of the next page, empty if there are no more records
+}
+{{- range .Indexes}}
+{{- $index := .}}
+{{- if .IsUnique}}
+
+message {{.Message}}Request {
+{{- range $i, $v := .Fields}}
+  {{$v.ProtoType}} {{$v.JSONName}} = {{$index.FieldNumber $i}};
+{{- end}}
+}
+
+message {{.Message}}Reply {
+  {{$.TableName}} {{$.TName}} = 1;
+}
+{{- else}}
+
+message {{.Message}}Request {
+{{- range $i, $v := .Fields}}
+  {{$v.ProtoType}} {{$v.JSONName}} = {{$index.FieldNumber $i}};
+{{- end}}
+  types.Params params = {{.ParamsNumber}};
+}
+
+message {{.Message}}Reply {
+  int64 total =1;
+  repeated {{$.TableName}} {{$.TName}}s = 2;
+  string nextCursor = 3; // cursor of the next page, empty if there are no more records
+}
+{{- end}}
+{{- end}}
+{{- if .SoftDelete}}
+
+message Restore{{.TableName}}Request {
+  uint64   id =1;
+}
+
+message Restore{{.TableName}}Reply {
+
+}
+
+message ListDeleted{{.TableName}}Request {
+  types.Params params = 1;
+}
+
+message ListDeleted{{.TableName}}Reply {
+  int64 total =1;
+  repeated {{.TableName}} {{.TName}}s = 2;
+  string nextCursor = 3; // cursor of the next page, empty if there are no more records
+}
+
+message Purge{{.TableName}}Request {
+  uint64   id =1;
 }
+
+message Purge{{.TableName}}Reply {
+
+}
+{{- end}}
 `
 
 	protoFileForWebTmpl    *template.Template
@@ -363,6 +894,101 @@ service {{.TName}} {
       //}
     };
   }
+{{- range .Indexes}}
+{{- if .IsUnique}}
+
+  // get {{$.TName}} by {{.Columns}}
+  rpc {{.Method}}({{.Message}}Request) returns ({{.Message}}Reply) {
+    option (google.api.http) = {
+      get: "/api/v1/{{$.TName}}/by/{{.Path}}"
+    };
+    option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+      summary: "get {{$.TName}} by {{.Columns}}",
+      description: "get {{$.TName}} detail by the unique index {{.Columns}}",
+      //security: {
+      //  security_requirement: {
+      //    key: "BearerAuth";
+      //    value: {}
+      //  }
+      //}
+    };
+  }
+{{- else}}
+
+  // list of {{$.TName}} by {{.Columns}} and query parameters
+  rpc {{.Method}}({{.Message}}Request) returns ({{.Message}}Reply) {
+    option (google.api.http) = {
+      post: "/api/v1/{{$.TName}}/list/by/{{.Path}}"
+      body: "*"
+    };
+    option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+      summary: "list of {{$.TName}}s by {{.Columns}}",
+      description: "list of {{$.TName}}s by the index {{.Columns}}, paging and conditions",
+      //security: {
+      //  security_requirement: {
+      //    key: "BearerAuth";
+      //    value: {}
+      //  }
+      //}
+    };
+  }
+{{- end}}
+{{- end}}
+{{- if .SoftDelete}}
+
+  // restore a deleted {{.TName}} by id
+  rpc Restore(Restore{{.TableName}}Request) returns (Restore{{.TableName}}Reply) {
+    option (google.api.http) = {
+      put: "/api/v1/{{.TName}}/{id}/restore"
+      body: "*"
+    };
+    option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+      summary: "restore {{.TName}}",
+      description: "restore a deleted {{.TName}} by id",
+      //security: {
+      //  security_requirement: {
+      //    key: "BearerAuth";
+      //    value: {}
+      //  }
+      //}
+    };
+  }
+
+  // list of deleted {{.TName}} by query parameters
+  rpc ListDeleted(ListDeleted{{.TableName}}Request) returns (ListDeleted{{.TableName}}Reply) {
+    option (google.api.http) = {
+      post: "/api/v1/{{.TName}}/list/deleted"
+      body: "*"
+    };
+    option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+      summary: "list of deleted {{.TName}}s by parameters",
+      description: "list of deleted {{.TName}}s by paging and conditions",
+      //security: {
+      //  security_requirement: {
+      //    key: "BearerAuth";
+      //    value: {}
+      //  }
+      //}
+    };
+  }
+
+  // permanently delete a deleted {{.TName}} by id
+  rpc Purge(Purge{{.TableName}}Request) returns (Purge{{.TableName}}Reply) {
+    option (google.api.http) = {
+      delete: "/api/v1/{{.TName}}/{id}/purge"
+    };
+    option (grpc.gateway.protoc_gen_openapiv2.options.openapiv2_operation) = {
+      summary: "purge {{.TName}}",
+      description: "permanently delete a deleted {{.TName}} by id",
+      //security: {
+      //  security_requirement: {
+      //    key: "BearerAuth";
+      //    value: {}
+      //  }
+      //}
+    };
+  }
+{{- end}}
 }
 
 // Some notes on defining fields under message:
@@ -439,20 +1065,78 @@ message List{{.TableName}}Request {
 message List{{.TableName}}Reply {
   int64 total =1;
   repeated {{.TableName}} {{.TName}}s = 2;
+  string nextCursor = 3; // cursor of the next page, empty if there are no more records
 }
+{{- range .Indexes}}
+{{- $index := .}}
+{{- if .IsUnique}}
+
+message {{.Message}}Request {
+{{- range $i, $v := .Fields}}
+  {{$v.ProtoType}} {{$v.JSONName}} = {{$index.FieldNumber $i}} [(tagger.tags) = "form:\"{{$v.JSONName}}\"" ];
+{{- end}}
+}
+
+message {{.Message}}Reply {
+  {{$.TableName}} {{$.TName}} = 1;
+}
+{{- else}}
+
+message {{.Message}}Request {
+{{- range $i, $v := .Fields}}
+  {{$v.ProtoType}} {{$v.JSONName}} = {{$index.FieldNumber $i}};
+{{- end}}
+  types.Params params = {{.ParamsNumber}};
+}
+
+message {{.Message}}Reply {
+  int64 total =1;
+  repeated {{$.TableName}} {{$.TName}}s = 2;
+  string nextCursor = 3; // cursor of the next page, empty if there are no more records
+}
+{{- end}}
+{{- end}}
+{{- if .SoftDelete}}
+
+message Restore{{.TableName}}Request {
+  uint64   id =1 [(tagger.tags) = "uri:\"id\"" ];
+}
+
+message Restore{{.TableName}}Reply {
+
+}
+
+message ListDeleted{{.TableName}}Request {
+  types.Params params = 1;
+}
+
+message ListDeleted{{.TableName}}Reply {
+  int64 total =1;
+  repeated {{.TableName}} {{.TName}}s = 2;
+  string nextCursor = 3; // cursor of the next page, empty if there are no more records
+}
+
+message Purge{{.TableName}}Request {
+  uint64   id =1 [(tagger.tags) = "uri:\"id\"" ];
+}
+
+message Purge{{.TableName}}Reply {
+
+}
+{{- end}}
 `
 
 	protoMessageCreateTmpl    *template.Template
 	protoMessageCreateTmplRaw = `message Create{{.TableName}}Request {
 {{- range $i, $v := .Fields}}
-	{{$v.GoType}} {{$v.JSONName}} = {{$v.AddOne $i}}; {{if $v.Comment}} // {{$v.Comment}}{{end}}
+	{{$v.GoType}} {{$v.JSONName}} = {{$v.AddOne $i}}{{$v.ValidateRules}}; {{if $v.Comment}} // {{$v.Comment}}{{end}}
 {{- end}}
 }`
 
 	protoMessageUpdateTmpl    *template.Template
 	protoMessageUpdateTmplRaw = `message Update{{.TableName}}ByIDRequest {
 {{- range $i, $v := .Fields}}
-	{{$v.GoType}} {{$v.JSONName}} = {{$v.AddOneWithTag $i}}; {{if $v.Comment}} // {{$v.Comment}}{{end}}
+	{{$v.GoType}} {{$v.JSONName}} = {{$v.AddOneWithTag $i}}{{$v.UpdateValidateRules}}; {{if $v.Comment}} // {{$v.Comment}}{{end}}
 {{- end}}
 }`
 
@@ -461,7 +1145,19 @@ message List{{.TableName}}Reply {
 {{- range $i, $v := .Fields}}
 	{{$v.GoType}} {{$v.JSONName}} = {{$v.AddOne $i}}; {{if $v.Comment}} // {{$v.Comment}}{{end}}
 {{- end}}
-}`
+{{- range $i, $r := .Relations}}
+	{{if $r.IsMany}}repeated {{end}}{{$r.Nested}} {{$r.JSONName}} = {{$.RelationNumber $i}};
+{{- end}}
+}
+{{- range .Relations}}
+
+// {{.Nested}} detail of the associated {{.RawTable}}
+message {{.Nested}} {
+{{- range $i, $v := .Fields}}
+	{{$v.GoType}} {{$v.JSONName}} = {{$v.AddOne $i}}; {{if $v.Comment}} // {{$v.Comment}}{{end}}
+{{- end}}
+}
+{{- end}}`
 
 	serviceStructTmpl    *template.Template
 	serviceStructTmplRaw = `
@@ -503,73 +1199,50 @@ message List{{.TableName}}Reply {
 	tmplParseOnce sync.Once
 )
 
+// builtinTemplates the built-in templates, the name is the file name of the template which overrides it
+// in the template directory, see WithTemplateDir.
+var builtinTemplates = []struct {
+	name string
+	tmpl **template.Template
+	raw  *string
+}{
+	{"modelStruct.tmpl", &modelStructTmpl, &modelStructTmplRaw},
+	{"model.tmpl", &modelTmpl, &modelTmplRaw},
+	{"updateField.tmpl", &updateFieldTmpl, &updateFieldTmplRaw},
+	{"daoColumns.tmpl", &daoColumnsTmpl, &daoColumnsTmplRaw},
+	{"daoRelationInterface.tmpl", &daoRelationInterfaceTmpl, &daoRelationInterfaceTmplRaw},
+	{"daoRelation.tmpl", &daoRelationTmpl, &daoRelationTmplRaw},
+	{"daoIndexInterface.tmpl", &daoIndexInterfaceTmpl, &daoIndexInterfaceTmplRaw},
+	{"daoIndex.tmpl", &daoIndexTmpl, &daoIndexTmplRaw},
+	{"handlerIndexStruct.tmpl", &handlerIndexStructTmpl, &handlerIndexStructTmplRaw},
+	{"handlerIndexInterface.tmpl", &handlerIndexInterfaceTmpl, &handlerIndexInterfaceTmplRaw},
+	{"routerIndex.tmpl", &routerIndexTmpl, &routerIndexTmplRaw},
+	{"routerIndexMock.tmpl", &routerIndexMockTmpl, &routerIndexMockTmplRaw},
+	{"handlerIndex.tmpl", &handlerIndexTmpl, &handlerIndexTmplRaw},
+	{"handlerPbIndex.tmpl", &handlerPbIndexTmpl, &handlerPbIndexTmplRaw},
+	{"serviceIndex.tmpl", &serviceIndexTmpl, &serviceIndexTmplRaw},
+	{"handlerCreateStruct.tmpl", &handlerCreateStructTmpl, &handlerCreateStructTmplRaw},
+	{"handlerUpdateStruct.tmpl", &handlerUpdateStructTmpl, &handlerUpdateStructTmplRaw},
+	{"handlerDetailStruct.tmpl", &handlerDetailStructTmpl, &handlerDetailStructTmplRaw},
+	{"modelJSON.tmpl", &modelJSONTmpl, &modelJSONTmplRaw},
+	{"protoFile.tmpl", &protoFileTmpl, &protoFileTmplRaw},
+	{"protoFileForWeb.tmpl", &protoFileForWebTmpl, &protoFileForWebTmplRaw},
+	{"protoMessageCreate.tmpl", &protoMessageCreateTmpl, &protoMessageCreateTmplRaw},
+	{"protoMessageUpdate.tmpl", &protoMessageUpdateTmpl, &protoMessageUpdateTmplRaw},
+	{"protoMessageDetail.tmpl", &protoMessageDetailTmpl, &protoMessageDetailTmplRaw},
+	{"serviceCreateStruct.tmpl", &serviceCreateStructTmpl, &serviceCreateStructTmplRaw},
+	{"serviceUpdateStruct.tmpl", &serviceUpdateStructTmpl, &serviceUpdateStructTmplRaw},
+	{"serviceStruct.tmpl", &serviceStructTmpl, &serviceStructTmplRaw},
+}
+
 func initTemplate() {
 	tmplParseOnce.Do(func() {
-		var err, errSum error
-
-		modelStructTmpl, err = template.New("goStruct").Parse(modelStructTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(err, "modelStructTmplRaw")
-		}
-		modelTmpl, err = template.New("goFile").Parse(modelTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "modelTmplRaw:"+err.Error())
-		}
-		updateFieldTmpl, err = template.New("goUpdateField").Parse(updateFieldTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "updateFieldTmplRaw:"+err.Error())
-		}
-		handlerCreateStructTmpl, err = template.New("goPostStruct").Parse(handlerCreateStructTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "handlerCreateStructTmplRaw:"+err.Error())
-		}
-		handlerUpdateStructTmpl, err = template.New("goPutStruct").Parse(handlerUpdateStructTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "handlerUpdateStructTmplRaw:"+err.Error())
-		}
-		handlerDetailStructTmpl, err = template.New("goGetStruct").Parse(handlerDetailStructTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "handlerDetailStructTmplRaw:"+err.Error())
-		}
-		modelJSONTmpl, err = template.New("modelJSON").Parse(modelJSONTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "modelJSONTmplRaw:"+err.Error())
-		}
-		protoFileTmpl, err = template.New("protoFile").Parse(protoFileTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "protoFileTmplRaw:"+err.Error())
-		}
-		protoFileForWebTmpl, err = template.New("protoFileForWeb").Parse(protoFileForWebTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "protoFileForWebTmplRaw:"+err.Error())
-		}
-		protoMessageCreateTmpl, err = template.New("protoMessageCreate").Parse(protoMessageCreateTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "protoMessageCreateTmplRaw:"+err.Error())
-		}
-		protoMessageUpdateTmpl, err = template.New("protoMessageUpdate").Parse(protoMessageUpdateTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "protoMessageUpdateTmplRaw:"+err.Error())
-		}
-		protoMessageDetailTmpl, err = template.New("protoMessageDetail").Parse(protoMessageDetailTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "protoMessageDetailTmplRaw:"+err.Error())
-		}
-		serviceCreateStructTmpl, err = template.New("serviceCreateStruct").Parse(serviceCreateStructTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "serviceCreateStructTmplRaw:"+err.Error())
-		}
-		serviceUpdateStructTmpl, err = template.New("serviceUpdateStruct").Parse(serviceUpdateStructTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "serviceUpdateStructTmplRaw:"+err.Error())
-		}
-		serviceStructTmpl, err = template.New("serviceStruct").Parse(serviceStructTmplRaw)
-		if err != nil {
-			errSum = errors.Wrap(errSum, "serviceStructTmplRaw:"+err.Error())
-		}
-
-		if errSum != nil {
-			panic(errSum)
+		for _, v := range builtinTemplates {
+			tmpl, err := template.New(v.name).Parse(*v.raw)
+			if err != nil {
+				panic(errors.Wrap(err, v.name))
+			}
+			*v.tmpl = tmpl
 		}
 	})
 }
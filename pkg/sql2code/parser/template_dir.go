@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// tmplOverrides the templates loaded from the template directory, built-in template name -> template
+type tmplOverrides map[string]*template.Template
+
+// get the template which overrides the built-in template, return the built-in template if it is not overridden
+func (o tmplOverrides) get(tmpl *template.Template) *template.Template {
+	if t, ok := o[tmpl.Name()]; ok {
+		return t
+	}
+	return tmpl
+}
+
+// loadTemplateDir load the *.tmpl files in the directory, the file name must be one of the built-in template names,
+// the template is named with the file path, so the parse and execute errors report the file name and line number.
+func loadTemplateDir(dir string) (tmplOverrides, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read template dir failed, %v", err)
+	}
+
+	isBuiltin := make(map[string]bool, len(builtinTemplates))
+	for _, v := range builtinTemplates {
+		isBuiltin[v.name] = true
+	}
+
+	overrides := tmplOverrides{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".tmpl") {
+			continue
+		}
+		file := filepath.Join(dir, name)
+		if !isBuiltin[name] {
+			return nil, fmt.Errorf("unknown template file %s, the file name must be one of the built-in template names", file)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read template file failed, %v", err)
+		}
+		tmpl, err := template.New(file).Parse(string(data))
+		if err != nil {
+			return nil, err
+		}
+		overrides[name] = tmpl
+	}
+
+	return overrides, nil
+}
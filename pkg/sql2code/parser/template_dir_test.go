@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSQL_templateDir(t *testing.T) {
+	sql := "CREATE TABLE `user` (`id` bigint NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` varchar(50) NOT NULL);"
+	dir := t.TempDir()
+	writeFile := func(name string, content string) {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	writeFile("daoColumns.tmpl", `var {{.TName}}Columns = []string{
+{{- range .Fields}}
+	"{{.ColName}}",
+{{- end}}
+}`)
+	writeFile("README.md", "the other files are ignored")
+	codes, err := ParseSQL(sql, WithTemplateDir(dir))
+	assert.Nil(t, err)
+	assert.Equal(t, "var userColumns = []string{\n\t\"id\",\n\t\"name\",\n}", codes[CodeTypeDAOColumns])
+	assert.Contains(t, codes[CodeTypeModel], "type User struct {") // built-in template
+
+	// the error reports the file name and line number
+	writeFile("daoColumns.tmpl", "var {{.TName}}Columns = []string{\n{{if}}\n}")
+	_, err = ParseSQL(sql, WithTemplateDir(dir))
+	assert.ErrorContains(t, err, filepath.Join(dir, "daoColumns.tmpl")+":2:")
+
+	writeFile("daoColumns.tmpl", "var {{.TName}}Columns = []string{\n}\n{{.Foo}}")
+	_, err = ParseSQL(sql, WithTemplateDir(dir))
+	assert.ErrorContains(t, err, filepath.Join(dir, "daoColumns.tmpl")+":3:")
+
+	assert.Nil(t, os.Remove(filepath.Join(dir, "daoColumns.tmpl")))
+	writeFile("daoColumn.tmpl", "")
+	_, err = ParseSQL(sql, WithTemplateDir(dir))
+	assert.ErrorContains(t, err, "unknown template file")
+
+	_, err = ParseSQL(sql, WithTemplateDir(filepath.Join(dir, "notfound")))
+	assert.Error(t, err)
+}
+
+func Test_tmplOverrides_get(t *testing.T) {
+	initTemplate()
+	var overrides tmplOverrides
+	assert.Equal(t, modelTmpl, overrides.get(modelTmpl))
+
+	overrides = tmplOverrides{modelTmpl.Name(): daoColumnsTmpl}
+	assert.Equal(t, daoColumnsTmpl, overrides.get(modelTmpl))
+	assert.Equal(t, modelStructTmpl, overrides.get(modelStructTmpl))
+}
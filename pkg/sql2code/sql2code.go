@@ -17,8 +17,11 @@ type Args struct {
 
 	DDLFile string // DDL file
 
-	DBDsn   string // connecting to mysql's dsn
-	DBTable string
+	DBDsn    string // connecting to mysql's dsn
+	DBTable  string
+	DBDriver string // database driver of the sql and dsn, mysql (default) or postgres
+
+	RelatedTables []string // the other tables of the db, used to generate the associations with DBTable
 
 	Package        string // specify the package name (only valid for model types)
 	GormType       bool   // whether to display the gorm type name (only valid for model type codes)
@@ -34,6 +37,11 @@ type Args struct {
 	ColumnPrefix   string
 	NoNullType     bool
 	NullStyle      string
+
+	ShardingKey       string // column name of the sharding key, the tables containing the column are sharded
+	ShardingAlgorithm string // sharding algorithm, e.g. mod:64, hash:16, month:2023-01:2024-12
+
+	TemplateDir string // directory of the <name>.tmpl files which override the built-in templates of the same name
 }
 
 func (a *Args) checkValid() error {
@@ -59,7 +67,7 @@ func getSQL(args *Args) (string, error) {
 		if args.DBTable == "" {
 			return sql, errors.New("miss mysql table")
 		}
-		sqlStr, err := parser.GetTableInfo(args.DBDsn, args.DBTable)
+		sqlStr, err := getTableInfo(args.DBDriver)(args.DBDsn, args.DBTable)
 		if err != nil {
 			return sql, err
 		}
@@ -69,6 +77,26 @@ func getSQL(args *Args) (string, error) {
 	return sql, errors.New("no SQL input(-sql|-f|-db-dsn)")
 }
 
+// get the ddl of the related tables from db, the tables which are not found are ignored
+func getRelatedSQL(args *Args) string {
+	if args.SQL != "" || args.DDLFile != "" || args.DBDsn == "" {
+		return ""
+	}
+
+	sql := ""
+	for _, table := range args.RelatedTables {
+		if table == "" || table == args.DBTable {
+			continue
+		}
+		sqlStr, err := getTableInfo(args.DBDriver)(args.DBDsn, table)
+		if err != nil {
+			continue
+		}
+		sql += sqlStr + "\n"
+	}
+	return sql
+}
+
 func getOptions(args *Args) []parser.Option {
 	var opts []parser.Option
 
@@ -119,10 +147,32 @@ func getOptions(args *Args) []parser.Option {
 	if args.ForceTableName {
 		opts = append(opts, parser.WithForceTableName())
 	}
+	if args.ShardingKey != "" {
+		opts = append(opts, parser.WithSharding(args.ShardingKey, args.ShardingAlgorithm))
+	}
+	if args.TemplateDir != "" {
+		opts = append(opts, parser.WithTemplateDir(args.TemplateDir))
+	}
+	if isPostgresql(args.DBDriver) {
+		opts = append(opts, parser.WithDBDriver(parser.DBDriverPostgresql))
+	} else if args.DBDriver != "" {
+		opts = append(opts, parser.WithDBDriver(args.DBDriver))
+	}
 
 	return opts
 }
 
+func getTableInfo(driver string) func(dsn, tableName string) (string, error) {
+	if isPostgresql(driver) {
+		return parser.GetPostgresqlTableInfo
+	}
+	return parser.GetTableInfo
+}
+
+func isPostgresql(driver string) bool {
+	return driver == parser.DBDriverPostgresql || driver == "postgresql"
+}
+
 // GenerateOne generate gorm code from sql, which can be obtained from parameters, files and db, with priority from highest to lowest
 func GenerateOne(args *Args) (string, error) {
 	codes, err := Generate(args)
@@ -153,6 +203,9 @@ func Generate(args *Args) (map[string]string, error) {
 	}
 
 	opt := getOptions(args)
+	if relatedSQL := getRelatedSQL(args); relatedSQL != "" {
+		opt = append(opt, parser.WithRelatedSQL(relatedSQL))
+	}
 
 	return parser.ParseSQL(sql, opt...)
 }
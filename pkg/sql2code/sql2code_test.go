@@ -85,6 +85,14 @@ func TestGenerate(t *testing.T) {
 			}},
 			wantErr: false,
 		},
+		{
+			name: "postgresql sql form param",
+			args: args{args: &Args{
+				SQL:      "CREATE TABLE \"user\" (id bigserial PRIMARY KEY, name varchar(50) NOT NULL, is_active boolean);",
+				DBDriver: "postgres",
+			}},
+			wantErr: false,
+		},
 		//{
 		//	name: "sql from db",
 		//	args: args{args: &Args{
@@ -125,6 +133,14 @@ func TestGenerateError(t *testing.T) {
 	_, err = Generate(a)
 	assert.Error(t, err)
 
+	a = &Args{DBDsn: "host=127.0.0.1 port=1 user=root dbname=test", DBTable: "user", DBDriver: "postgres"}
+	_, err = Generate(a)
+	assert.Error(t, err)
+
+	a = &Args{DDLFile: "test.sql", DBDriver: "sqlite"}
+	_, err = Generate(a)
+	assert.Error(t, err)
+
 	a = &Args{DDLFile: "test.sql", CodeType: "unknown"}
 	_, err = GenerateOne(a)
 	t.Log(err)
@@ -143,6 +159,10 @@ func Test_getOptions(t *testing.T) {
 		ColumnPrefix:   "ColumnPrefix",
 		NoNullType:     true,
 		NullStyle:      "sql",
+
+		ShardingKey:       "user_id",
+		ShardingAlgorithm: "mod:64",
+		TemplateDir:       "templates",
 	}
 
 	o := getOptions(a)